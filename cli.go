@@ -0,0 +1,241 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/labstack/echo/v4"
+)
+
+const usage = `Usage: did-resolver [command]
+
+Without a command, the resolver prints its configuration and serves requests.
+
+Commands:
+  serve                        serve requests, the same as without a command
+  resolve <did-url>            resolve DID or dereference DID URL in-process and print the result
+  dereference <did-url>        alias of resolve
+  check-endpoints              run health checks of the configured gRPC endpoints once
+  config validate              parse the configuration and report every problem
+  healthcheck                  check that the resolver listener responds, for Docker HEALTHCHECK
+  export                       export DIDs from the ledger to a snapshot, see export -h
+  loadtest                     replay a DID URL mix against a running resolver, see loadtest -h
+`
+
+// Number of redirects followed by the resolve command, e.g. to the canonical URL of a resource
+const maxRedirects = 5
+
+func runCommand(command string, args []string) error {
+	switch command {
+	case "serve":
+		serve()
+		return nil
+	case "resolve", "dereference":
+		return resolve(command, args)
+	case "check-endpoints":
+		return checkEndpoints()
+	case "config":
+		if len(args) != 1 || args[0] != "validate" {
+			return errors.New("usage: did-resolver config validate")
+		}
+		return validateConfig()
+	case "healthcheck":
+		return healthcheck(args)
+	case "export":
+		return export(args)
+	case "loadtest":
+		return loadtest(args)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// resolve runs the request through the same handlers as the listener, without starting it
+func resolve(command string, args []string) error {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	accept := flags.String("accept", "", "value of the Accept header, e.g. application/did+ld+json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	// Flags may also follow the DID URL
+	if flags.NArg() == 0 {
+		return fmt.Errorf("usage: did-resolver %s <did-url> [--accept <media type>]", command)
+	}
+	didUrl := flags.Arg(0)
+	if err := flags.Parse(flags.Args()[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+
+	config, err := types.LoadConfig()
+	if err != nil {
+		return err
+	}
+	types.SetupLogger(config)
+	// The recording belongs to the listener: creating it here would truncate the traffic recorded by a running resolver
+	if config.LedgerRecordPath != "" {
+		fmt.Fprintln(os.Stderr, "LEDGER_RECORD_PATH is ignored by the resolve command")
+		config.LedgerRecordPath = ""
+	}
+	e, _ := newServer(config)
+
+	// Fragment is a part of the DID URL for the resolver, so it's escaped to reach the handlers
+	target := types.RESOLVER_PATH + strings.ReplaceAll(didUrl, "#", "%23")
+	for range maxRedirects {
+		request := httptest.NewRequest(http.MethodGet, target, nil)
+		if *accept != "" {
+			request.Header.Set(echo.HeaderAccept, *accept)
+		}
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, request)
+
+		location := recorder.Header().Get("Location")
+		if recorder.Code >= 300 && recorder.Code < 400 && strings.HasPrefix(location, "/") {
+			fmt.Fprintf(os.Stderr, "%d: redirected to %s\n", recorder.Code, location)
+			target = location
+			continue
+		}
+
+		if _, err := io.Copy(os.Stdout, recorder.Body); err != nil {
+			return err
+		}
+		fmt.Println()
+		if recorder.Code >= http.StatusBadRequest {
+			return fmt.Errorf("resolution failed with status %d", recorder.Code)
+		}
+		return nil
+	}
+	return fmt.Errorf("more than %d redirects", maxRedirects)
+}
+
+// checkEndpoints reports health of all configured endpoints and fails if any of them is unhealthy
+func checkEndpoints() error {
+	config, err := types.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	types.SetupLogger(config)
+
+	checks := services.CheckEndpoints(config)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAMESPACE\tROLE\tENDPOINT\tSTATUS\tDURATION")
+	unhealthy := 0
+	for _, check := range checks {
+		status := "healthy"
+		if !check.Healthy {
+			status = "unhealthy"
+			unhealthy++
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", check.Namespace, check.Endpoint.Role, check.Endpoint.URL, status, check.Duration.Round(time.Millisecond))
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	if unhealthy != 0 {
+		return fmt.Errorf("%d of %d endpoints are unhealthy", unhealthy, len(checks))
+	}
+	return nil
+}
+
+// validateConfig reports all problems of the configuration, including the snapshot and the ledger recording if they're configured
+func validateConfig() error {
+	var problems []error
+	config, err := types.LoadConfig()
+	if err != nil {
+		problems = append(problems, configProblems(err)...)
+	} else {
+		if config.SnapshotPath != "" {
+			if _, err := services.ReadSnapshot(config.SnapshotPath); err != nil {
+				problems = append(problems, fmt.Errorf("invalid snapshot %s: %w", config.SnapshotPath, err))
+			}
+		}
+		if config.LedgerReplayPath != "" {
+			if _, err := services.ReadLedgerRecording(config.LedgerReplayPath); err != nil {
+				problems = append(problems, fmt.Errorf("invalid ledger recording %s: %w", config.LedgerReplayPath, err))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		fmt.Println("Configuration is valid")
+		return nil
+	}
+	for _, problem := range problems {
+		fmt.Println("-", problem)
+	}
+	return fmt.Errorf("configuration has %d problem(s)", len(problems))
+}
+
+// configProblems unwraps the problems joined by NewConfig
+func configProblems(err error) []error {
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+// healthcheck requests the health route of the running resolver
+func healthcheck(args []string) error {
+	flags := flag.NewFlagSet("healthcheck", flag.ContinueOnError)
+	url := flags.String("url", "", "URL of the health route, derived from RESOLVER_LISTENER by default")
+	timeout := flags.Duration("timeout", 5*time.Second, "timeout of the request")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *url == "" {
+		config, err := types.LoadConfig()
+		if err != nil {
+			return err
+		}
+		baseUrl, err := listenerURL(config)
+		if err != nil {
+			return err
+		}
+		*url = baseUrl + types.HEALTH_PATH
+	}
+
+	client := http.Client{Timeout: *timeout}
+	response, err := client.Get(*url)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s responded with status %d", *url, response.StatusCode)
+	}
+	return nil
+}
+
+// listenerURL is the URL of the resolver listener on the same host
+func listenerURL(config types.Config) (string, error) {
+	host, port, err := net.SplitHostPort(config.ResolverListener)
+	if err != nil {
+		return "", fmt.Errorf("invalid RESOLVER_LISTENER: %w", err)
+	}
+	// The listener may be bound to all interfaces, but the requests are sent from the same host
+	if host == "" || net.ParseIP(host).IsUnspecified() {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port), nil
+}
@@ -23,6 +23,103 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/credentialStatus": {
+            "get": {
+                "description": "Dereference Bitstring Status List or StatusList2021 credential stored as a Resource and read the status of the credential.\nQuery parameters are the fields of credentialStatus entry of the credential.",
+                "consumes": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "produces": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "tags": [
+                    "Resource Resolution"
+                ],
+                "summary": "Check credential status in a status list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "DID URL or resolver URL of the status list resource",
+                        "name": "statusListCredential",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Index of the credential in the status list",
+                        "name": "statusListIndex",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purpose of the status. Required if the status list has several purposes",
+                        "name": "statusPurpose",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of bits per status. 1 by default",
+                        "name": "statusSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.ResourceDereferencing"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "contentStream": {
+                                            "$ref": "#/definitions/types.CredentialStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    }
+                }
+            }
+        },
         "/{did}": {
             "get": {
                 "description": "Fetch DID Document (\"DIDDoc\") from cheqd network",
@@ -68,7 +165,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Can transform Verification Method into another type",
+                        "description": "Can transform Verification Method into another type. Keys converted from Ed25519VerificationKey2020 contain only the 32-byte public key, without the ed25519-pub multicodec prefix",
                         "name": "transformKeys",
                         "in": "query"
                     },
@@ -78,6 +175,12 @@ const docTemplate = `{
                         "name": "service",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "Redirects to Service Endpoint of the given type with the highest priority",
+                        "name": "serviceType",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Addition to Service Endpoint",
@@ -116,7 +219,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by Resource Version",
+                        "description": "Filter by Resource Version, or latest or first version of every Resource Name and Type",
                         "name": "resourceVersion",
                         "in": "query"
                     },
@@ -128,7 +231,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Show only metadata of resources",
+                        "description": "Show only metadata of resources, or summary to count them per type and name",
                         "name": "resourceMetadata",
                         "in": "query"
                     },
@@ -137,6 +240,36 @@ const docTemplate = `{
                         "description": "Sanity check that Checksum of resource is the same as expected",
                         "name": "checksum",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "DID URL of the Resource with JSON Schema to validate the Resource data against, or $schema for the schema declared by the data",
+                        "name": "validateAgainst",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of resources in linkedResourceMetadata",
+                        "name": "resourceLimit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page of resources",
+                        "name": "resourceCursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort resources by created, name or version. Prefix - sorts in descending order",
+                        "name": "resourceSort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields of resources to return",
+                        "name": "resourceFields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -179,9 +312,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/{did}/metadata": {
+        "/{did}/anoncreds/statusList": {
             "get": {
-                "description": "Get metadata for all Resources within a DID Resource Collection",
+                "description": "Get the revocation status list which was current at the given time, and the time when it was replaced by the next one.\nThe revocation registry is identified either by id of its definition, or by the name of its status lists.",
                 "consumes": [
                     "application/did+ld+json",
                     "application/ld+json",
@@ -195,7 +328,7 @@ const docTemplate = `{
                 "tags": [
                     "Resource Resolution"
                 ],
-                "summary": "Fetch metadata for all Resources",
+                "summary": "Fetch AnonCreds revocation status list at a point in time",
                 "parameters": [
                     {
                         "type": "string",
@@ -203,25 +336,31 @@ const docTemplate = `{
                         "name": "did",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource-specific unique identifier of revocation registry definition",
+                        "name": "revRegDefId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of status lists",
+                        "name": "resourceName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unix timestamp or time string. Current time by default",
+                        "name": "timestamp",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/types.ResourceDereferencing"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "contentStream": {
-                                            "$ref": "#/definitions/types.ResolutionDidDocMetadata"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/types.AnonCredsStatusListDereferencing"
                         }
                     },
                     "400": {
@@ -257,19 +396,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/{did}/resources/{resourceId}": {
+        "/{did}/authorization": {
             "get": {
-                "description": "Get specific Resource within a DID Resource Collection",
+                "description": "Check whether a verification method is authorised to act for a DID under the given verification relationship.\nControllers of the DID are resolved recursively and the shortest chain of DIDs which led to the answer is returned.",
                 "consumes": [
-                    "*/*"
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
                 ],
                 "produces": [
-                    "*/*"
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
                 ],
                 "tags": [
-                    "Resource Resolution"
+                    "DID Resolution"
                 ],
-                "summary": "Fetch specific Resource",
+                "summary": "Check verification method authorization on did:cheqd",
                 "parameters": [
                     {
                         "type": "string",
@@ -280,9 +423,23 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Resource-specific unique-identifier",
-                        "name": "resourceId",
-                        "in": "path",
+                        "description": "DID URL of the verification method",
+                        "name": "verificationMethod",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "authentication",
+                            "assertionMethod",
+                            "capabilityInvocation",
+                            "capabilityDelegation",
+                            "keyAgreement"
+                        ],
+                        "type": "string",
+                        "description": "Verification relationship",
+                        "name": "relationship",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -290,10 +447,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "integer"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.DidDereferencing"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "contentStream": {
+                                            "$ref": "#/definitions/types.ControllerAuthorization"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -329,9 +495,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/{did}/resources/{resourceId}/metadata": {
+        "/{did}/diff": {
             "get": {
-                "description": "Get metadata for a specific Resource within a DID Resource Collection",
+                "description": "Get the difference between two versions of a DID Document (\"DIDDoc\") as RFC 6902 JSON Patch with a summary of changed keys, services and controllers.\nIf \"from\" is omitted, the version preceding \"to\" is used. If \"to\" is omitted, the latest version is used.",
                 "consumes": [
                     "application/did+ld+json",
                     "application/ld+json",
@@ -343,9 +509,9 @@ const docTemplate = `{
                     "application/did+json"
                 ],
                 "tags": [
-                    "Resource Resolution"
+                    "DID Resolution"
                 ],
-                "summary": "Fetch Resource-specific metadata",
+                "summary": "Compare two DID Document versions on did:cheqd",
                 "parameters": [
                     {
                         "type": "string",
@@ -356,17 +522,34 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Resource-specific unique identifier",
-                        "name": "resourceId",
-                        "in": "path",
-                        "required": true
+                        "description": "Version ID to compare from",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version ID to compare to",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.DidDereferencing"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.DidDereferencing"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "contentStream": {
+                                            "$ref": "#/definitions/types.DidDocDiff"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -402,23 +585,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/{did}/version/{versionId}": {
+        "/{did}/history": {
             "get": {
-                "description": "Fetch specific all version of a DID Document (\"DIDDoc\") for a given DID and version ID",
+                "description": "Fetch all versions of a DID Document (\"DIDDoc\") together with their metadata and linked resources.\nVersions are returned in chronological order in JSON Lines format, one version per line.",
                 "consumes": [
                     "application/did+ld+json",
                     "application/ld+json",
                     "application/did+json"
                 ],
                 "produces": [
-                    "application/did+ld+json",
-                    "application/ld+json",
-                    "application/did+json"
+                    "application/jsonl"
                 ],
                 "tags": [
                     "DID Resolution"
                 ],
-                "summary": "Resolve DID Document Version on did:cheqd",
+                "summary": "Export the full history of a DID Document on did:cheqd",
                 "parameters": [
                     {
                         "type": "string",
@@ -426,20 +607,13 @@ const docTemplate = `{
                         "name": "did",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "version of a DID document",
-                        "name": "versionId",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.DidResolution"
+                            "$ref": "#/definitions/types.DidDocHistoryEntry"
                         }
                     },
                     "400": {
@@ -475,13 +649,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/{did}/version/{versionId}/metadata": {
+        "/{did}/metadata": {
             "get": {
-                "description": "Fetch metadata of specific a DID Document (\"DIDDoc\") version for a given DID and version ID",
+                "description": "Get metadata for all Resources within a DID Resource Collection",
                 "consumes": [
                     "application/did+ld+json",
                     "application/ld+json",
-                    "application/did+jsonww"
+                    "application/did+json"
                 ],
                 "produces": [
                     "application/did+ld+json",
@@ -489,9 +663,9 @@ const docTemplate = `{
                     "application/did+json"
                 ],
                 "tags": [
-                    "DID Resolution"
+                    "Resource Resolution"
                 ],
-                "summary": "Resolve DID Document Version Metadata on did:cheqd",
+                "summary": "Fetch metadata for all Resources",
                 "parameters": [
                     {
                         "type": "string",
@@ -502,17 +676,52 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "version of a DID document",
-                        "name": "versionId",
-                        "in": "path",
-                        "required": true
+                        "description": "summary to count resources per type and name",
+                        "name": "resourceMetadata",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of resources in linkedResourceMetadata",
+                        "name": "resourceLimit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page of resources",
+                        "name": "resourceCursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort resources by created, name or version. Prefix - sorts in descending order",
+                        "name": "resourceSort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields of resources to return",
+                        "name": "resourceFields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/types.DidDereferencing"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.ResourceDereferencing"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "contentStream": {
+                                            "$ref": "#/definitions/types.ResolutionDidDocMetadata"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -548,9 +757,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/{did}/versions": {
+        "/{did}/resources": {
             "get": {
-                "description": "Fetch specific all versions of a DID Document (\"DIDDoc\") for a given DID",
+                "description": "Get metadata of Resources within a DID Resource Collection which match the filters, from the newest to the oldest.\nResources created at the same time keep their order on the ledger.",
                 "consumes": [
                     "application/did+ld+json",
                     "application/ld+json",
@@ -562,7 +771,825 @@ const docTemplate = `{
                     "application/did+json"
                 ],
                 "tags": [
-                    "DID Resolution"
+                    "Resource Resolution"
+                ],
+                "summary": "Fetch Resources of a DID Resource Collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full DID with unique identifier",
+                        "name": "did",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Resource Type",
+                        "name": "resourceType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Resource Name",
+                        "name": "resourceName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Media Type",
+                        "name": "mediaType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only Resources created after the time",
+                        "name": "createdAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only Resources created before the time",
+                        "name": "createdBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by Resource Version, or latest or first version of every Resource Name and Type",
+                        "name": "resourceVersion",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "summary to count resources per type and name",
+                        "name": "resourceMetadata",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of resources in linkedResourceMetadata",
+                        "name": "resourceLimit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page of resources",
+                        "name": "resourceCursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort resources by created, name or version. Prefix - sorts in descending order",
+                        "name": "resourceSort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields of resources to return",
+                        "name": "resourceFields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ResourceDereferencing"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    }
+                }
+            }
+        },
+        "/{did}/resources/by-name/{type}/{name}": {
+            "get": {
+                "description": "Get the latest Resource with the given type and name, or its version, within a DID Resource Collection.\nDepending on RESOURCE_BY_NAME_REDIRECT configuration, the client is redirected to the canonical URL of the Resource\nwith 303 See Other, or the Resource is served as by the query ?resourceType=\u0026resourceName=\u0026resourceVersion=.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "*/*"
+                ],
+                "tags": [
+                    "Resource Resolution"
+                ],
+                "summary": "Fetch Resource by its type and name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full DID with unique identifier",
+                        "name": "did",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource Type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "303": {
+                        "description": "Redirect to the canonical URL of the Resource"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    }
+                }
+            }
+        },
+        "/{did}/resources/by-name/{type}/{name}/{version}": {
+            "get": {
+                "description": "Get the Resource with the given type, name and version within a DID Resource Collection.\nVersion is either the value of resourceVersion, or latest or first version in the order of creation.\nlatest and first always select by the order of creation, even if a Resource has such a value of resourceVersion.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "*/*"
+                ],
+                "tags": [
+                    "Resource Resolution"
+                ],
+                "summary": "Fetch version of Resource by its type and name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full DID with unique identifier",
+                        "name": "did",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource Type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource Version",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "303": {
+                        "description": "Redirect to the canonical URL of the Resource"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    }
+                }
+            }
+        },
+        "/{did}/resources/{resourceId}": {
+            "get": {
+                "description": "Get specific Resource within a DID Resource Collection. Resource data is negotiated with the Accept header:\nJSON Resources can be also fetched as application/json, application/ld+json, application/cbor or application/yaml.\nResources of any media type are returned as is for application/did+json and application/did+ld+json.\nExpanded and compacted forms of JSON-LD aren't supported, application/ld+json with a profile returns 406.\nMedia type of the Resource is sniffed if it's missing or wrong.\nWith validateAgainst, JSON data is validated against the Resource with JSON Schema (2020-12 by default),\nor against the one declared by the data in $schema with validateAgainst=$schema. The result is returned in dereferencingMetadata.schemaValidation.",
+                "consumes": [
+                    "*/*"
+                ],
+                "produces": [
+                    "*/*"
+                ],
+                "tags": [
+                    "Resource Resolution"
+                ],
+                "summary": "Fetch specific Resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full DID with unique identifier",
+                        "name": "did",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource-specific unique-identifier",
+                        "name": "resourceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "DID URL of the Resource with JSON Schema to validate the data against, or $schema for the schema declared by the data",
+                        "name": "validateAgainst",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    }
+                }
+            }
+        },
+        "/{did}/resources/{resourceId}/anoncreds": {
+            "get": {
+                "description": "Get schema, credential definition, revocation registry definition or revocation status list stored as a Resource\nin the format defined by AnonCreds Methods Registry. issuerId is set to the DID, timestamp of status list is the creation time of the Resource.",
+                "consumes": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "produces": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "tags": [
+                    "Resource Resolution"
+                ],
+                "summary": "Fetch AnonCreds object",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full DID with unique identifier",
+                        "name": "did",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource-specific unique identifier",
+                        "name": "resourceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/types.ResourceDereferencing"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "contentStream": {
+                                            "$ref": "#/definitions/types.AnonCredsSchema"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    }
+                }
+            }
+        },
+        "/{did}/resources/{resourceId}/metadata": {
+            "get": {
+                "description": "Get metadata for a specific Resource within a DID Resource Collection",
+                "consumes": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "produces": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "tags": [
+                    "Resource Resolution"
+                ],
+                "summary": "Fetch Resource-specific metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full DID with unique identifier",
+                        "name": "did",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource-specific unique identifier",
+                        "name": "resourceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.DidDereferencing"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    }
+                }
+            }
+        },
+        "/{did}/resources/{resourceId}/versions": {
+            "get": {
+                "description": "Get metadata of all versions of a Resource, i.e. Resources with the same name and type, from the oldest to the newest",
+                "consumes": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "produces": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "tags": [
+                    "Resource Resolution"
+                ],
+                "summary": "Fetch all versions of a Resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full DID with unique identifier",
+                        "name": "did",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource-specific unique identifier of any version",
+                        "name": "resourceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "summary to count resources per type and name",
+                        "name": "resourceMetadata",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of resources in linkedResourceMetadata",
+                        "name": "resourceLimit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page of resources",
+                        "name": "resourceCursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort resources by created, name or version. Prefix - sorts in descending order",
+                        "name": "resourceSort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields of resources to return",
+                        "name": "resourceFields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.ResourceDereferencing"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    }
+                }
+            }
+        },
+        "/{did}/version/{versionId}": {
+            "get": {
+                "description": "Fetch specific all version of a DID Document (\"DIDDoc\") for a given DID and version ID",
+                "consumes": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "produces": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "tags": [
+                    "DID Resolution"
+                ],
+                "summary": "Resolve DID Document Version on did:cheqd",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full DID with unique identifier",
+                        "name": "did",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "version of a DID document",
+                        "name": "versionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.DidResolution"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    }
+                }
+            }
+        },
+        "/{did}/version/{versionId}/metadata": {
+            "get": {
+                "description": "Fetch metadata of specific a DID Document (\"DIDDoc\") version for a given DID and version ID",
+                "consumes": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+jsonww"
+                ],
+                "produces": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "tags": [
+                    "DID Resolution"
+                ],
+                "summary": "Resolve DID Document Version Metadata on did:cheqd",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full DID with unique identifier",
+                        "name": "did",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "version of a DID document",
+                        "name": "versionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "summary to count resources per type and name",
+                        "name": "resourceMetadata",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of resources in linkedResourceMetadata",
+                        "name": "resourceLimit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nextCursor of the previous page of resources",
+                        "name": "resourceCursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort resources by created, name or version. Prefix - sorts in descending order",
+                        "name": "resourceSort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields of resources to return",
+                        "name": "resourceFields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/types.DidDereferencing"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    }
+                }
+            }
+        },
+        "/{did}/versions": {
+            "get": {
+                "description": "Fetch specific all versions of a DID Document (\"DIDDoc\") for a given DID",
+                "consumes": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "produces": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "tags": [
+                    "DID Resolution"
                 ],
                 "summary": "Resolve DID Document Versions on did:cheqd",
                 "parameters": [
@@ -572,6 +1599,30 @@ const docTemplate = `{
                         "name": "did",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "summary to count resources per type and name",
+                        "name": "resourceMetadata",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of resources in linkedResourceMetadata",
+                        "name": "resourceLimit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort resources by created, name or version. Prefix - sorts in descending order",
+                        "name": "resourceSort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields of resources to return",
+                        "name": "resourceFields",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -625,9 +1676,219 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
+        },
+        "/{did}/{path}": {
+            "get": {
+                "description": "Redirects DID URL with a path to the endpoint of LinkedDomains service, or of the service configured by DID_URL_PATH_SERVICE.\nThe path and the query are resolved relative to the service endpoint.",
+                "consumes": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "produces": [
+                    "application/did+ld+json",
+                    "application/ld+json",
+                    "application/did+json"
+                ],
+                "tags": [
+                    "DID Resolution"
+                ],
+                "summary": "Dereference DID URL path on did:cheqd",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Full DID with unique identifier",
+                        "name": "did",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Path of DID URL",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "303": {
+                        "description": "See Other"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/types.IdentityError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "types.AnonCredsRevocationStatusList": {
+            "type": "object",
+            "properties": {
+                "currentAccumulator": {
+                    "type": "string",
+                    "example": "21 124C594B6B20E41B681E92B2C43FD165EA9E68BC3C9D63A82C8893124983CAE94 21 124C5341937827427B0A3A32113BD5E64FB7AB39BD3E5ABDD7970874501CA4897 6 5438CB6F442E2F807812FD9DC0C39AFF4A86B1E6766DBB5359E86A4D70401B0F 4 39D1CA5C4716FFC4FE0853C4FF7F081DFD8DF8D2C2CA79705211680AC77BF3A1 6 70504A5493F89C97C225B68310811A41AD9CD889301F238E93C95AD085E84191 4 39582252194D756D5D86D0EED02BF1B95CE12AED2FA5CD3C53260747D891993C"
+                },
+                "issuerId": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47"
+                },
+                "revRegDefId": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/af20b1f0-5c4d-4037-9669-eaedddb9c2df"
+                },
+                "revocationList": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        0,
+                        1,
+                        0
+                    ]
+                },
+                "timestamp": {
+                    "description": "Creation time of the status list resource as Unix timestamp",
+                    "type": "integer",
+                    "example": 1669640864
+                }
+            }
+        },
+        "types.AnonCredsSchema": {
+            "type": "object",
+            "properties": {
+                "attrNames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "name",
+                        "age"
+                    ]
+                },
+                "issuerId": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Example schema"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0"
+                }
+            }
+        },
+        "types.AnonCredsStatusListDereferencing": {
+            "type": "object",
+            "properties": {
+                "@context": {
+                    "type": "string",
+                    "example": "https://w3id.org/did-resolution/v1"
+                },
+                "contentMetadata": {
+                    "$ref": "#/definitions/types.AnonCredsStatusListMetadata"
+                },
+                "contentStream": {
+                    "$ref": "#/definitions/types.AnonCredsRevocationStatusList"
+                },
+                "dereferencingMetadata": {
+                    "$ref": "#/definitions/types.DereferencingMetadata"
+                }
+            }
+        },
+        "types.AnonCredsStatusListMetadata": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string",
+                    "example": "a95380f460e63ad939541a57aecbfd795fcd37c6d78ee86c885340e33a91b559"
+                },
+                "created": {
+                    "type": "string",
+                    "example": "2021-09-01T12:00:00Z"
+                },
+                "links": {
+                    "description": "DID URLs of other versions of the resource",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.ResourceVersionLinks"
+                        }
+                    ]
+                },
+                "mediaType": {
+                    "type": "string",
+                    "example": "image/png"
+                },
+                "nextUpdate": {
+                    "description": "Creation time of the next status list of the registry. Empty if the status list is the latest one",
+                    "type": "string",
+                    "example": "2021-09-01T12:00:00Z"
+                },
+                "nextVersionId": {
+                    "type": "string",
+                    "example": "d4829ac7-4566-478c-a408-b44767eddadc"
+                },
+                "previousVersionId": {
+                    "type": "string",
+                    "example": "ad7a8442-3531-46eb-a024-53953ec6e4ff"
+                },
+                "resourceCollectionId": {
+                    "type": "string",
+                    "example": "55dbc8bf-fba3-4117-855c-1e0dc1d3bb47"
+                },
+                "resourceId": {
+                    "type": "string",
+                    "example": "398cee0a-efac-4643-9f4c-74c48c72a14b"
+                },
+                "resourceName": {
+                    "type": "string",
+                    "example": "Image Resource"
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "Image"
+                },
+                "resourceURI": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/398cee0a-efac-4643-9f4c-74c48c72a14b"
+                },
+                "resourceVersion": {
+                    "type": "string",
+                    "example": "1"
+                }
+            }
+        },
         "types.ContentType": {
             "type": "string",
             "enum": [
@@ -636,7 +1897,10 @@ const docTemplate = `{
                 "application/ld+json",
                 "application/json",
                 "application/did",
-                "text/plain"
+                "text/plain",
+                "application/jsonl",
+                "application/cbor",
+                "application/yaml"
             ],
             "x-enum-varnames": [
                 "DIDJSON",
@@ -644,9 +1908,73 @@ const docTemplate = `{
                 "JSONLD",
                 "JSON",
                 "DIDRES",
-                "TEXT"
+                "TEXT",
+                "JSONL",
+                "CBOR",
+                "YAML"
             ]
         },
+        "types.ControllerAuthorization": {
+            "type": "object",
+            "properties": {
+                "authorized": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "did": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47"
+                },
+                "relationship": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.VerificationRelationship"
+                        }
+                    ],
+                    "example": "authentication"
+                },
+                "resolutionPath": {
+                    "description": "If authorized, the chain of DIDs from the requested one to the DID which lists the verification method.\nOtherwise all the DIDs which were resolved while walking the controllers.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47"
+                    ]
+                },
+                "verificationMethod": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47#key-1"
+                }
+            }
+        },
+        "types.CredentialStatus": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "description": "Value of the status bits. For revocation and suspension 1 means the credential is revoked or suspended",
+                    "type": "integer",
+                    "example": 0
+                },
+                "statusListCredential": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/398cee0a-efac-4643-9f4c-74c48c72a14b"
+                },
+                "statusListIndex": {
+                    "type": "integer",
+                    "example": 94567
+                },
+                "statusPurpose": {
+                    "type": "string",
+                    "example": "revocation"
+                },
+                "statusSize": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
         "types.DereferencedDidVersionsList": {
             "type": "object",
             "properties": {
@@ -669,6 +1997,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2021-09-01T12:00:00Z"
                 },
+                "links": {
+                    "description": "DID URLs of other versions of the resource",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.ResourceVersionLinks"
+                        }
+                    ]
+                },
                 "mediaType": {
                     "type": "string",
                     "example": "image/png"
@@ -710,6 +2046,10 @@ const docTemplate = `{
         "types.DereferencingMetadata": {
             "type": "object",
             "properties": {
+                "checksumVerified": {
+                    "description": "Set for dereferenced resource data if its checksum matches the one stored on the ledger",
+                    "type": "boolean"
+                },
                 "contentType": {
                     "allOf": [
                         {
@@ -727,6 +2067,14 @@ const docTemplate = `{
                 "retrieved": {
                     "type": "string",
                     "example": "2021-09-01T12:00:00Z"
+                },
+                "schemaValidation": {
+                    "description": "Set for dereferenced resource data validated against JSON Schema",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.SchemaValidation"
+                        }
+                    ]
                 }
             }
         },
@@ -824,6 +2172,103 @@ const docTemplate = `{
                 }
             }
         },
+        "types.DidDocDiff": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "$ref": "#/definitions/types.ResolutionDidDocMetadata"
+                },
+                "patch": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.JsonPatchOperation"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/types.DidDocDiffSummary"
+                },
+                "to": {
+                    "$ref": "#/definitions/types.ResolutionDidDocMetadata"
+                }
+            }
+        },
+        "types.DidDocDiffSummary": {
+            "type": "object",
+            "properties": {
+                "controllersAdded": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "controllersRemoved": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "keysAdded": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47#key-2"
+                    ]
+                },
+                "keysChanged": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "keysRemoved": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47#key-1"
+                    ]
+                },
+                "servicesAdded": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "servicesChanged": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47#service-1"
+                    ]
+                },
+                "servicesRemoved": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "types.DidDocHistoryEntry": {
+            "type": "object",
+            "properties": {
+                "@context": {
+                    "type": "string",
+                    "example": "https://w3id.org/did-resolution/v1"
+                },
+                "didDocument": {
+                    "$ref": "#/definitions/types.DidDoc"
+                },
+                "didDocumentMetadata": {
+                    "$ref": "#/definitions/types.ResolutionDidDocMetadata"
+                }
+            }
+        },
         "types.DidProperties": {
             "type": "object",
             "properties": {
@@ -876,6 +2321,20 @@ const docTemplate = `{
                 }
             }
         },
+        "types.JsonPatchOperation": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "type": "string",
+                    "example": "replace"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/verificationMethod/0/publicKeyMultibase"
+                },
+                "value": {}
+            }
+        },
         "types.ResolutionDidDocMetadata": {
             "type": "object",
             "properties": {
@@ -893,6 +2352,12 @@ const docTemplate = `{
                         "$ref": "#/definitions/types.DereferencedResource"
                     }
                 },
+                "linkedResourcePagination": {
+                    "$ref": "#/definitions/types.ResourcePagination"
+                },
+                "linkedResourceSummary": {
+                    "$ref": "#/definitions/types.ResourceSummary"
+                },
                 "nextVersionId": {
                     "type": "string",
                     "example": "3f3111af-dfe6-411f-adc9-02af59716ddb"
@@ -905,6 +2370,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2021-09-10T12:00:00Z"
                 },
+                "validFrom": {
+                    "type": "string",
+                    "example": "2021-09-10T12:00:00Z"
+                },
+                "validUntil": {
+                    "type": "string",
+                    "example": "2021-09-20T12:00:00Z"
+                },
                 "versionId": {
                     "type": "string",
                     "example": "284f297b-b6e3-4ffa-9172-bc3bb904e286"
@@ -914,6 +2387,10 @@ const docTemplate = `{
         "types.ResolutionMetadata": {
             "type": "object",
             "properties": {
+                "checksumVerified": {
+                    "description": "Set for dereferenced resource data if its checksum matches the one stored on the ledger",
+                    "type": "boolean"
+                },
                 "contentType": {
                     "allOf": [
                         {
@@ -931,6 +2408,14 @@ const docTemplate = `{
                 "retrieved": {
                     "type": "string",
                     "example": "2021-09-01T12:00:00Z"
+                },
+                "schemaValidation": {
+                    "description": "Set for dereferenced resource data validated against JSON Schema",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.SchemaValidation"
+                        }
+                    ]
                 }
             }
         },
@@ -943,6 +2428,12 @@ const docTemplate = `{
                         "$ref": "#/definitions/types.DereferencedResource"
                     }
                 },
+                "linkedResourcePagination": {
+                    "$ref": "#/definitions/types.ResourcePagination"
+                },
+                "linkedResourceSummary": {
+                    "$ref": "#/definitions/types.ResourceSummary"
+                },
                 "metadata": {
                     "$ref": "#/definitions/types.DereferencedResource"
                 }
@@ -964,6 +2455,104 @@ const docTemplate = `{
                 }
             }
         },
+        "types.ResourcePagination": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "nextCursor": {
+                    "type": "string",
+                    "example": "Mzk4Y2VlMGEtZWZhYy00NjQzLTlmNGMtNzRjNDhjNzJhMTRi"
+                },
+                "total": {
+                    "description": "Total number of resources. The ledger counts them only for the first page.",
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "types.ResourceSummary": {
+            "type": "object",
+            "properties": {
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/types.ResourceSummaryEntry"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "types.ResourceSummaryEntry": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 1199
+                },
+                "latestCreated": {
+                    "type": "string",
+                    "example": "2021-09-01T12:00:00Z"
+                },
+                "resourceName": {
+                    "type": "string",
+                    "example": "revocation-list"
+                },
+                "resourceType": {
+                    "type": "string",
+                    "example": "StatusList2021Revocation"
+                }
+            }
+        },
+        "types.ResourceVersionLinks": {
+            "type": "object",
+            "properties": {
+                "firstVersion": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47?resourceName=Image+Resource\u0026resourceType=Image\u0026resourceVersion=first"
+                },
+                "latestVersion": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47?resourceName=Image+Resource\u0026resourceType=Image\u0026resourceVersion=latest"
+                },
+                "nextVersion": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/d4829ac7-4566-478c-a408-b44767eddadc"
+                },
+                "previousVersion": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/ad7a8442-3531-46eb-a024-53953ec6e4ff"
+                }
+            }
+        },
+        "types.SchemaValidation": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "at '/name': got number",
+                        " want string"
+                    ]
+                },
+                "schema": {
+                    "type": "string",
+                    "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/a7e7d4e6-5b3c-4f3e-9d2a-0e4f1c2b3a4d"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
         "types.Service": {
             "type": "object",
             "properties": {
@@ -973,10 +2562,41 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "accept": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "didcomm/aip2;env=rfc19"
+                    ]
+                },
                 "id": {
                     "type": "string",
                     "example": "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47#service-1"
                 },
+                "priority": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recipientKeys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47#key-1"
+                    ]
+                },
+                "routingKeys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47#key-2"
+                    ]
+                },
                 "serviceEndpoint": {
                     "type": "array",
                     "items": {
@@ -1018,6 +2638,23 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "types.VerificationRelationship": {
+            "type": "string",
+            "enum": [
+                "authentication",
+                "assertionMethod",
+                "capabilityInvocation",
+                "capabilityDelegation",
+                "keyAgreement"
+            ],
+            "x-enum-varnames": [
+                "AuthenticationRelationship",
+                "AssertionMethodRelationship",
+                "CapabilityInvocationRelationship",
+                "CapabilityDelegationRelationship",
+                "KeyAgreementRelationship"
+            ]
         }
     }
 }`
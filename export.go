@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/rs/zerolog/log"
+)
+
+const exportUsage = `Usage: did-resolver export -out <dir|file.tar.gz> [-dids <file>] [did ...]
+
+Reads DID Documents with all their versions and resources from the configured networks
+and writes them to a snapshot, which can be served offline with SNAPSHOT_PATH.
+`
+
+// export crawls the DIDs through the live ledger service and writes the snapshot
+func export(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), exportUsage)
+		flags.PrintDefaults()
+	}
+	out := flags.String("out", "", "snapshot directory, or archive if it ends with .tar.gz")
+	didsFile := flags.String("dids", "", "file with DIDs to export, one per line")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		flags.Usage()
+		return errors.New("-out is required")
+	}
+
+	dids := flags.Args()
+	if *didsFile != "" {
+		fileDids, err := readDidsFile(*didsFile)
+		if err != nil {
+			return err
+		}
+		dids = append(dids, fileDids...)
+	}
+	if len(dids) == 0 {
+		flags.Usage()
+		return errors.New("no DIDs to export")
+	}
+
+	config := types.GetConfig()
+	types.SetupLogger(config)
+	if len(config.Networks) == 0 {
+		return errors.New("no networks configured to export from")
+	}
+
+	ledgerService := services.NewLedgerService(services.NewEndpointManager(config))
+	registerNetworks(&ledgerService, config)
+
+	snapshot, err := services.ExportSnapshot(ledgerService, dids)
+	if err != nil {
+		return err
+	}
+	if err := services.WriteSnapshot(snapshot, *out); err != nil {
+		return err
+	}
+	log.Info().Msgf("Exported %d DIDs to %s", len(snapshot.Dids), *out)
+	return nil
+}
+
+// readDidsFile reads DIDs one per line, skipping empty lines and comments
+func readDidsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var dids []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		dids = append(dids, line)
+	}
+	return dids, scanner.Err()
+}
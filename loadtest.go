@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cheqd/did-resolver/types"
+)
+
+const loadtestUsage = `Usage: did-resolver loadtest -mix <file> [-url <resolver>] [-concurrency 10] [-duration 30s | -requests N]
+
+Replays the DID URLs of the mix file against a running resolver and reports latency percentiles
+and error rates. Every line of the mix file has a DID URL and, optionally, an Accept header
+separated by whitespace. Empty lines and lines starting with # are skipped.
+`
+
+// loadRequest is a DID URL of the mix with the Accept header to send
+type loadRequest struct {
+	didUrl string
+	accept string
+}
+
+type loadResult struct {
+	duration time.Duration
+	status   int
+	err      error
+}
+
+// LoadReport summarizes the responses of the resolver. Requests failed if the resolver didn't respond
+// or responded with status 400 and above.
+type LoadReport struct {
+	Requests  int
+	Errors    int
+	ErrorRate float64
+	Duration  time.Duration
+	// Requests per second
+	Throughput float64
+	P50        time.Duration
+	P95        time.Duration
+	P99        time.Duration
+	Max        time.Duration
+	// Number of responses by status, or "error" if the resolver didn't respond
+	Statuses map[string]int
+}
+
+// MarshalJSON writes durations in milliseconds
+func (r LoadReport) MarshalJSON() ([]byte, error) {
+	milliseconds := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+	return json.Marshal(struct {
+		Requests   int            `json:"requests"`
+		Errors     int            `json:"errors"`
+		ErrorRate  float64        `json:"errorRate"`
+		Duration   float64        `json:"durationMs"`
+		Throughput float64        `json:"throughput"`
+		P50        float64        `json:"p50Ms"`
+		P95        float64        `json:"p95Ms"`
+		P99        float64        `json:"p99Ms"`
+		Max        float64        `json:"maxMs"`
+		Statuses   map[string]int `json:"statuses"`
+	}{
+		r.Requests, r.Errors, r.ErrorRate, milliseconds(r.Duration), r.Throughput,
+		milliseconds(r.P50), milliseconds(r.P95), milliseconds(r.P99), milliseconds(r.Max), r.Statuses,
+	})
+}
+
+func loadtest(args []string) error {
+	flags := flag.NewFlagSet("loadtest", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), loadtestUsage)
+		flags.PrintDefaults()
+	}
+	mixFile := flags.String("mix", "", "file with the DID URL mix")
+	baseUrl := flags.String("url", "", "URL of the resolver, derived from RESOLVER_LISTENER by default")
+	concurrency := flags.Int("concurrency", 10, "number of concurrent requests")
+	duration := flags.Duration("duration", 30*time.Second, "how long to send requests, if -requests isn't set")
+	requests := flags.Int("requests", 0, "number of requests to send")
+	timeout := flags.Duration("timeout", 10*time.Second, "timeout of a request")
+	jsonOutput := flags.Bool("json", false, "print the report as JSON")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *mixFile == "" {
+		flags.Usage()
+		return errors.New("-mix is required")
+	}
+	if *concurrency < 1 {
+		return errors.New("-concurrency should be at least 1")
+	}
+
+	mix, err := readLoadMix(*mixFile)
+	if err != nil {
+		return err
+	}
+	if len(mix) == 0 {
+		return fmt.Errorf("%s has no DID URLs", *mixFile)
+	}
+
+	if *baseUrl == "" {
+		config, err := types.LoadConfig()
+		if err != nil {
+			return err
+		}
+		if *baseUrl, err = listenerURL(config); err != nil {
+			return err
+		}
+	}
+
+	client := &http.Client{
+		Timeout: *timeout,
+		// Redirects are responses of the resolver, e.g. to service endpoints, which shouldn't be measured
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		Transport:     &http.Transport{MaxIdleConnsPerHost: *concurrency},
+	}
+	fmt.Fprintf(os.Stderr, "Sending %d DID URLs to %s with concurrency %d\n", len(mix), *baseUrl, *concurrency)
+	report := runLoad(client, strings.TrimSuffix(*baseUrl, "/"), mix, *concurrency, *duration, *requests)
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	printLoadReport(os.Stdout, report)
+	return nil
+}
+
+// readLoadMix reads DID URLs with optional Accept headers, one per line
+func readLoadMix(path string) ([]loadRequest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var mix []loadRequest
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		request := loadRequest{didUrl: line}
+		if i := strings.IndexAny(line, " \t"); i != -1 {
+			request = loadRequest{didUrl: line[:i], accept: strings.TrimSpace(line[i+1:])}
+		}
+		mix = append(mix, request)
+	}
+	return mix, scanner.Err()
+}
+
+// runLoad sends the requests of the mix in order, starting over at its end, until either
+// the number of requests is sent or the duration is over
+func runLoad(client *http.Client, baseUrl string, mix []loadRequest, concurrency int, duration time.Duration, requests int) LoadReport {
+	var next atomic.Int64
+	deadline := time.Now().Add(duration)
+	results := make([][]loadResult, concurrency)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for worker := range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if (requests > 0 && i >= requests) || (requests <= 0 && time.Now().After(deadline)) {
+					return
+				}
+				results[worker] = append(results[worker], sendLoadRequest(client, baseUrl, mix[i%len(mix)]))
+			}
+		}()
+	}
+	wg.Wait()
+
+	var all []loadResult
+	for _, workerResults := range results {
+		all = append(all, workerResults...)
+	}
+	return newLoadReport(all, time.Since(start))
+}
+
+func sendLoadRequest(client *http.Client, baseUrl string, request loadRequest) loadResult {
+	// Fragment is a part of the DID URL for the resolver, so it's escaped to reach it
+	httpRequest, err := http.NewRequest(http.MethodGet, baseUrl+types.RESOLVER_PATH+strings.ReplaceAll(request.didUrl, "#", "%23"), nil)
+	if err != nil {
+		return loadResult{err: err}
+	}
+	if request.accept != "" {
+		httpRequest.Header.Set("Accept", request.accept)
+	}
+
+	start := time.Now()
+	response, err := client.Do(httpRequest)
+	if err != nil {
+		return loadResult{duration: time.Since(start), err: err}
+	}
+	// Latency includes reading of the whole body
+	_, err = io.Copy(io.Discard, response.Body)
+	response.Body.Close()
+	return loadResult{duration: time.Since(start), status: response.StatusCode, err: err}
+}
+
+func newLoadReport(results []loadResult, elapsed time.Duration) LoadReport {
+	report := LoadReport{Requests: len(results), Duration: elapsed, Statuses: map[string]int{}}
+	durations := make([]time.Duration, 0, len(results))
+	for _, result := range results {
+		durations = append(durations, result.duration)
+		switch {
+		case result.err != nil:
+			report.Errors++
+			report.Statuses["error"]++
+		case result.status >= http.StatusBadRequest:
+			report.Errors++
+			fallthrough
+		default:
+			report.Statuses[fmt.Sprint(result.status)]++
+		}
+	}
+	if len(results) == 0 {
+		return report
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	report.ErrorRate = float64(report.Errors) / float64(report.Requests)
+	report.Throughput = float64(report.Requests) / elapsed.Seconds()
+	report.P50 = percentile(durations, 50)
+	report.P95 = percentile(durations, 95)
+	report.P99 = percentile(durations, 99)
+	report.Max = durations[len(durations)-1]
+	return report
+}
+
+// percentile of sorted durations by the nearest-rank method
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+func printLoadReport(w io.Writer, report LoadReport) {
+	fmt.Fprintf(w, "Requests:    %d in %s (%.1f req/s)\n", report.Requests, report.Duration.Round(time.Millisecond), report.Throughput)
+	fmt.Fprintf(w, "Errors:      %d (%.2f%%)\n", report.Errors, report.ErrorRate*100)
+	fmt.Fprintf(w, "Latency:     p50 %s, p95 %s, p99 %s, max %s\n",
+		report.P50.Round(time.Microsecond), report.P95.Round(time.Microsecond),
+		report.P99.Round(time.Microsecond), report.Max.Round(time.Microsecond))
+
+	statuses := make([]string, 0, len(report.Statuses))
+	for status := range report.Statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(w, "Status %-5s %d\n", status+":", report.Statuses[status])
+	}
+}
@@ -1,7 +1,12 @@
 package main
 
 import (
+	"fmt"
+	"net/http"
+	"os"
+
 	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/services/admin"
 	didDocServices "github.com/cheqd/did-resolver/services/diddoc"
 	resourceServices "github.com/cheqd/did-resolver/services/resource"
 	"github.com/cheqd/did-resolver/types"
@@ -22,21 +27,54 @@ func serve() {
 	// Setup logger
 	types.SetupLogger(config)
 
-	// Initialize endpoint manager
-	endpointManager := services.NewEndpointManager(config)
+	e, ledgers := newServer(config)
+	e.Use(middleware.Logger())
+
+	if config.AdminListener != "" {
+		serveAdmin(config, ledgers)
+	}
+	if types.WatchConfig(newConfigReloader(config, ledgers)) {
+		log.Info().Msgf("Watching %s for configuration changes", types.ConfigFile)
+	}
+
+	e.Debug = true
+	log.Info().Msg("Starting listener")
+	log.Fatal().Err(e.Start(config.ResolverListener))
+}
+
+// serveAdmin starts the admin API on its own listener, in the background
+func serveAdmin(config types.Config, ledgers ledgerServices) {
+	if ledgers.network == nil {
+		log.Warn().Msg("Admin API is disabled, as no networks are configured")
+		return
+	}
+	adminService := &admin.Service{
+		EndpointManager: ledgers.network.EndpointManager(),
+		LedgerService:   ledgers.network,
+		Cache:           ledgers.cache,
+	}
+	adminEcho := echo.New()
+	adminEcho.HideBanner = true
+	adminEcho.Use(middleware.Logger())
+	adminEcho.Use(middleware.Recover())
+	adminService.SetRoutes(adminEcho, config.AdminToken)
+
+	go func() {
+		log.Info().Msgf("Starting admin listener on %s", config.AdminListener)
+		log.Fatal().Err(adminEcho.Start(config.AdminListener))
+	}()
+}
 
+// newServer sets up services and routes of the resolver, which are shared by the listener and the resolve command.
+// It also returns the ledger services, which are managed at runtime.
+func newServer(config types.Config) (*echo.Echo, ledgerServices) {
 	// Services
-	ledgerService := services.NewLedgerService(endpointManager)
+	ledgers := newLedgerService(config)
+	ledgerService := ledgers.resolver
 	didService := services.NewDIDDocService(types.DID_METHOD, ledgerService)
+	didService.SetPathServiceId(config.DidUrlPathService)
 	resourceService := services.NewResourceService(types.DID_METHOD, ledgerService)
-
-	for _, network := range config.Networks {
-		log.Info().Msgf("Registering network: %s.", network.Namespace)
-		err := ledgerService.RegisterLedger(types.DID_METHOD, network)
-		if err != nil {
-			panic(err)
-		}
-	}
+	resourceService.SetByNameRedirect(config.ResourceByNameRedirect)
 
 	// Echo instance
 	e := echo.New()
@@ -65,17 +103,95 @@ func serve() {
 		// If gzip not in Accept-Encoding header, do not compress
 		Skipper: utils.GzipSkipper,
 	}))
-	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 
 	e.GET(types.SWAGGER_PATH, echoSwagger.WrapHandler)
+	e.GET(types.HEALTH_PATH, func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
 
 	didDocServices.SetRoutes(e)
 	resourceServices.SetRoutes(e)
+	return e, ledgers
+}
 
-	e.Debug = true
-	log.Info().Msg("Starting listener")
-	log.Fatal().Err(e.Start(config.ResolverListener))
+// ledgerServices are the ledger service used by the handlers and its parts managed at runtime
+type ledgerServices struct {
+	// With the cache, snapshot and recording, if enabled
+	resolver services.LedgerServiceI
+	// Ledger service of the configured networks, nil if there are none or the recording is replayed
+	network *services.LedgerService
+	// Nil if the disk cache is disabled
+	cache *services.DiskCache
+}
+
+// newLedgerService connects to the configured networks, optionally through the disk cache,
+// and serves namespaces of the snapshot, if any, offline. A ledger recording replaces all of them.
+func newLedgerService(config types.Config) ledgerServices {
+	if config.LedgerReplayPath != "" {
+		log.Info().Msgf("Replaying ledger recording: %s", config.LedgerReplayPath)
+		recording, err := services.ReadLedgerRecording(config.LedgerReplayPath)
+		if err != nil {
+			panic(err)
+		}
+		return ledgerServices{resolver: services.NewReplayLedgerService(recording)}
+	}
+
+	ledgers := newLiveLedgerService(config)
+	if config.LedgerRecordPath == "" {
+		return ledgers
+	}
+	// Queries are recorded as the handlers see them, so the recording can be replayed without cache and snapshot
+	log.Warn().Msgf("Recording ledger traffic to %s", config.LedgerRecordPath)
+	recordingLedgerService, err := services.NewRecordingLedgerService(ledgers.resolver, config.LedgerRecordPath)
+	if err != nil {
+		panic(err)
+	}
+	ledgers.resolver = recordingLedgerService
+	return ledgers
+}
+
+func newLiveLedgerService(config types.Config) ledgerServices {
+	var ledgers ledgerServices
+	if len(config.Networks) != 0 {
+		// Initialize endpoint manager
+		endpointManager := services.NewEndpointManager(config)
+		networkLedgerService := services.NewLedgerService(endpointManager)
+		registerNetworks(&networkLedgerService, config)
+		ledgers.resolver = networkLedgerService
+		ledgers.network = &networkLedgerService
+
+		if config.CacheDir != "" {
+			cache, err := services.NewDiskCache(config.CacheDir, config.CacheMaxSizeMB<<20)
+			if err != nil {
+				panic(err)
+			}
+			ledgers.resolver = services.NewCachedLedgerService(networkLedgerService, cache)
+			ledgers.cache = cache
+		}
+	}
+
+	if config.SnapshotPath == "" {
+		return ledgers
+	}
+	log.Info().Msgf("Loading snapshot: %s", config.SnapshotPath)
+	snapshot, err := services.ReadSnapshot(config.SnapshotPath)
+	if err != nil {
+		panic(err)
+	}
+	log.Info().Msgf("Serving namespaces %v from snapshot created at %s", snapshot.Namespaces, snapshot.CreatedAt)
+	ledgers.resolver = services.NewSnapshotLedgerService(snapshot, ledgers.resolver)
+	return ledgers
+}
+
+func registerNetworks(ledgerService *services.LedgerService, config types.Config) {
+	for _, network := range config.Networks {
+		log.Info().Msgf("Registering network: %s.", network.Namespace)
+		err := ledgerService.RegisterLedger(types.DID_METHOD, network)
+		if err != nil {
+			panic(err)
+		}
+	}
 }
 
 //	@title			DID Resolver for cheqd DID method
@@ -90,6 +206,14 @@ func serve() {
 //	@schemes		https http
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	err := types.PrintConfig()
 	if err != nil {
 		panic(err)
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/cheqd/did-resolver/types"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// newConfigReloader returns the handler of config changes, which applies the log level and endpoints
+// of the changed config. If they can't be applied, the whole config is rejected and the current one stays in use.
+func newConfigReloader(config types.Config, ledgers ledgerServices) func(types.Config) {
+	current := config
+	return func(next types.Config) {
+		if reflect.DeepEqual(current, next) {
+			return
+		}
+		if err := reloadConfig(current, next, ledgers); err != nil {
+			log.Error().Err(err).Msg("Changed configuration is rejected, keeping the current one")
+			return
+		}
+		current = next
+		log.Info().Msg("Configuration reloaded")
+	}
+}
+
+func reloadConfig(current types.Config, next types.Config, ledgers ledgerServices) error {
+	// Already validated by the config
+	level, err := zerolog.ParseLevel(next.LogLevel)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(current.Networks, next.Networks) {
+		if ledgers.network == nil {
+			return errors.New("endpoints can't be changed, as the resolver was started without networks or with the ledger recording")
+		}
+		if err := ledgers.network.Reconfigure(types.DID_METHOD, next); err != nil {
+			return err
+		}
+		for _, network := range next.Networks {
+			log.Info().Msgf("Registered network: %s with %d endpoint(s).", network.Namespace, len(network.Endpoints))
+		}
+	}
+
+	zerolog.SetGlobalLevel(level)
+	for _, setting := range restartSettings(current, next) {
+		log.Warn().Msgf("%s changed, the resolver must be restarted to apply it", setting)
+	}
+	return nil
+}
+
+// restartSettings returns the changed settings, which are read only on start
+func restartSettings(current types.Config, next types.Config) []string {
+	settings := []struct {
+		name    string
+		changed bool
+	}{
+		{"RESOLVER_LISTENER", current.ResolverListener != next.ResolverListener},
+		{"DID_URL_PATH_SERVICE", current.DidUrlPathService != next.DidUrlPathService},
+		{"RESOURCE_BY_NAME_REDIRECT", current.ResourceByNameRedirect != next.ResourceByNameRedirect},
+		{"SNAPSHOT_PATH", current.SnapshotPath != next.SnapshotPath},
+		{"CACHE_DIR", current.CacheDir != next.CacheDir},
+		{"CACHE_MAX_SIZE_MB", current.CacheMaxSizeMB != next.CacheMaxSizeMB},
+		{"LEDGER_RECORD_PATH", current.LedgerRecordPath != next.LedgerRecordPath},
+		{"LEDGER_REPLAY_PATH", current.LedgerReplayPath != next.LedgerReplayPath},
+		{"ADMIN_LISTENER", current.AdminListener != next.AdminListener},
+		{"ADMIN_TOKEN", current.AdminToken != next.AdminToken},
+	}
+	var changed []string
+	for _, setting := range settings {
+		if setting.changed {
+			changed = append(changed, setting.name)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// ListEndpointsEchoHandler returns all endpoints with their health
+func (s *Service) ListEndpointsEchoHandler(c echo.Context) error {
+	return c.JSON(http.StatusOK, newEndpointResponses(s.EndpointManager.ListEndpoints()))
+}
+
+// AddEndpointEchoHandler adds the endpoint and registers its namespace, if it's new
+func (s *Service) AddEndpointEchoHandler(c echo.Context) error {
+	var request AddEndpointRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+	endpoint, err := request.endpoint()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	status, err := s.EndpointManager.AddEndpoint(request.Namespace, endpoint)
+	if errors.Is(err, services.ErrEndpointExists) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	// Queries of registered namespaces are routed by the endpoint manager, so the network is needed only once
+	if !s.isRegistered(request.Namespace) {
+		log.Info().Msgf("Registering network: %s.", request.Namespace)
+		network := types.Network{
+			Namespace: request.Namespace,
+			Endpoints: []types.Endpoint{endpoint},
+			UseTls:    endpoint.UseTls,
+			Timeout:   endpoint.Timeout,
+		}
+		if err := s.LedgerService.RegisterLedger(types.DID_METHOD, network); err != nil {
+			return err
+		}
+	}
+	return c.JSON(http.StatusCreated, NewEndpointResponse(status))
+}
+
+// RemoveEndpointEchoHandler removes the endpoint given by namespace and url query parameters.
+// The namespace is unregistered with its last endpoint.
+func (s *Service) RemoveEndpointEchoHandler(c echo.Context) error {
+	namespace := c.QueryParam("namespace")
+	remaining, err := s.EndpointManager.RemoveEndpoint(namespace, c.QueryParam("url"))
+	if errors.Is(err, services.ErrEndpointNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	if remaining == 0 {
+		log.Info().Msgf("Unregistering network: %s.", namespace)
+		s.LedgerService.UnregisterLedger(types.DID_METHOD, namespace)
+	}
+	return c.JSON(http.StatusOK, RemoveEndpointResponse{NamespaceRemoved: remaining == 0})
+}
+
+// MarkEndpointEchoHandler drains the endpoint or returns it to service
+func (s *Service) MarkEndpointEchoHandler(c echo.Context) error {
+	var request MarkEndpointRequest
+	if err := c.Bind(&request); err != nil {
+		return err
+	}
+
+	status, err := s.EndpointManager.ForceEndpointHealth(request.Namespace, request.URL, request.Healthy)
+	if errors.Is(err, services.ErrEndpointNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, NewEndpointResponse(status))
+}
+
+// HealthCheckEchoHandler checks all endpoints now and returns their health
+func (s *Service) HealthCheckEchoHandler(c echo.Context) error {
+	return c.JSON(http.StatusOK, newEndpointResponses(s.EndpointManager.CheckHealthNow()))
+}
+
+// FlushCacheEchoHandler removes all entries of the disk cache
+func (s *Service) FlushCacheEchoHandler(c echo.Context) error {
+	if s.Cache == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "cache is disabled")
+	}
+	removed := s.Cache.Flush()
+	log.Info().Msgf("Flushed %d cached entries", removed)
+	return c.JSON(http.StatusOK, FlushCacheResponse{Removed: removed})
+}
+
+// StatsEchoHandler returns counters of the running resolver
+func (s *Service) StatsEchoHandler(c echo.Context) error {
+	return c.JSON(http.StatusOK, StatsResponse{ChecksumMismatchCount: services.ChecksumMismatchCount()})
+}
+
+func (s *Service) isRegistered(namespace string) bool {
+	for _, registered := range s.LedgerService.GetNamespaces() {
+		if registered == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func (r AddEndpointRequest) endpoint() (types.Endpoint, error) {
+	// Namespaces are a part of DIDs, so they follow the DID syntax
+	if r.Namespace == "" || !utils.DidNamespaceRegexp.MatchString(r.Namespace) {
+		return types.Endpoint{}, fmt.Errorf("invalid namespace %q", r.Namespace)
+	}
+	if r.URL == "" {
+		return types.Endpoint{}, errors.New("url is required")
+	}
+	role := r.Role
+	if role == "" {
+		role = types.EndpointRolePrimary
+	}
+	if role != types.EndpointRolePrimary && role != types.EndpointRoleFallback {
+		return types.Endpoint{}, fmt.Errorf("invalid role %q, expected %s or %s", r.Role, types.EndpointRolePrimary, types.EndpointRoleFallback)
+	}
+	timeout, err := time.ParseDuration(r.Timeout)
+	if err != nil || timeout <= 0 {
+		return types.Endpoint{}, fmt.Errorf("invalid timeout %q", r.Timeout)
+	}
+	return types.Endpoint{URL: r.URL, UseTls: r.UseTls, Timeout: timeout, Role: role}, nil
+}
@@ -0,0 +1,25 @@
+package admin
+
+import (
+	"crypto/subtle"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+const ADMIN_PATH = "/admin"
+
+// SetRoutes registers the admin API, which requires the token in the Authorization: Bearer header
+func (s *Service) SetRoutes(e *echo.Echo, token string) {
+	g := e.Group(ADMIN_PATH, middleware.KeyAuth(func(key string, c echo.Context) (bool, error) {
+		return subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1, nil
+	}))
+
+	g.GET("/endpoints", s.ListEndpointsEchoHandler)
+	g.POST("/endpoints", s.AddEndpointEchoHandler)
+	g.DELETE("/endpoints", s.RemoveEndpointEchoHandler)
+	g.POST("/endpoints/mark", s.MarkEndpointEchoHandler)
+	g.POST("/health-check", s.HealthCheckEchoHandler)
+	g.POST("/cache/flush", s.FlushCacheEchoHandler)
+	g.GET("/stats", s.StatsEchoHandler)
+}
@@ -0,0 +1,80 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+)
+
+// Service manages endpoints of the running resolver. Its API is served on a separate listener,
+// so it isn't exposed together with the resolver.
+type Service struct {
+	EndpointManager *services.EndpointManager
+	LedgerService   *services.LedgerService
+	// Optional, nil when the disk cache is disabled
+	Cache *services.DiskCache
+}
+
+type EndpointResponse struct {
+	Namespace    string             `json:"namespace"`
+	URL          string             `json:"url"`
+	Role         types.EndpointRole `json:"role"`
+	UseTls       bool               `json:"useTls"`
+	Timeout      string             `json:"timeout"`
+	Healthy      bool               `json:"healthy"`
+	Drained      bool               `json:"drained"`
+	FailureCount int                `json:"failureCount"`
+	LastCheck    time.Time          `json:"lastCheck"`
+}
+
+func NewEndpointResponse(status services.EndpointStatus) EndpointResponse {
+	return EndpointResponse{
+		Namespace:    status.Namespace,
+		URL:          status.Endpoint.URL,
+		Role:         status.Endpoint.Role,
+		UseTls:       status.Endpoint.UseTls,
+		Timeout:      status.Endpoint.Timeout.String(),
+		Healthy:      status.Healthy,
+		Drained:      status.Drained,
+		FailureCount: status.FailureCount,
+		LastCheck:    status.LastCheck,
+	}
+}
+
+func newEndpointResponses(statuses []services.EndpointStatus) []EndpointResponse {
+	responses := make([]EndpointResponse, len(statuses))
+	for i, status := range statuses {
+		responses[i] = NewEndpointResponse(status)
+	}
+	return responses
+}
+
+type AddEndpointRequest struct {
+	Namespace string             `json:"namespace"`
+	URL       string             `json:"url"`
+	Role      types.EndpointRole `json:"role"`
+	UseTls    bool               `json:"useTls"`
+	// Duration, e.g. 5s
+	Timeout string `json:"timeout"`
+}
+
+type MarkEndpointRequest struct {
+	Namespace string `json:"namespace"`
+	URL       string `json:"url"`
+	Healthy   bool   `json:"healthy"`
+}
+
+type RemoveEndpointResponse struct {
+	// Whether the namespace was unregistered, as it has no endpoints left
+	NamespaceRemoved bool `json:"namespaceRemoved"`
+}
+
+type FlushCacheResponse struct {
+	Removed int `json:"removed"`
+}
+
+type StatsResponse struct {
+	// Number of served resources whose data didn't match the checksum on the ledger
+	ChecksumMismatchCount uint64 `json:"checksumMismatchCount"`
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
+)
+
+// CachedLedgerService keeps immutable ledger objects in the disk cache: DID Documents of versions and data
+// of resources. Their metadata changes over time, e.g. cheqd-node sets deactivated on all versions of a deactivated DID
+// and nextVersionId on update, so the metadata is always queried from the next ledger service.
+type CachedLedgerService struct {
+	next  LedgerServiceI
+	cache *DiskCache
+}
+
+func NewCachedLedgerService(next LedgerServiceI, cache *DiskCache) CachedLedgerService {
+	return CachedLedgerService{next: next, cache: cache}
+}
+
+func (cls CachedLedgerService) QueryDIDDoc(did string, version string) (*didTypes.DidDocWithMetadata, *types.IdentityError) {
+	if version != "" {
+		var didDoc didTypes.DidDoc
+		if cls.get(didDocCacheKey(did, version), &didDoc) {
+			versions, err := cls.next.QueryAllDidDocVersionsMetadata(did)
+			if err != nil {
+				return nil, err
+			}
+			for _, metadata := range versions {
+				if metadata.VersionId == version {
+					return &didTypes.DidDocWithMetadata{DidDoc: &didDoc, Metadata: metadata}, nil
+				}
+			}
+		}
+	}
+
+	didDoc, err := cls.next.QueryDIDDoc(did, version)
+	if err != nil {
+		return nil, err
+	}
+	// DID Document of a version never changes, even if the version is the latest one
+	cls.put(didDocCacheKey(did, didDoc.Metadata.VersionId), didDoc.DidDoc)
+	return didDoc, nil
+}
+
+func (cls CachedLedgerService) QueryAllDidDocVersionsMetadata(did string) ([]*didTypes.Metadata, *types.IdentityError) {
+	return cls.next.QueryAllDidDocVersionsMetadata(did)
+}
+
+func (cls CachedLedgerService) QueryResource(did string, resourceId string) (*resourceTypes.ResourceWithMetadata, *types.IdentityError) {
+	key := resourceCacheKey(did, resourceId)
+	var resource resourceTypes.Resource
+	if cls.get(key, &resource) {
+		collection, err := cls.next.QueryCollectionResources(did)
+		if err != nil {
+			return nil, err
+		}
+		for _, metadata := range collection {
+			if metadata.Id == resourceId && utils.Sha256Checksum(resource.GetData()) == metadata.GetChecksum() {
+				return &resourceTypes.ResourceWithMetadata{Resource: &resource, Metadata: metadata}, nil
+			}
+		}
+	}
+
+	fetched, err := cls.next.QueryResource(did, resourceId)
+	if err != nil {
+		return nil, err
+	}
+	// Data of resources never changes, but data which doesn't match the checksum isn't kept
+	if utils.Sha256Checksum(fetched.Resource.GetData()) == fetched.Metadata.GetChecksum() {
+		cls.put(key, fetched.Resource)
+	}
+	return fetched, nil
+}
+
+func (cls CachedLedgerService) QueryCollectionResources(did string) ([]*resourceTypes.Metadata, *types.IdentityError) {
+	return cls.next.QueryCollectionResources(did)
+}
+
+func (cls CachedLedgerService) QueryCollectionResourcesPage(did string, page *query.PageRequest) ([]*resourceTypes.Metadata, *query.PageResponse, *types.IdentityError) {
+	return cls.next.QueryCollectionResourcesPage(did, page)
+}
+
+func (cls CachedLedgerService) GetNamespaces() []string {
+	return cls.next.GetNamespaces()
+}
+
+func (cls CachedLedgerService) get(key string, message proto.Message) bool {
+	data, ok := cls.cache.Get(key)
+	if !ok {
+		return false
+	}
+	if err := proto.Unmarshal(data, message); err != nil {
+		log.Warn().Err(err).Msgf("Failed to decode cache entry %s", key)
+		return false
+	}
+	return true
+}
+
+func (cls CachedLedgerService) put(key string, message proto.Message) {
+	data, err := proto.Marshal(message)
+	if err == nil {
+		err = cls.cache.Put(key, data)
+	}
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to cache %s", key)
+	}
+}
+
+func didDocCacheKey(did string, version string) string {
+	return "diddoc-document:" + did + ":" + version
+}
+
+// resourceCacheKey includes the namespace, because collection ids are unique only within a network
+func resourceCacheKey(did string, resourceId string) string {
+	_, namespace, collectionId, _ := utils.TrySplitDID(did)
+	return "resource-data:" + namespace + ":" + collectionId + ":" + resourceId
+}
@@ -29,11 +29,11 @@ func (dd DIDDocAllVersionMetadataRequestService) Redirect(c services.ResolverCon
 }
 
 func (dd *DIDDocAllVersionMetadataRequestService) SpecificValidation(c services.ResolverContext) error {
-	// We not allow query here
-	if len(dd.Queries) != 0 {
+	// Only queries of linked resources list are allowed. Cursor can't be used as each version has its own list
+	if dd.GetQueryParam(types.ResourceCursorQ) != "" {
 		return types.NewInvalidDidUrlError(dd.GetDid(), dd.RequestedContentType, nil, dd.IsDereferencing)
 	}
-	return nil
+	return dd.ValidateResourceListQueries()
 }
 
 func (dd *DIDDocAllVersionMetadataRequestService) Query(c services.ResolverContext) error {
@@ -0,0 +1,62 @@
+package diddoc
+
+import (
+	"net/http"
+
+	"github.com/cheqd/did-resolver/migrations"
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+type DIDDocAuthorizationRequestService struct {
+	services.BaseRequestService
+	VerificationMethod string
+	Relationship       types.VerificationRelationship
+}
+
+func (dd *DIDDocAuthorizationRequestService) Setup(c services.ResolverContext) error {
+	dd.IsDereferencing = true
+	return nil
+}
+
+func (dd *DIDDocAuthorizationRequestService) SpecificPrepare(c services.ResolverContext) error {
+	dd.VerificationMethod = dd.GetQueryParam(types.VerificationMethodQ)
+	dd.Relationship = types.VerificationRelationship(dd.GetQueryParam(types.RelationshipQ))
+	return nil
+}
+
+func (dd DIDDocAuthorizationRequestService) Redirect(c services.ResolverContext) error {
+	migratedDid := migrations.MigrateDID(dd.GetDid())
+	queryRaw, _ := services.PrepareQueries(c)
+
+	path := types.RESOLVER_PATH + migratedDid + types.DID_AUTHORIZATION_PATH + utils.GetQuery(queryRaw)
+	return c.Redirect(http.StatusMovedPermanently, path)
+}
+
+func (dd *DIDDocAuthorizationRequestService) SpecificValidation(c services.ResolverContext) error {
+	// Only verificationMethod and relationship queries are allowed here
+	if len(types.ControllerAuthorizationQueries.DiffWithUrlValues(dd.Queries)) > 0 {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.RequestedContentType, nil, dd.IsDereferencing)
+	}
+
+	if !dd.Relationship.IsSupported() {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.RequestedContentType, nil, dd.IsDereferencing)
+	}
+
+	// Verification method should be a DID URL with a fragment
+	_, _, _, fragment, err := utils.TrySplitDIDUrl(dd.VerificationMethod)
+	if err != nil || fragment == "" {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.RequestedContentType, err, dd.IsDereferencing)
+	}
+	return nil
+}
+
+func (dd *DIDDocAuthorizationRequestService) Query(c services.ResolverContext) error {
+	result, err := c.DidDocService.CheckControllerAuthorization(dd.GetDid(), dd.VerificationMethod, dd.Relationship, dd.GetContentType())
+	if err != nil {
+		err.IsDereferencing = dd.IsDereferencing
+		return err
+	}
+	return dd.SetResponse(result)
+}
@@ -0,0 +1,60 @@
+package diddoc
+
+import (
+	"net/http"
+
+	"github.com/cheqd/did-resolver/migrations"
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+type DIDDocDiffRequestService struct {
+	services.BaseRequestService
+	FromVersion string
+	ToVersion   string
+}
+
+func (dd *DIDDocDiffRequestService) Setup(c services.ResolverContext) error {
+	dd.IsDereferencing = true
+	return nil
+}
+
+func (dd *DIDDocDiffRequestService) SpecificPrepare(c services.ResolverContext) error {
+	dd.FromVersion = dd.GetQueryParam(types.DiffFrom)
+	dd.ToVersion = dd.GetQueryParam(types.DiffTo)
+	return nil
+}
+
+func (dd DIDDocDiffRequestService) Redirect(c services.ResolverContext) error {
+	migratedDid := migrations.MigrateDID(dd.GetDid())
+	queryRaw, _ := services.PrepareQueries(c)
+
+	path := types.RESOLVER_PATH + migratedDid + types.DID_DIFF_PATH + utils.GetQuery(queryRaw)
+	return c.Redirect(http.StatusMovedPermanently, path)
+}
+
+func (dd *DIDDocDiffRequestService) SpecificValidation(c services.ResolverContext) error {
+	// Only from and to queries are allowed here
+	if len(types.DidDocDiffQueries.DiffWithUrlValues(dd.Queries)) > 0 {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.RequestedContentType, nil, dd.IsDereferencing)
+	}
+
+	if dd.FromVersion != "" && !utils.IsValidUUID(dd.FromVersion) {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.RequestedContentType, nil, dd.IsDereferencing)
+	}
+
+	if dd.ToVersion != "" && !utils.IsValidUUID(dd.ToVersion) {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.RequestedContentType, nil, dd.IsDereferencing)
+	}
+	return nil
+}
+
+func (dd *DIDDocDiffRequestService) Query(c services.ResolverContext) error {
+	result, err := c.DidDocService.GetDidDocVersionsDiff(dd.GetDid(), dd.FromVersion, dd.ToVersion, dd.GetContentType())
+	if err != nil {
+		err.IsDereferencing = dd.IsDereferencing
+		return err
+	}
+	return dd.SetResponse(result)
+}
@@ -0,0 +1,95 @@
+package diddoc
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/cheqd/did-resolver/migrations"
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+)
+
+type DIDDocHistoryRequestService struct {
+	services.BaseRequestService
+}
+
+func (dd *DIDDocHistoryRequestService) Setup(c services.ResolverContext) error {
+	dd.IsDereferencing = true
+	return nil
+}
+
+func (dd *DIDDocHistoryRequestService) BasicPrepare(c services.ResolverContext) error {
+	dd.RequestedContentType, dd.Profile = services.GetHistoryContentType(c.Request().Header.Get(echo.HeaderAccept))
+	if !dd.GetContentType().IsSupported() {
+		return types.NewRepresentationNotSupportedError(dd.GetDid(), types.JSON, nil, dd.IsDereferencing)
+	}
+	return dd.PrepareDidAndQueries(c)
+}
+
+func (dd *DIDDocHistoryRequestService) SpecificPrepare(c services.ResolverContext) error {
+	return nil
+}
+
+func (dd DIDDocHistoryRequestService) Redirect(c services.ResolverContext) error {
+	migratedDid := migrations.MigrateDID(dd.GetDid())
+
+	path := types.RESOLVER_PATH + migratedDid + types.DID_HISTORY_PATH
+	return c.Redirect(http.StatusMovedPermanently, path)
+}
+
+func (dd *DIDDocHistoryRequestService) SpecificValidation(c services.ResolverContext) error {
+	// We not allow query here
+	if len(dd.Queries) != 0 {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.RequestedContentType, nil, dd.IsDereferencing)
+	}
+	return nil
+}
+
+func (dd *DIDDocHistoryRequestService) Query(c services.ResolverContext) error {
+	result, err := c.DidDocService.GetDidDocHistory(dd.GetDid(), dd.GetContentType())
+	if err != nil {
+		err.IsDereferencing = dd.IsDereferencing
+		return err
+	}
+	return dd.SetResponse(result)
+}
+
+// Respond resolves DID Documents of the versions one by one and writes every version on a separate line
+// as soon as it's resolved, so large histories are neither buffered nor delayed until the last version
+func (dd DIDDocHistoryRequestService) Respond(c services.ResolverContext) error {
+	history, ok := dd.Result.(*types.DidDocHistory)
+	if !ok {
+		return types.NewInternalError(dd.GetDid(), dd.GetContentType(), errors.New("unexpected result type for DID history"), dd.IsDereferencing)
+	}
+
+	encoder := json.NewEncoder(c.Response())
+	err := c.DidDocService.StreamDidDocHistory(dd.GetDid(), history, func(entry types.DidDocHistoryEntry) error {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+		c.Response().Flush()
+		return nil
+	})
+	if err == nil {
+		return nil
+	}
+
+	identityErr, isIdentityErr := err.(*types.IdentityError)
+	if isIdentityErr {
+		identityErr.ContentType = dd.GetContentType()
+		identityErr.IsDereferencing = dd.IsDereferencing
+	}
+	// Until the first version is written, the error is returned with its status
+	if !c.Response().Committed {
+		return err
+	}
+	// Afterwards the status is already sent, so the error ends the stream as its last line
+	log.Error().Err(err).Msgf("Failed to write history of %s", dd.GetDid())
+	if isIdentityErr {
+		return encoder.Encode(identityErr.DisplayMessage())
+	}
+	return nil
+}
@@ -30,15 +30,12 @@ func (dr DIDDocMetadataService) Redirect(c services.ResolverContext) error {
 }
 
 func (dr *DIDDocMetadataService) SpecificValidation(c services.ResolverContext) error {
-	// We only allow one query parameter
-	if len(dr.Queries) > 1 {
-		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
-	}
-	return nil
+	// Only queries of linked resources list are allowed
+	return dr.ValidateResourceListQueries()
 }
 
 func (dr *DIDDocMetadataService) Query(c services.ResolverContext) error {
-	resolution, err := c.ResourceService.ResolveMetadataResources(dr.GetDid(), dr.GetContentType())
+	resolution, err := c.ResourceService.ResolveMetadataResources(dr.GetDid(), dr.ResourcePageRequest(), dr.GetContentType())
 	if err != nil {
 		err.IsDereferencing = dr.GetDereferencing()
 		return err
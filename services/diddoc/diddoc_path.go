@@ -0,0 +1,55 @@
+package diddoc
+
+import (
+	"net/http"
+
+	"github.com/cheqd/did-resolver/migrations"
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+type DIDDocPathRequestService struct {
+	services.BaseRequestService
+	Path string
+}
+
+func (dd *DIDDocPathRequestService) Setup(c services.ResolverContext) error {
+	dd.IsDereferencing = true
+	return nil
+}
+
+func (dd *DIDDocPathRequestService) SpecificPrepare(c services.ResolverContext) error {
+	dd.Path = "/" + c.Param("*")
+	return nil
+}
+
+func (dd DIDDocPathRequestService) Redirect(c services.ResolverContext) error {
+	migratedDid := migrations.MigrateDID(dd.GetDid())
+	queryRaw, _ := services.PrepareQueries(c)
+
+	path := types.RESOLVER_PATH + migratedDid + dd.Path + utils.GetQuery(queryRaw)
+	return c.Redirect(http.StatusMovedPermanently, path)
+}
+
+func (dd *DIDDocPathRequestService) SpecificValidation(c services.ResolverContext) error {
+	if !utils.DIDPathAbemptyRegexp.MatchString(dd.Path) {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.RequestedContentType, nil, dd.IsDereferencing)
+	}
+	return nil
+}
+
+func (dd *DIDDocPathRequestService) Query(c services.ResolverContext) error {
+	// Query of DID URL is passed to the service endpoint together with the path
+	queryRaw, _ := services.PrepareQueries(c)
+	result, err := c.DidDocService.DereferencePath(dd.GetDid(), dd.Path, queryRaw, dd.GetContentType())
+	if err != nil {
+		err.IsDereferencing = dd.IsDereferencing
+		return err
+	}
+	return dd.SetResponse(result)
+}
+
+func (dd DIDDocPathRequestService) Respond(c services.ResolverContext) error {
+	return services.RedirectToService(c, dd.Result)
+}
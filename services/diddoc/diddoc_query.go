@@ -70,6 +70,7 @@ func (dd *QueryDIDDocRequestService) SpecificValidation(c services.ResolverConte
 	versionTime := dd.GetQueryParam(types.VersionTime)
 	transformKeys := types.TransformKeysType(dd.GetQueryParam(types.TransformKeys))
 	service := dd.GetQueryParam(types.ServiceQ)
+	serviceType := dd.GetQueryParam(types.ServiceTypeQ)
 	relativeRef := dd.GetQueryParam(types.RelativeRef)
 	resourceId := dd.GetQueryParam(types.ResourceId)
 	resourceVersionTime := dd.GetQueryParam(types.ResourceVersionTime)
@@ -79,13 +80,13 @@ func (dd *QueryDIDDocRequestService) SpecificValidation(c services.ResolverConte
 		return types.NewRepresentationNotSupportedError(dd.GetDid(), dd.GetContentType(), nil, dd.IsDereferencing)
 	}
 
-	// relativeRef should be only with service parameter also
-	if relativeRef != "" && service == "" {
+	// relativeRef should be only with service or serviceType parameter also
+	if relativeRef != "" && service == "" && serviceType == "" {
 		return types.NewRepresentationNotSupportedError(dd.GetDid(), dd.GetContentType(), nil, dd.IsDereferencing)
 	}
 
-	// service query is permitted only for diddoc queries
-	if service != "" && dd.AreResourceQueriesPlaced(c) {
+	// service and serviceType queries are permitted only for diddoc queries
+	if (service != "" || serviceType != "") && dd.AreResourceQueriesPlaced(c) {
 		return types.NewRepresentationNotSupportedError(dd.GetDid(), dd.GetContentType(), nil, dd.IsDereferencing)
 	}
 
@@ -99,11 +100,15 @@ func (dd *QueryDIDDocRequestService) SpecificValidation(c services.ResolverConte
 		return types.NewRepresentationNotSupportedError(dd.GetDid(), dd.GetContentType(), nil, dd.IsDereferencing)
 	}
 
-	// value if resourceMetadata can be only true or false
-	if resourceMetadata != "" && resourceMetadata != "true" && resourceMetadata != "false" {
+	// value if resourceMetadata can be only true, false or summary
+	if resourceMetadata != "" && resourceMetadata != "true" && resourceMetadata != "false" && resourceMetadata != types.ResourceMetadataSummary {
 		return types.NewRepresentationNotSupportedError(dd.GetDid(), dd.GetContentType(), nil, dd.IsDereferencing)
 	}
 
+	if _, err := types.NewResourceListOptions(dd.Queries); err != nil {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.GetContentType(), err, dd.IsDereferencing)
+	}
+
 	// if profile is W3IDDIDURL then metadata should be true
 	if resourceMetadata == "false" && dd.Profile == types.W3IDDIDURL {
 		return types.NewInvalidDidUrlError(dd.GetDid(), dd.GetContentType(), nil, dd.IsDereferencing)
@@ -119,7 +124,7 @@ func (dd *QueryDIDDocRequestService) SpecificValidation(c services.ResolverConte
 	}
 
 	// Validate time format
-	if resourceVersionTime != "" {
+	if resourceVersionTime != "" && resourceVersionTime != types.ResourceVersionTimeNow {
 		_, err := utils.ParseFromStringTimeToGoTime(resourceVersionTime)
 		if err != nil {
 			log.Debug().Msg(err.Error())
@@ -359,7 +364,7 @@ func (dd *QueryDIDDocRequestService) IsResourceData(result types.ResolutionResul
 
 func (dd QueryDIDDocRequestService) Respond(c services.ResolverContext) error {
 	if dd.Result.IsRedirect() {
-		return c.Redirect(http.StatusSeeOther, string(dd.Result.GetBytes()))
+		return services.RedirectToService(c, dd.Result)
 	}
 	if dd.IsResourceData(dd.Result) {
 		return dd.RespondWithResourceData(c)
@@ -36,15 +36,12 @@ func (dd *DIDDocVersionMetadataRequestService) SpecificValidation(c services.Res
 		return types.NewInvalidDidUrlError(dd.Version, dd.RequestedContentType, nil, dd.IsDereferencing)
 	}
 
-	// We not allow query here
-	if len(dd.Queries) != 0 {
-		return types.NewInvalidDidUrlError(dd.GetDid(), dd.RequestedContentType, nil, dd.IsDereferencing)
-	}
-	return nil
+	// Only queries of linked resources list are allowed
+	return dd.ValidateResourceListQueries()
 }
 
 func (dd *DIDDocVersionMetadataRequestService) Query(c services.ResolverContext) error {
-	result, err := c.DidDocService.GetDIDDocVersionsMetadata(dd.GetDid(), dd.Version, dd.GetContentType())
+	result, err := c.DidDocService.GetDIDDocVersionsMetadata(dd.GetDid(), dd.Version, dd.ResourcePageRequest(), dd.GetContentType())
 	if err != nil {
 		err.IsDereferencing = dd.IsDereferencing
 		return err
@@ -20,18 +20,24 @@ import (
 //	@Param			fragmentId				query		string				false	"#Fragment"
 //	@Param			versionId				query		string				false	"Version"
 //	@Param			versionTime				query		string				false	"Created of Updated time of DID Document"
-//	@Param			transformKeys			query		string				false	"Can transform Verification Method into another type"
+//	@Param			transformKeys			query		string				false	"Can transform Verification Method into another type. Keys converted from Ed25519VerificationKey2020 contain only the 32-byte public key, without the ed25519-pub multicodec prefix"
 //	@Param			service					query		string				false	"Redirects to Service Endpoint"
+//	@Param			serviceType				query		string				false	"Redirects to Service Endpoint of the given type with the highest priority"
 //	@Param			relativeRef				query		string				false	"Addition to Service Endpoint"
 //	@Param			metadata				query		string				false	"Show only metadata of DID Document"
 //	@Param			resourceId				query		string				false	"Filter by ResourceId"
 //	@Param			resourceCollectionId	query		string				false	"Filter by CollectionId"
 //	@Param			resourceType			query		string				false	"Filter by Resource Type"
 //	@Param			resourceName			query		string				false	"Filter by Resource Name"
-//	@Param			resourceVersion			query		string				false	"Filter by Resource Version"
+//	@Param			resourceVersion			query		string				false	"Filter by Resource Version, or latest or first version of every Resource Name and Type"
 //	@Param			resourceVersionTime		query		string				false	"Get the nearest resource by creation time"
-//	@Param			resourceMetadata		query		string				false	"Show only metadata of resources"
+//	@Param			resourceMetadata		query		string				false	"Show only metadata of resources, or summary to count them per type and name"
 //	@Param			checksum				query		string				false	"Sanity check that Checksum of resource is the same as expected"
+//	@Param			validateAgainst			query		string				false	"DID URL of the Resource with JSON Schema to validate the Resource data against, or $schema for the schema declared by the data"
+//	@Param			resourceLimit			query		int					false	"Maximum number of resources in linkedResourceMetadata"
+//	@Param			resourceCursor			query		string				false	"nextCursor of the previous page of resources"
+//	@Param			resourceSort			query		string				false	"Sort resources by created, name or version. Prefix - sorts in descending order"
+//	@Param			resourceFields			query		string				false	"Comma separated fields of resources to return"
 //	@success		200						{object}	types.DidResolution	"versionId, versionTime, transformKeys returns Full DID Document"
 //	@Failure		400						{object}	types.IdentityError
 //	@Failure		404						{object}	types.IdentityError
@@ -102,14 +108,19 @@ func DidDocVersionEchoHandler(c echo.Context) error {
 //	@Tags			DID Resolution
 //	@Accept			application/did+ld+json,application/ld+json,application/did+jsonww
 //	@Produce		application/did+ld+json,application/ld+json,application/did+json
-//	@Param			did			path		string	true	"Full DID with unique identifier"
-//	@Param			versionId	path		string	true	"version of a DID document"
-//	@Success		200			{object}	types.DidDereferencing
-//	@Failure		400			{object}	types.IdentityError
-//	@Failure		404			{object}	types.IdentityError
-//	@Failure		406			{object}	types.IdentityError
-//	@Failure		500			{object}	types.IdentityError
-//	@Failure		501			{object}	types.IdentityError
+//	@Param			did					path		string	true	"Full DID with unique identifier"
+//	@Param			versionId			path		string	true	"version of a DID document"
+//	@Param			resourceMetadata	query		string	false	"summary to count resources per type and name"
+//	@Param			resourceLimit		query		int		false	"Maximum number of resources in linkedResourceMetadata"
+//	@Param			resourceCursor		query		string	false	"nextCursor of the previous page of resources"
+//	@Param			resourceSort		query		string	false	"Sort resources by created, name or version. Prefix - sorts in descending order"
+//	@Param			resourceFields		query		string	false	"Comma separated fields of resources to return"
+//	@Success		200					{object}	types.DidDereferencing
+//	@Failure		400					{object}	types.IdentityError
+//	@Failure		404					{object}	types.IdentityError
+//	@Failure		406					{object}	types.IdentityError
+//	@Failure		500					{object}	types.IdentityError
+//	@Failure		501					{object}	types.IdentityError
 //	@Router			/{did}/version/{versionId}/metadata [get]
 func DidDocVersionMetadataEchoHandler(c echo.Context) error {
 	return services.EchoWrapHandler(&DIDDocVersionMetadataRequestService{})(c)
@@ -122,32 +133,126 @@ func DidDocVersionMetadataEchoHandler(c echo.Context) error {
 //	@Tags			DID Resolution
 //	@Accept			application/did+ld+json,application/ld+json,application/did+json
 //	@Produce		application/did+ld+json,application/ld+json,application/did+json
+//	@Param			did					path		string	true	"Full DID with unique identifier"
+//	@Param			resourceMetadata	query		string	false	"summary to count resources per type and name"
+//	@Param			resourceLimit		query		int		false	"Maximum number of resources in linkedResourceMetadata"
+//	@Param			resourceSort		query		string	false	"Sort resources by created, name or version. Prefix - sorts in descending order"
+//	@Param			resourceFields		query		string	false	"Comma separated fields of resources to return"
+//	@Success		200					{object}	types.ResourceDereferencing{contentStream=types.DereferencedDidVersionsList}
+//	@Failure		400					{object}	types.IdentityError
+//	@Failure		404					{object}	types.IdentityError
+//	@Failure		406					{object}	types.IdentityError
+//	@Failure		500					{object}	types.IdentityError
+//	@Failure		501					{object}	types.IdentityError
+//	@Router			/{did}/versions [get]
+func DidDocAllVersionMetadataEchoHandler(c echo.Context) error {
+	return services.EchoWrapHandler(&DIDDocAllVersionMetadataRequestService{})(c)
+}
+
+// DidDocDiffEchoHandler godoc
+//
+//	@Summary		Compare two DID Document versions on did:cheqd
+//	@Description	Get the difference between two versions of a DID Document ("DIDDoc") as RFC 6902 JSON Patch with a summary of changed keys, services and controllers.
+//	@Description	If "from" is omitted, the version preceding "to" is used. If "to" is omitted, the latest version is used.
+//	@Tags			DID Resolution
+//	@Accept			application/did+ld+json,application/ld+json,application/did+json
+//	@Produce		application/did+ld+json,application/ld+json,application/did+json
+//	@Param			did		path		string	true	"Full DID with unique identifier"
+//	@Param			from	query		string	false	"Version ID to compare from"
+//	@Param			to		query		string	false	"Version ID to compare to"
+//	@Success		200		{object}	types.DidDereferencing{contentStream=types.DidDocDiff}
+//	@Failure		400		{object}	types.IdentityError
+//	@Failure		404		{object}	types.IdentityError
+//	@Failure		406		{object}	types.IdentityError
+//	@Failure		500		{object}	types.IdentityError
+//	@Failure		501		{object}	types.IdentityError
+//	@Router			/{did}/diff [get]
+func DidDocDiffEchoHandler(c echo.Context) error {
+	return services.EchoWrapHandler(&DIDDocDiffRequestService{})(c)
+}
+
+// DidDocHistoryEchoHandler godoc
+//
+//	@Summary		Export the full history of a DID Document on did:cheqd
+//	@Description	Fetch all versions of a DID Document ("DIDDoc") together with their metadata and linked resources.
+//	@Description	Versions are returned in chronological order in JSON Lines format, one version per line.
+//	@Tags			DID Resolution
+//	@Accept			application/did+ld+json,application/ld+json,application/did+json
+//	@Produce		application/jsonl
 //	@Param			did	path		string	true	"Full DID with unique identifier"
-//	@Success		200	{object}	types.ResourceDereferencing{contentStream=types.DereferencedDidVersionsList}
+//	@Success		200	{object}	types.DidDocHistoryEntry
 //	@Failure		400	{object}	types.IdentityError
 //	@Failure		404	{object}	types.IdentityError
 //	@Failure		406	{object}	types.IdentityError
 //	@Failure		500	{object}	types.IdentityError
 //	@Failure		501	{object}	types.IdentityError
-//	@Router			/{did}/versions [get]
-func DidDocAllVersionMetadataEchoHandler(c echo.Context) error {
-	return services.EchoWrapHandler(&DIDDocAllVersionMetadataRequestService{})(c)
+//	@Router			/{did}/history [get]
+func DidDocHistoryEchoHandler(c echo.Context) error {
+	return services.EchoWrapHandler(&DIDDocHistoryRequestService{})(c)
 }
 
-// DidDocMetadataEchoHandler godoc
+// DidDocAuthorizationEchoHandler godoc
 //
-//	@Summary		Fetch metadata for all Resources
-//	@Description	Get metadata for all Resources within a DID Resource Collection
-//	@Tags			Resource Resolution
+//	@Summary		Check verification method authorization on did:cheqd
+//	@Description	Check whether a verification method is authorised to act for a DID under the given verification relationship.
+//	@Description	Controllers of the DID are resolved recursively and the shortest chain of DIDs which led to the answer is returned.
+//	@Tags			DID Resolution
 //	@Accept			application/did+ld+json,application/ld+json,application/did+json
 //	@Produce		application/did+ld+json,application/ld+json,application/did+json
-//	@Param			did	path		string	true	"Full DID with unique identifier"
-//	@Success		200	{object}	types.ResourceDereferencing{contentStream=types.ResolutionDidDocMetadata}
+//	@Param			did					path		string	true	"Full DID with unique identifier"
+//	@Param			verificationMethod	query		string	true	"DID URL of the verification method"
+//	@Param			relationship		query		string	true	"Verification relationship"	Enums(authentication, assertionMethod, capabilityInvocation, capabilityDelegation, keyAgreement)
+//	@Success		200					{object}	types.DidDereferencing{contentStream=types.ControllerAuthorization}
+//	@Failure		400					{object}	types.IdentityError
+//	@Failure		404					{object}	types.IdentityError
+//	@Failure		406					{object}	types.IdentityError
+//	@Failure		500					{object}	types.IdentityError
+//	@Failure		501					{object}	types.IdentityError
+//	@Router			/{did}/authorization [get]
+func DidDocAuthorizationEchoHandler(c echo.Context) error {
+	return services.EchoWrapHandler(&DIDDocAuthorizationRequestService{})(c)
+}
+
+// DidDocPathEchoHandler godoc
+//
+//	@Summary		Dereference DID URL path on did:cheqd
+//	@Description	Redirects DID URL with a path to the endpoint of LinkedDomains service, or of the service configured by DID_URL_PATH_SERVICE.
+//	@Description	The path and the query are resolved relative to the service endpoint.
+//	@Tags			DID Resolution
+//	@Accept			application/did+ld+json,application/ld+json,application/did+json
+//	@Produce		application/did+ld+json,application/ld+json,application/did+json
+//	@Param			did		path	string	true	"Full DID with unique identifier"
+//	@Param			path	path	string	true	"Path of DID URL"
+//	@Success		303
 //	@Failure		400	{object}	types.IdentityError
 //	@Failure		404	{object}	types.IdentityError
 //	@Failure		406	{object}	types.IdentityError
 //	@Failure		500	{object}	types.IdentityError
 //	@Failure		501	{object}	types.IdentityError
+//	@Router			/{did}/{path} [get]
+func DidDocPathEchoHandler(c echo.Context) error {
+	return services.EchoWrapHandler(&DIDDocPathRequestService{})(c)
+}
+
+// DidDocMetadataEchoHandler godoc
+//
+//	@Summary		Fetch metadata for all Resources
+//	@Description	Get metadata for all Resources within a DID Resource Collection
+//	@Tags			Resource Resolution
+//	@Accept			application/did+ld+json,application/ld+json,application/did+json
+//	@Produce		application/did+ld+json,application/ld+json,application/did+json
+//	@Param			did					path		string	true	"Full DID with unique identifier"
+//	@Param			resourceMetadata	query		string	false	"summary to count resources per type and name"
+//	@Param			resourceLimit		query		int		false	"Maximum number of resources in linkedResourceMetadata"
+//	@Param			resourceCursor		query		string	false	"nextCursor of the previous page of resources"
+//	@Param			resourceSort		query		string	false	"Sort resources by created, name or version. Prefix - sorts in descending order"
+//	@Param			resourceFields		query		string	false	"Comma separated fields of resources to return"
+//	@Success		200					{object}	types.ResourceDereferencing{contentStream=types.ResolutionDidDocMetadata}
+//	@Failure		400					{object}	types.IdentityError
+//	@Failure		404					{object}	types.IdentityError
+//	@Failure		406					{object}	types.IdentityError
+//	@Failure		500					{object}	types.IdentityError
+//	@Failure		501					{object}	types.IdentityError
 //	@Router			/{did}/metadata [get]
 func DidDocMetadataEchoHandler(c echo.Context) error {
 	// Get Accept header
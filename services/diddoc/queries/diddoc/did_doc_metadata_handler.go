@@ -36,7 +36,7 @@ func (dd *DidDocMetadataHandler) Handle(c services.ResolverContext, service serv
 
 	// Filter in descending order
 	sort.Sort(filteredResources)
-	result, _err := c.DidDocService.GetDIDDocVersionsMetadata(service.GetDid(), versionId, service.GetContentType())
+	result, _err := c.DidDocService.GetDIDDocVersionsMetadata(service.GetDid(), versionId, nil, service.GetContentType())
 	if _err != nil {
 		_err.IsDereferencing = dd.IsDereferencing
 		return nil, _err
@@ -45,6 +45,9 @@ func (dd *DidDocMetadataHandler) Handle(c services.ResolverContext, service serv
 	// Fill the resources
 	resultMetadata := result.Metadata
 	resultMetadata.Resources = filteredResources
+	resultMetadata.Deactivated = allVersions[0].Deactivated
+	resultMetadata.ValidFrom = allVersions[0].ValidFrom
+	resultMetadata.ValidUntil = allVersions[0].ValidUntil
 	result.Metadata = resultMetadata
 
 	return dd.Continue(c, service, result)
@@ -46,6 +46,9 @@ func (dd *DidDocResolveHandler) Handle(c services.ResolverContext, service servi
 		result.Metadata = nil
 	} else {
 		result.Metadata.Resources = filteredResources
+		result.Metadata.Deactivated = allVersions[0].Deactivated
+		result.Metadata.ValidFrom = allVersions[0].ValidFrom
+		result.Metadata.ValidUntil = allVersions[0].ValidUntil
 	}
 	// Call the next handler
 	return dd.Continue(c, service, result)
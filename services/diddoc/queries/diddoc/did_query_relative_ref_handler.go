@@ -4,6 +4,7 @@ import (
 	"github.com/cheqd/did-resolver/services"
 	"github.com/cheqd/did-resolver/services/diddoc/queries"
 	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
 )
 
 type RelativeRefHandler struct {
@@ -25,6 +26,17 @@ func (r *RelativeRefHandler) Handle(c services.ResolverContext, service services
 		return r.Continue(c, service, response)
 	}
 
+	// Resolve relativeRef against every service endpoint as described in RFC 3986, section 5.2
+	endpoints := serviceResult.GetServiceEndpoints()
+	results := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		result, err := utils.ResolveReference(endpoint, relativeRef)
+		if err != nil {
+			return nil, types.NewInvalidDidUrlError(service.GetDid(), service.GetContentType(), err, service.GetDereferencing())
+		}
+		results[i] = result
+	}
+
 	// Call the next handler
-	return r.Continue(c, service, types.NewServiceResult(serviceResult.GetServiceEndpoint()+relativeRef))
+	return r.Continue(c, service, types.NewServiceResult(results...))
 }
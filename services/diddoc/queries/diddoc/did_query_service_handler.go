@@ -13,9 +13,10 @@ type ServiceHandler struct {
 func (s *ServiceHandler) Handle(c services.ResolverContext, service services.RequestServiceI, response types.ResolutionResultI) (types.ResolutionResultI, error) {
 	// Get Params
 	serviceValue := service.GetQueryParam(types.ServiceQ)
+	serviceType := service.GetQueryParam(types.ServiceTypeQ)
 
-	// If serviceValue is empty, call the next handler. We don't need to handle it here
-	if serviceValue == "" {
+	// If both serviceValue and serviceType are empty, call the next handler. We don't need to handle it here
+	if serviceValue == "" && serviceType == "" {
 		return s.Continue(c, service, response)
 	}
 	// We expect here only DidResolution
@@ -24,14 +25,15 @@ func (s *ServiceHandler) Handle(c services.ResolverContext, service services.Req
 		return nil, types.NewInternalError(service.GetDid(), types.DIDJSONLD, nil, service.GetDereferencing())
 	}
 
-	result, err := didResolution.GetServiceByName(serviceValue)
+	// If several services match, the one with the highest priority is taken with all its endpoints
+	endpoints, err := didResolution.GetServiceEndpoints(serviceValue, serviceType)
 	if err != nil {
 		return nil, types.NewInternalError(service.GetDid(), types.JSONLD, nil, service.GetDereferencing())
 	}
 
-	if result == "" {
+	if len(endpoints) == 0 {
 		return nil, types.NewNotFoundError(service.GetDid(), service.GetContentType(), nil, service.GetDereferencing())
 	}
 	// Call the next handler
-	return s.Continue(c, service, types.NewServiceResult(result))
+	return s.Continue(c, service, types.NewServiceResult(endpoints...))
 }
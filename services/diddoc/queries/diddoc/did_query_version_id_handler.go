@@ -31,6 +31,12 @@ func (v *VersionIdHandler) Handle(c services.ResolverContext, service services.R
 	}
 
 	versionFiltered[0].Resources = allVersions.GetResourcesBeforeNextVersion(versionId)
+	// Only versionTime resolution reports the state of the DID as of the requested time.
+	// VersionTimeHandler gets the list already filtered, so the state is computed here.
+	if service.GetQueryParam(types.VersionTime) != "" {
+		versionFiltered[0].Deactivated = allVersions.IsDeactivatedInVersion(versionId)
+		versionFiltered[0].ValidFrom, versionFiltered[0].ValidUntil = allVersions.GetVersionWindow(versionId)
+	}
 
 	// Call the next handler
 	return v.Continue(c, service, versionFiltered)
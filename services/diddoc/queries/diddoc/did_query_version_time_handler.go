@@ -1,6 +1,8 @@
 package diddoc
 
 import (
+	"fmt"
+
 	"github.com/cheqd/did-resolver/services"
 	"github.com/cheqd/did-resolver/services/diddoc/queries"
 	"github.com/cheqd/did-resolver/types"
@@ -31,8 +33,9 @@ func (v *VersionTimeHandler) Handle(c services.ResolverContext, service services
 		return nil, types.NewInternalError(service.GetDid(), contentType, _err, service.GetDereferencing())
 	}
 
+	// There is no version before the DID was created
 	if versionId == "" {
-		return nil, types.NewNotFoundError(service.GetDid(), contentType, nil, service.GetDereferencing())
+		return nil, types.NewNotFoundError(service.GetDid(), contentType, fmt.Errorf("DID was created after %s", versionTime), service.GetDereferencing())
 	}
 
 	versionsFiltered := allVersions.GetByVersionId(versionId)
@@ -40,6 +43,13 @@ func (v *VersionTimeHandler) Handle(c services.ResolverContext, service services
 		return nil, types.NewInternalError(service.GetDid(), contentType, nil, service.GetDereferencing())
 	}
 
+	// Report the state of the DID as of the requested time.
+	// If versionId is also placed, the list is already filtered and the state is filled by VersionIdHandler
+	if service.GetQueryParam(types.VersionId) == "" {
+		versionsFiltered[0].Deactivated = allVersions.IsDeactivatedInVersion(versionId)
+		versionsFiltered[0].ValidFrom, versionsFiltered[0].ValidUntil = allVersions.GetVersionWindow(versionId)
+	}
+
 	// Call the next handler
 	return v.Continue(c, service, versionsFiltered)
 }
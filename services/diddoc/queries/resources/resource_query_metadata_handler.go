@@ -27,8 +27,9 @@ func (d *ResourceMetadataHandler) Handle(c services.ResolverContext, service ser
 	if isOnlyMetadataQuery && profile != types.W3IDDIDURL {
 		d.IsDereferencing = false
 	}
+	isMetadataList := resourceMetadata == "true" || resourceMetadata == types.ResourceMetadataSummary
 	// If its not OnlyMetadataQuery and ResourceMetadata!=true and Invalid List of resources, return an error
-	if !isOnlyMetadataQuery && c.QueryParam(types.ResourceMetadata) != "true" && IsInvalidResourceCollection(didResolution.Metadata.Resources) {
+	if !isOnlyMetadataQuery && !isMetadataList && IsInvalidResourceCollection(didResolution.Metadata.Resources) {
 		return nil, types.NewInvalidDidUrlError(service.GetDid(), service.GetContentType(), nil, d.IsDereferencing)
 	}
 	// return didResolution result if dereferencing is false
@@ -39,7 +40,8 @@ func (d *ResourceMetadataHandler) Handle(c services.ResolverContext, service ser
 		return d.Continue(c, service, didResolution)
 	}
 
-	if resourceMetadata == "true" {
+	// The summary is made from the list of resources when the response is set up
+	if isMetadataList {
 		dereferencingResult := types.NewResourceDereferencingFromResources(
 			service.GetDid(), service.GetContentType(), &didResolution.Metadata.Resources,
 		)
@@ -50,12 +52,22 @@ func (d *ResourceMetadataHandler) Handle(c services.ResolverContext, service ser
 	// They are sorted in descending order by default
 	resource := didResolution.Metadata.Resources[0]
 
-	if contentType == types.JSONLD && profile == types.W3IDDIDURL {
+	// Result of schema validation is returned in dereferencing metadata, so the data is returned with it
+	validateAgainst := service.GetQueryParam(types.ValidateAgainstQ)
+	if (contentType == types.JSONLD && profile == types.W3IDDIDURL) || validateAgainst != "" {
 		dereferenceResult, _err := c.ResourceService.DereferenceResourceDataWithMetadata(service.GetDid(), resource.ResourceId, service.GetContentType())
 		if _err != nil {
 			return nil, _err
 		}
 
+		if validateAgainst != "" {
+			validation, _err := c.ResourceService.ValidateResourceData(service.GetDid(), dereferenceResult.ContentStream.GetBytes(), validateAgainst, service.GetContentType())
+			if _err != nil {
+				return nil, _err
+			}
+			dereferenceResult.DereferencingMetadata.SchemaValidation = validation
+		}
+
 		// Call the next handler
 		return d.Continue(c, service, dereferenceResult)
 	}
@@ -23,8 +23,16 @@ func (d *ResourceVersionHandler) Handle(c services.ResolverContext, service serv
 		return nil, err
 	}
 
-	// Filter the list of metadata by the resourceCollectionId
-	resourceCollectionFiltered := didResolution.Metadata.Resources.FilterByResourceVersion(resourceVersion)
+	// latest and first are versions in the order of creation, not the values of resourceVersion
+	var resourceCollectionFiltered types.DereferencedResourceList
+	switch resourceVersion {
+	case types.ResourceVersionLatest:
+		resourceCollectionFiltered = didResolution.Metadata.Resources.FilterLatestVersions()
+	case types.ResourceVersionFirst:
+		resourceCollectionFiltered = didResolution.Metadata.Resources.FilterFirstVersions()
+	default:
+		resourceCollectionFiltered = didResolution.Metadata.Resources.FilterByResourceVersion(resourceVersion)
+	}
 	if len(resourceCollectionFiltered) == 0 {
 		return nil, types.NewNotFoundError(service.GetDid(), service.GetContentType(), nil, d.IsDereferencing)
 	}
@@ -1,6 +1,8 @@
 package resources
 
 import (
+	"time"
+
 	"github.com/cheqd/did-resolver/services"
 	"github.com/cheqd/did-resolver/services/diddoc/queries"
 	"github.com/cheqd/did-resolver/types"
@@ -16,6 +18,9 @@ func (d *ResourceVersionTimeHandler) Handle(c services.ResolverContext, service
 	if resourceVersionTime == "" {
 		return d.Continue(c, service, response)
 	}
+	if resourceVersionTime == types.ResourceVersionTimeNow {
+		resourceVersionTime = time.Now().UTC().Format(time.RFC3339)
+	}
 
 	// Cast to just list of resources
 	didResolution, err := d.CastToContent(service, response)
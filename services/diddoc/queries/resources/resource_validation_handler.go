@@ -21,7 +21,7 @@ func (d *ResourceValidationHandler) Handle(c services.ResolverContext, service s
 		return nil, err
 	}
 
-	if resourceMetadata == "true" {
+	if resourceMetadata == "true" || resourceMetadata == types.ResourceMetadataSummary {
 		return d.Continue(c, service, didResolution)
 	}
 
@@ -13,4 +13,9 @@ func SetRoutes(e *echo.Echo) {
 	e.GET(types.RESOLVER_PATH+":did"+types.DID_VERSION_PATH+":version", DidDocVersionEchoHandler)
 	e.GET(types.RESOLVER_PATH+":did"+types.DID_VERSION_PATH+":version/metadata", DidDocVersionMetadataEchoHandler)
 	e.GET(types.RESOLVER_PATH+":did"+types.DID_VERSIONS_PATH, DidDocAllVersionMetadataEchoHandler)
+	e.GET(types.RESOLVER_PATH+":did"+types.DID_DIFF_PATH, DidDocDiffEchoHandler)
+	e.GET(types.RESOLVER_PATH+":did"+types.DID_HISTORY_PATH, DidDocHistoryEchoHandler)
+	e.GET(types.RESOLVER_PATH+":did"+types.DID_AUTHORIZATION_PATH, DidDocAuthorizationEchoHandler)
+	// Should be the last one, as any other path of DID URL is dereferenced through the service
+	e.GET(types.RESOLVER_PATH+":did"+types.DID_URL_PATH, DidDocPathEchoHandler)
 }
@@ -3,14 +3,25 @@ package services
 import (
 	"strings"
 
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
 	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
 
 	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+const (
+	// Maximum number of DID Document versions requested from the ledger at the same time
+	didDocHistoryConcurrency = 8
+	// Maximum number of DIDs in a controller chain, including the requested one
+	controllerChainDepthLimit = 5
 )
 
 type DIDDocService struct {
 	didMethod     string
 	ledgerService LedgerServiceI
+	// Id of the service used for DID URL path dereferencing. If empty, LinkedDomains service is used
+	pathServiceId string
 }
 
 func NewDIDDocService(didMethod string, ledgerService LedgerServiceI) DIDDocService {
@@ -20,6 +31,10 @@ func NewDIDDocService(didMethod string, ledgerService LedgerServiceI) DIDDocServ
 	}
 }
 
+func (dds *DIDDocService) SetPathServiceId(serviceId string) {
+	dds.pathServiceId = serviceId
+}
+
 func (DIDDocService) GetDIDFragment(fragmentId string, didDoc types.DidDoc) types.ContentStreamI {
 	for _, verMethod := range didDoc.VerificationMethod {
 		if strings.Contains(verMethod.Id, fragmentId) {
@@ -79,7 +94,9 @@ func (dds DIDDocService) Resolve(did string, version string, contentType types.C
 	return &result, nil
 }
 
-func (dds DIDDocService) GetDIDDocVersionsMetadata(did string, version string, contentType types.ContentType) (*types.DidResolution, *types.IdentityError) {
+// GetDIDDocVersionsMetadata returns metadata of the DID Document version with all resources of the collection,
+// or with the page of them if the page is set
+func (dds DIDDocService) GetDIDDocVersionsMetadata(did string, version string, page *query.PageRequest, contentType types.ContentType) (*types.DidResolution, *types.IdentityError) {
 	resolutionMetadata := types.NewResolutionMetadata(did, contentType, "")
 	protoDidDocWithMetadata, err := dds.ledgerService.QueryDIDDoc(did, version)
 	if err != nil {
@@ -87,7 +104,7 @@ func (dds DIDDocService) GetDIDDocVersionsMetadata(did string, version string, c
 		return nil, err
 	}
 
-	resources, err := dds.ledgerService.QueryCollectionResources(did)
+	resources, pagination, err := queryCollection(dds.ledgerService, did, page)
 	if err != nil {
 		err.ContentType = contentType
 		return nil, err
@@ -99,6 +116,7 @@ func (dds DIDDocService) GetDIDDocVersionsMetadata(did string, version string, c
 	}
 
 	metadata := types.NewResolutionDidDocMetadata(did, protoDidDocWithMetadata.Metadata, resources)
+	metadata.ResourcePagination = pagination
 
 	return &types.DidResolution{Context: context, Metadata: metadata, ResolutionMetadata: resolutionMetadata}, nil
 }
@@ -135,6 +153,215 @@ func (dds DIDDocService) GetAllDidDocVersionsMetadata(did string, contentType ty
 	return &types.DidDereferencing{Context: context, ContentStream: contentStream, DereferencingMetadata: dereferenceMetadata}, nil
 }
 
+// GetDidDocVersionsDiff compares two versions of a DID Document.
+// If toVersion is empty the latest version is used, if fromVersion is empty the version preceding toVersion is used.
+func (dds DIDDocService) GetDidDocVersionsDiff(did string, fromVersion string, toVersion string, contentType types.ContentType) (*types.DidDereferencing, *types.IdentityError) {
+	dereferenceMetadata := types.NewDereferencingMetadata(did, contentType, "")
+
+	toDidDoc, err := dds.ledgerService.QueryDIDDoc(did, toVersion)
+	if err != nil {
+		err.ContentType = contentType
+		return nil, err
+	}
+
+	if fromVersion == "" {
+		fromVersion = toDidDoc.Metadata.PreviousVersionId
+		// The first version has nothing to be compared with
+		if fromVersion == "" {
+			return nil, types.NewNotFoundError(did, contentType, nil, true)
+		}
+	}
+
+	fromDidDoc, err := dds.ledgerService.QueryDIDDoc(did, fromVersion)
+	if err != nil {
+		err.ContentType = contentType
+		return nil, err
+	}
+
+	var context string
+	if contentType == types.DIDJSONLD || contentType == types.JSONLD {
+		context = types.ResolutionSchemaJSONLD
+	}
+
+	contentStream, diffErr := types.NewDidDocDiff(did, fromDidDoc, toDidDoc)
+	if diffErr != nil {
+		return nil, types.NewInternalError(did, contentType, diffErr, true)
+	}
+
+	return &types.DidDereferencing{Context: context, ContentStream: contentStream, DereferencingMetadata: dereferenceMetadata}, nil
+}
+
+// GetDidDocHistory returns all versions of a DID Document in chronological order.
+// Every version carries the resources which were created before the next version.
+// DID Documents of the versions are resolved by StreamDidDocHistory.
+func (dds DIDDocService) GetDidDocHistory(did string, contentType types.ContentType) (*types.DidDocHistory, *types.IdentityError) {
+	versions, err := dds.ledgerService.QueryAllDidDocVersionsMetadata(did)
+	if err != nil {
+		err.ContentType = contentType
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, types.NewNotFoundError(did, contentType, nil, true)
+	}
+
+	resources, err := dds.ledgerService.QueryCollectionResources(did)
+	if err != nil {
+		err.ContentType = contentType
+		return nil, err
+	}
+
+	versionsList := types.NewDereferencedDidVersionsList(did, versions, resources).Versions
+	return types.NewDidDocHistory(versionsList, contentType), nil
+}
+
+type didDocResult struct {
+	didDoc *didTypes.DidDocWithMetadata
+	err    *types.IdentityError
+}
+
+// StreamDidDocHistory resolves DID Documents of the history and passes every version to write
+// in chronological order as soon as it's resolved. Up to didDocHistoryConcurrency versions
+// are requested from the ledger ahead of the written one.
+func (dds DIDDocService) StreamDidDocHistory(did string, history *types.DidDocHistory, write func(types.DidDocHistoryEntry) error) error {
+	results := make([]chan didDocResult, len(history.Versions))
+	for i := range results {
+		results[i] = make(chan didDocResult, 1)
+	}
+	semaphore := make(chan struct{}, didDocHistoryConcurrency)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go func() {
+		for i, version := range history.Versions {
+			select {
+			case semaphore <- struct{}{}:
+			case <-stop:
+				return
+			}
+			go func(i int, versionId string) {
+				didDoc, err := dds.ledgerService.QueryDIDDoc(did, versionId)
+				results[i] <- didDocResult{didDoc: didDoc, err: err}
+			}(i, version.VersionId)
+		}
+	}()
+
+	for i := range history.Versions {
+		result := <-results[i]
+		// The slot is released once the version is written, so at most didDocHistoryConcurrency versions wait in memory
+		<-semaphore
+		if result.err != nil {
+			return result.err
+		}
+		if err := write(history.NewEntry(i, result.didDoc.DidDoc)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DereferencePath maps the path of DID URL onto the endpoints of the configured service or LinkedDomains service.
+// The path is appended to the path of every service endpoint.
+func (dds DIDDocService) DereferencePath(did string, path string, query string, contentType types.ContentType) (*types.ServiceResult, *types.IdentityError) {
+	didResolution, err := dds.Resolve(did, "", contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceType := ""
+	if dds.pathServiceId == "" {
+		serviceType = types.LinkedDomains
+	}
+	endpoints, sErr := didResolution.GetServiceEndpoints(dds.pathServiceId, serviceType)
+	if sErr != nil {
+		return nil, types.NewInternalError(did, contentType, sErr, true)
+	}
+	if len(endpoints) == 0 {
+		return nil, types.NewNotFoundError(did, contentType, nil, true)
+	}
+
+	results := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		result, jErr := utils.JoinURLPath(endpoint, path, query)
+		if jErr != nil {
+			return nil, types.NewInvalidDidUrlError(did, contentType, jErr, true)
+		}
+		results[i] = result
+	}
+
+	return types.NewServiceResult(results...), nil
+}
+
+// CheckControllerAuthorization checks whether the verification method is authorised to act for the DID
+// under the given relationship. The DID Document itself is checked first and then its controllers, recursively.
+func (dds DIDDocService) CheckControllerAuthorization(did string, verificationMethod string, relationship types.VerificationRelationship, contentType types.ContentType) (*types.DidDereferencing, *types.IdentityError) {
+	dereferenceMetadata := types.NewDereferencingMetadata(did, contentType, "")
+
+	path, visited, err := dds.findAuthorizingController(did, verificationMethod, relationship, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	var contentStream *types.ControllerAuthorization
+	if path != nil {
+		contentStream = types.NewControllerAuthorization(did, verificationMethod, relationship, true, path)
+	} else {
+		contentStream = types.NewControllerAuthorization(did, verificationMethod, relationship, false, visited)
+	}
+
+	var context string
+	if contentType == types.DIDJSONLD || contentType == types.JSONLD {
+		context = types.ResolutionSchemaJSONLD
+	}
+
+	return &types.DidDereferencing{Context: context, ContentStream: contentStream, DereferencingMetadata: dereferenceMetadata}, nil
+}
+
+// findAuthorizingController walks the controllers breadth-first and returns the shortest chain of DIDs
+// which ends with the DID Document listing the verification method, or nil if there is no such chain,
+// together with all DIDs checked. Breadth-first order reaches every DID at its lowest depth first,
+// so skipping already visited DIDs keeps cycles from being followed without hiding chains within the depth limit.
+// Controllers which cannot be resolved are skipped, only the DID itself has to be resolved.
+func (dds DIDDocService) findAuthorizingController(did string, verificationMethod string, relationship types.VerificationRelationship, contentType types.ContentType) ([]string, []string, *types.IdentityError) {
+	visited := []string{did}
+	queue := [][]string{{did}}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+
+		didResolution, err := dds.Resolve(path[len(path)-1], "", contentType)
+		if err != nil {
+			if len(path) == 1 {
+				return nil, visited, err
+			}
+			continue
+		}
+
+		// Deactivated DIDs cannot authorise anything
+		if didResolution.Metadata.Deactivated {
+			continue
+		}
+
+		if didResolution.Did.HasVerificationRelationship(relationship, verificationMethod) {
+			return path, visited, nil
+		}
+
+		if len(path) >= controllerChainDepthLimit {
+			continue
+		}
+		for _, controller := range didResolution.Did.Controller {
+			// Controllers from other DID methods cannot be resolved here
+			if !utils.IsValidDID(controller, types.DID_METHOD, dds.ledgerService.GetNamespaces()) || utils.Contains(visited, controller) {
+				continue
+			}
+			visited = append(visited, controller)
+			queue = append(queue, append(append([]string{}, path...), controller))
+		}
+	}
+
+	return nil, visited, nil
+}
+
 func (dds DIDDocService) DereferenceSecondary(did string, version string, fragmentId string, contentType types.ContentType) (*types.DidDereferencing, *types.IdentityError) {
 	didResolution, err := dds.Resolve(did, version, contentType)
 	if err != nil {
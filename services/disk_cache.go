@@ -0,0 +1,261 @@
+package services
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DiskCache is a persistent key-value store with a limit of the total size and LRU eviction.
+// Each value is a file named by SHA-256 of its key, prefixed by SHA-256 of the value itself,
+// so corrupted entries are detected and dropped on read. The order of entries survives restarts
+// through modification times of the files, which are updated on access.
+//
+// Only files named like entries are adopted and removed, so the directory may be shared with other files.
+// The mutex guards the index only, files are read and written outside of it.
+type DiskCache struct {
+	dir      string
+	maxBytes int64
+
+	mutex   sync.Mutex
+	size    int64
+	lru     *list.List // of *diskCacheEntry, the most recently used first
+	entries map[string]*list.Element
+}
+
+type diskCacheEntry struct {
+	name string
+	size int64
+}
+
+var (
+	// Names of entries: hex encoded SHA-256 of the key
+	diskCacheEntryName = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	// Names of temporary files of entries, created by os.CreateTemp with the name.*.tmp pattern
+	diskCacheTempName = regexp.MustCompile(`^[0-9a-f]{64}\.[0-9]+\.tmp$`)
+)
+
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if maxBytes <= 0 {
+		return nil, errors.New("cache size limit must be positive")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	cache := &DiskCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+	if err := cache.load(); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+// load restores the LRU order of the entries left by the previous run and applies the current size limit
+func (c *DiskCache) load() error {
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	type storedFile struct {
+		name     string
+		size     int64
+		modified time.Time
+	}
+	var stored []storedFile
+	for _, file := range files {
+		if !file.Type().IsRegular() {
+			continue
+		}
+		// Leftovers of writes interrupted by the previous run
+		if diskCacheTempName.MatchString(file.Name()) {
+			c.removeFiles([]string{file.Name()})
+			continue
+		}
+		if !diskCacheEntryName.MatchString(file.Name()) {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			return err
+		}
+		stored = append(stored, storedFile{name: file.Name(), size: info.Size(), modified: info.ModTime()})
+	}
+	slices.SortFunc(stored, func(a, b storedFile) int {
+		return b.modified.Compare(a.modified)
+	})
+
+	for _, file := range stored {
+		c.entries[file.name] = c.lru.PushBack(&diskCacheEntry{name: file.name, size: file.size})
+		c.size += file.size
+	}
+	c.removeFiles(c.evict())
+	log.Info().Msgf("Loaded %d cached entries (%d bytes) from %s", len(c.entries), c.size, c.dir)
+	return nil
+}
+
+// Get returns the value of the key, if it's cached and not corrupted
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	name := diskCacheFileName(key)
+	c.mutex.Lock()
+	element, ok := c.entries[name]
+	c.mutex.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	path := filepath.Join(c.dir, name)
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) < sha256.Size {
+		log.Warn().Err(err).Msgf("Dropping unreadable cache entry %s", key)
+		c.drop(element)
+		return nil, false
+	}
+	checksum, value := data[:sha256.Size], data[sha256.Size:]
+	if actual := sha256.Sum256(value); !bytes.Equal(checksum, actual[:]) {
+		log.Warn().Msgf("Dropping corrupted cache entry %s", key)
+		c.drop(element)
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	// The entry may be evicted or replaced while the file is read
+	if c.entries[name] == element {
+		c.lru.MoveToFront(element)
+	}
+	c.mutex.Unlock()
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return value, true
+}
+
+// Put stores the value of the key, evicting the least recently used entries above the size limit
+func (c *DiskCache) Put(key string, value []byte) error {
+	checksum := sha256.Sum256(value)
+	data := append(checksum[:], value...)
+	size := int64(len(data))
+	if size > c.maxBytes {
+		return nil
+	}
+
+	name := diskCacheFileName(key)
+	// Write to a temporary file first, so a crash or a concurrent read never sees a partially written entry
+	tmp, err := os.CreateTemp(c.dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(c.dir, name))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	c.mutex.Lock()
+	if element, ok := c.entries[name]; ok {
+		c.size -= element.Value.(*diskCacheEntry).size
+		c.lru.Remove(element)
+	}
+	c.entries[name] = c.lru.PushFront(&diskCacheEntry{name: name, size: size})
+	c.size += size
+	evicted := c.evict()
+	c.mutex.Unlock()
+
+	c.removeFiles(evicted)
+	return nil
+}
+
+// Size returns the total size of the cached entries in bytes
+func (c *DiskCache) Size() int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.size
+}
+
+// Len returns the number of the cached entries
+func (c *DiskCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.entries)
+}
+
+// Flush removes all entries and returns their number
+func (c *DiskCache) Flush() int {
+	c.mutex.Lock()
+	names := make([]string, 0, len(c.entries))
+	for c.lru.Len() > 0 {
+		names = append(names, c.unlink(c.lru.Back()))
+	}
+	c.mutex.Unlock()
+
+	c.removeFiles(names)
+	return len(names)
+}
+
+// evict unlinks the least recently used entries above the size limit and returns their names.
+// The caller holds the mutex and removes the files after releasing it.
+func (c *DiskCache) evict() []string {
+	var names []string
+	for c.size > c.maxBytes {
+		names = append(names, c.unlink(c.lru.Back()))
+	}
+	return names
+}
+
+// drop removes the entry read from the file, unless it was already replaced
+func (c *DiskCache) drop(element *list.Element) {
+	c.mutex.Lock()
+	entry := element.Value.(*diskCacheEntry)
+	if c.entries[entry.name] != element {
+		c.mutex.Unlock()
+		return
+	}
+	name := c.unlink(element)
+	c.mutex.Unlock()
+
+	c.removeFiles([]string{name})
+}
+
+// unlink removes the entry from the index and returns its name. The caller holds the mutex.
+func (c *DiskCache) unlink(element *list.Element) string {
+	entry := element.Value.(*diskCacheEntry)
+	c.lru.Remove(element)
+	delete(c.entries, entry.name)
+	c.size -= entry.size
+	return entry.name
+}
+
+// removeFiles removes files of unlinked entries. If the same key is put again meanwhile, its new file may be
+// removed too, which costs only a miss, as the entry is dropped on read.
+func (c *DiskCache) removeFiles(names []string) {
+	for _, name := range names {
+		if err := os.Remove(filepath.Join(c.dir, name)); err != nil && !os.IsNotExist(err) {
+			log.Warn().Err(err).Msgf("Failed to remove cache entry %s", name)
+		}
+	}
+}
+
+func diskCacheFileName(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -21,6 +22,10 @@ var (
 	ErrEndpointUnavailable      = fmt.Errorf("endpoint is currently unavailable")
 	ErrEndpointTimeout          = fmt.Errorf("endpoint health check timeout")
 	ErrEndpointConnectionFailed = fmt.Errorf("failed to establish connection to endpoint")
+	ErrEndpointNotFound         = fmt.Errorf("endpoint is not configured")
+	ErrEndpointExists           = fmt.Errorf("endpoint is already configured")
+	ErrEndpointsChangedByAdmin  = fmt.Errorf("endpoints were added or removed by the admin API, revert the changes or restart the resolver to apply the configuration")
+	ErrEndpointsChanged         = fmt.Errorf("endpoints were changed while the configuration was applied")
 )
 
 // EndpointHealth represents the health status of an endpoint
@@ -30,7 +35,9 @@ type EndpointHealth struct {
 	IsHealthy    bool
 	LastCheck    time.Time
 	FailureCount int
-	Mutex        sync.RWMutex
+	// Drained endpoints aren't used until they're marked healthy, whatever the health checks say
+	Drained bool
+	Mutex   sync.RWMutex
 }
 
 // EndpointManager manages endpoint health and fallback logic
@@ -43,6 +50,8 @@ type EndpointManager struct {
 	healthTimeout       time.Duration
 	stopChan            chan struct{}
 	wg                  sync.WaitGroup
+	// Incremented on every change of endpoints, so the ones prepared from a stale state aren't set
+	generation uint64
 }
 
 // NewEndpointManager creates a new endpoint manager
@@ -156,7 +165,7 @@ func (em *EndpointManager) isEndpointHealthy(endpointHealth *EndpointHealth) boo
 	endpointHealth.Mutex.RLock()
 	defer endpointHealth.Mutex.RUnlock()
 
-	isHealthy := endpointHealth.IsHealthy
+	isHealthy := endpointHealth.IsHealthy && !endpointHealth.Drained
 	lastCheck := endpointHealth.LastCheck
 
 	// Check if health data is stale
@@ -355,3 +364,253 @@ func (em *EndpointManager) hasAnyHealthyEndpoints() bool {
 	}
 	return false
 }
+
+// EndpointStatus is the health of an endpoint at the moment, as reported by the admin API
+type EndpointStatus struct {
+	Namespace string
+	Endpoint  types.Endpoint
+	// Whether requests are routed to the endpoint, which is false for drained endpoints and stale health data
+	Healthy      bool
+	Drained      bool
+	FailureCount int
+	LastCheck    time.Time
+}
+
+func endpointKey(namespace string, endpoint types.Endpoint) string {
+	return fmt.Sprintf("%s-%s-%s", namespace, endpoint.Role, endpoint.URL)
+}
+
+// ListEndpoints returns the health of all endpoints ordered by namespace, role and URL
+func (em *EndpointManager) ListEndpoints() []EndpointStatus {
+	em.mutex.RLock()
+	defer em.mutex.RUnlock()
+
+	statuses := make([]EndpointStatus, 0, len(em.endpoints))
+	for _, endpointHealth := range em.endpoints {
+		healthy := em.isEndpointHealthy(endpointHealth)
+		endpointHealth.Mutex.RLock()
+		statuses = append(statuses, EndpointStatus{
+			Namespace:    endpointHealth.Network.Namespace,
+			Endpoint:     endpointHealth.Endpoint,
+			Healthy:      healthy,
+			Drained:      endpointHealth.Drained,
+			FailureCount: endpointHealth.FailureCount,
+			LastCheck:    endpointHealth.LastCheck,
+		})
+		endpointHealth.Mutex.RUnlock()
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return endpointKey(statuses[i].Namespace, statuses[i].Endpoint) < endpointKey(statuses[j].Namespace, statuses[j].Endpoint)
+	})
+	return statuses
+}
+
+// findEndpoint returns the endpoint of the namespace by URL. URLs are unique within a namespace, whatever the role.
+func (em *EndpointManager) findEndpoint(namespace string, url string) (string, *EndpointHealth) {
+	for key, endpointHealth := range em.endpoints {
+		if endpointHealth.Network.Namespace == namespace && endpointHealth.Endpoint.URL == url {
+			return key, endpointHealth
+		}
+	}
+	return "", nil
+}
+
+// AddEndpoint starts using the endpoint for the namespace once it passes the health check, which runs immediately
+func (em *EndpointManager) AddEndpoint(namespace string, endpoint types.Endpoint) (EndpointStatus, error) {
+	em.mutex.Lock()
+	if _, existing := em.findEndpoint(namespace, endpoint.URL); existing != nil {
+		em.mutex.Unlock()
+		return EndpointStatus{}, ErrEndpointExists
+	}
+	endpointHealth := &EndpointHealth{
+		Network: types.Network{
+			Namespace: namespace,
+			Endpoints: []types.Endpoint{endpoint},
+			UseTls:    endpoint.UseTls,
+			Timeout:   endpoint.Timeout,
+		},
+		Endpoint:  endpoint,
+		LastCheck: time.Now(),
+	}
+	em.endpoints[endpointKey(namespace, endpoint)] = endpointHealth
+	em.generation++
+	em.mutex.Unlock()
+
+	log.Info().Msgf("Added %s endpoint %s for namespace %s", endpoint.Role, endpoint.URL, namespace)
+	em.checkEndpointHealth(endpointHealth)
+	return em.endpointStatus(endpointHealth), nil
+}
+
+// RemoveEndpoint stops using the endpoint and returns the number of endpoints left for the namespace
+func (em *EndpointManager) RemoveEndpoint(namespace string, url string) (int, error) {
+	em.mutex.Lock()
+	defer em.mutex.Unlock()
+
+	key, endpointHealth := em.findEndpoint(namespace, url)
+	if endpointHealth == nil {
+		return 0, ErrEndpointNotFound
+	}
+	delete(em.endpoints, key)
+	em.generation++
+	log.Info().Msgf("Removed endpoint %s of namespace %s", url, namespace)
+
+	remaining := 0
+	for _, endpointHealth := range em.endpoints {
+		if endpointHealth.Network.Namespace == namespace {
+			remaining++
+		}
+	}
+	return remaining, nil
+}
+
+// ForceEndpointHealth overrides the health of the endpoint. Unhealthy endpoints are drained:
+// they aren't used until they're marked healthy again. Healthy mark lasts until the next failed health check.
+func (em *EndpointManager) ForceEndpointHealth(namespace string, url string, healthy bool) (EndpointStatus, error) {
+	em.mutex.Lock()
+	_, endpointHealth := em.findEndpoint(namespace, url)
+	if endpointHealth != nil {
+		em.generation++
+	}
+	em.mutex.Unlock()
+	if endpointHealth == nil {
+		return EndpointStatus{}, ErrEndpointNotFound
+	}
+
+	endpointHealth.Mutex.Lock()
+	endpointHealth.Drained = !healthy
+	if healthy {
+		endpointHealth.IsHealthy = true
+		endpointHealth.FailureCount = 0
+		endpointHealth.LastCheck = time.Now()
+	}
+	endpointHealth.Mutex.Unlock()
+
+	if healthy {
+		log.Info().Msgf("Endpoint %s of namespace %s is forced healthy", url, namespace)
+	} else {
+		log.Warn().Msgf("Endpoint %s of namespace %s is drained", url, namespace)
+	}
+	return em.endpointStatus(endpointHealth), nil
+}
+
+// CheckHealthNow runs health checks of all endpoints without waiting for the background checker
+func (em *EndpointManager) CheckHealthNow() []EndpointStatus {
+	em.performHealthChecks("Requested health check completed")
+	return em.ListEndpoints()
+}
+
+func (em *EndpointManager) endpointStatus(endpointHealth *EndpointHealth) EndpointStatus {
+	healthy := em.isEndpointHealthy(endpointHealth)
+	endpointHealth.Mutex.RLock()
+	defer endpointHealth.Mutex.RUnlock()
+
+	return EndpointStatus{
+		Namespace:    endpointHealth.Network.Namespace,
+		Endpoint:     endpointHealth.Endpoint,
+		Healthy:      healthy,
+		Drained:      endpointHealth.Drained,
+		FailureCount: endpointHealth.FailureCount,
+		LastCheck:    endpointHealth.LastCheck,
+	}
+}
+
+// prepareEndpoints builds the endpoints of the config to replace the current ones. Health of the endpoints which
+// stay the same is kept, new and changed endpoints are checked now, so they're routed to only if healthy.
+// The returned generation is passed to setEndpoints. Endpoints added or removed by the admin API would be lost,
+// so the config is rejected until they're reverted.
+func (em *EndpointManager) prepareEndpoints(config types.Config) (map[string]*EndpointHealth, uint64, error) {
+	em.mutex.RLock()
+	current := em.endpoints
+	generation := em.generation
+	changedByAdmin := em.changedByAdmin()
+	em.mutex.RUnlock()
+	if changedByAdmin {
+		return nil, 0, ErrEndpointsChangedByAdmin
+	}
+
+	endpoints := make(map[string]*EndpointHealth)
+	for _, network := range config.Networks {
+		for _, endpoint := range network.Endpoints {
+			key := endpointKey(network.Namespace, endpoint)
+			endpointHealth := &EndpointHealth{
+				Network:  network,
+				Endpoint: endpoint,
+			}
+			if existing, ok := current[key]; ok && existing.Endpoint == endpoint {
+				existing.Mutex.RLock()
+				endpointHealth.IsHealthy = existing.IsHealthy
+				endpointHealth.LastCheck = existing.LastCheck
+				endpointHealth.FailureCount = existing.FailureCount
+				endpointHealth.Drained = existing.Drained
+				existing.Mutex.RUnlock()
+			} else {
+				em.checkEndpointHealth(endpointHealth)
+			}
+			endpoints[key] = endpointHealth
+		}
+	}
+
+	for _, endpointHealth := range endpoints {
+		if em.isEndpointHealthy(endpointHealth) {
+			return endpoints, generation, nil
+		}
+	}
+	return nil, 0, ErrNoHealthyEndpoints
+}
+
+// setEndpoints replaces the endpoints prepared by prepareEndpoints. Requests in flight keep the endpoint they've got.
+// The endpoints aren't set if the current ones were changed since they were prepared, e.g. drained by the admin API.
+func (em *EndpointManager) setEndpoints(config types.Config, endpoints map[string]*EndpointHealth, generation uint64) error {
+	em.mutex.Lock()
+	defer em.mutex.Unlock()
+
+	if em.generation != generation {
+		return ErrEndpointsChanged
+	}
+	em.config = config
+	em.endpoints = endpoints
+	em.generation++
+	return nil
+}
+
+// changedByAdmin returns true if the endpoints differ from the ones of the config, the caller holds the mutex
+func (em *EndpointManager) changedByAdmin() bool {
+	configured := 0
+	for _, network := range em.config.Networks {
+		for _, endpoint := range network.Endpoints {
+			if _, ok := em.endpoints[endpointKey(network.Namespace, endpoint)]; !ok {
+				return true
+			}
+			configured++
+		}
+	}
+	return configured != len(em.endpoints)
+}
+
+// EndpointCheck is the result of a one-off health check of an endpoint
+type EndpointCheck struct {
+	Namespace string
+	Endpoint  types.Endpoint
+	Healthy   bool
+	Duration  time.Duration
+}
+
+// CheckEndpoints runs health checks of all configured endpoints once, without starting the background checker
+func CheckEndpoints(config types.Config) []EndpointCheck {
+	em := &EndpointManager{config: config, healthTimeout: 15 * time.Second}
+
+	var checks []EndpointCheck
+	for _, network := range config.Networks {
+		for _, endpoint := range network.Endpoints {
+			start := time.Now()
+			healthy := em.performSingleHealthCheck(&endpoint)
+			checks = append(checks, EndpointCheck{
+				Namespace: network.Namespace,
+				Endpoint:  endpoint,
+				Healthy:   healthy,
+				Duration:  time.Since(start),
+			})
+		}
+	}
+	return checks
+}
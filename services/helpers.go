@@ -1,6 +1,7 @@
 package services
 
 import (
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -54,6 +55,22 @@ func GetPriorityContentType(acceptHeader string, resource bool) (types.ContentTy
 	return highestPriorityType, profile
 }
 
+// GetHistoryContentType negotiates the content type of DID Document history entries.
+// History is always written as JSON Lines, so application/jsonl is served as plain JSON entries,
+// unless a supported content type is preferred by the client.
+func GetHistoryContentType(acceptHeader string) (types.ContentType, string) {
+	for _, at := range accept.Parse(acceptHeader) {
+		mediaType, _ := extractMediaTypeAndProfile(at)
+		if mediaType == types.JSONL {
+			return types.JSON, ""
+		}
+		if mediaType.IsSupported() {
+			break
+		}
+	}
+	return GetPriorityContentType(acceptHeader, false)
+}
+
 // Extracts media type and profile from an accept header entry
 func extractMediaTypeAndProfile(at accept.Accept) (types.ContentType, string) {
 	mediaType := types.ContentType(at.Type + "/" + at.Subtype)
@@ -75,3 +92,14 @@ func PrepareQueries(c echo.Context) (rawQuery string, flag *string) {
 func GetDidParam(c echo.Context) (string, error) {
 	return url.QueryUnescape(c.Param("did"))
 }
+
+// RedirectToService redirects to the first endpoint of the service and lists the other endpoints
+// of the service in Link headers, so the client can fall back to them
+func RedirectToService(c echo.Context, result types.ResolutionResultI) error {
+	if service, ok := result.(*types.ServiceResult); ok {
+		for _, endpoint := range service.GetAlternateEndpoints() {
+			c.Response().Header().Add("Link", "<"+endpoint+">; rel=\"alternate\"")
+		}
+	}
+	return c.Redirect(http.StatusSeeOther, string(result.GetBytes()))
+}
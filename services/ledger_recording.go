@@ -0,0 +1,278 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// Version of the recording format, bumped on incompatible changes
+	LedgerRecordingFormatVersion = 1
+
+	QueryDIDDocRecord                    = "QueryDIDDoc"
+	QueryAllDidDocVersionsMetadataRecord = "QueryAllDidDocVersionsMetadata"
+	QueryResourceRecord                  = "QueryResource"
+	QueryCollectionResourcesRecord       = "QueryCollectionResources"
+	QueryCollectionResourcesPageRecord   = "QueryCollectionResourcesPage"
+)
+
+// LedgerRecording is the ledger traffic of the resolver: every query with its response or error, in the order of responses.
+//
+// On disk it's a JSON Lines file. The first line is the header with the format version and namespaces,
+// every next line is a LedgerRecord. Ledger objects are stored in protobuf JSON mapping.
+type LedgerRecording struct {
+	CreatedAt  time.Time
+	Namespaces []string
+	Records    []LedgerRecord
+}
+
+type ledgerRecordingHeader struct {
+	Version    int       `json:"version"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Namespaces []string  `json:"namespaces"`
+}
+
+type LedgerRecord struct {
+	Query      string `json:"query"`
+	Did        string `json:"did"`
+	Version    string `json:"version,omitempty"`
+	ResourceId string `json:"resourceId,omitempty"`
+	// Page request of QueryCollectionResourcesPage
+	PageKey        []byte          `json:"pageKey,omitempty"`
+	PageLimit      uint64          `json:"pageLimit,omitempty"`
+	PageCountTotal bool            `json:"pageCountTotal,omitempty"`
+	Response       json.RawMessage `json:"response,omitempty"`
+	Error          *RecordedError  `json:"error,omitempty"`
+}
+
+// RecordedError keeps the identity error with the gRPC status of the ledger, if any
+type RecordedError struct {
+	Code            int               `json:"code"`
+	Message         string            `json:"message"`
+	Did             string            `json:"did"`
+	ContentType     types.ContentType `json:"contentType,omitempty"`
+	IsDereferencing bool              `json:"isDereferencing,omitempty"`
+	Internal        string            `json:"internal,omitempty"`
+	GrpcCode        string            `json:"grpcCode,omitempty"`
+	GrpcMessage     string            `json:"grpcMessage,omitempty"`
+}
+
+func newRecordedError(err *types.IdentityError) *RecordedError {
+	recorded := &RecordedError{
+		Code:            err.Code,
+		Message:         err.Message,
+		Did:             err.Did,
+		ContentType:     err.ContentType,
+		IsDereferencing: err.IsDereferencing,
+	}
+	if err.Internal == nil {
+		return recorded
+	}
+	recorded.Internal = err.Internal.Error()
+	if grpcStatus, ok := status.FromError(err.Internal); ok {
+		recorded.GrpcCode = grpcStatus.Code().String()
+		recorded.GrpcMessage = grpcStatus.Message()
+	}
+	return recorded
+}
+
+// IdentityError restores the error, with the gRPC status as the internal error if it was recorded
+func (e RecordedError) IdentityError() *types.IdentityError {
+	var internal error
+	switch {
+	case e.GrpcCode != "":
+		internal = status.Error(parseGrpcCode(e.GrpcCode), e.GrpcMessage)
+	case e.Internal != "":
+		internal = errors.New(e.Internal)
+	}
+	return types.NewIdentityError(e.Code, e.Message, e.IsDereferencing, e.Did, e.ContentType, internal)
+}
+
+func parseGrpcCode(name string) codes.Code {
+	for code := codes.OK; code <= codes.Unauthenticated; code++ {
+		if code.String() == name {
+			return code
+		}
+	}
+	return codes.Unknown
+}
+
+// ReadLedgerRecording reads the recording file written by RecordingLedgerService
+func ReadLedgerRecording(path string) (*LedgerRecording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Lines hold whole resources, which are up to the ledger limit of resource size
+	scanner.Buffer(nil, 64<<20)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("recording is empty")
+	}
+	var header ledgerRecordingHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, fmt.Errorf("invalid recording header: %w", err)
+	}
+	if header.Version != LedgerRecordingFormatVersion {
+		return nil, fmt.Errorf("unsupported recording version %d, expected %d", header.Version, LedgerRecordingFormatVersion)
+	}
+
+	recording := &LedgerRecording{CreatedAt: header.CreatedAt, Namespaces: header.Namespaces}
+	for line := 2; scanner.Scan(); line++ {
+		var record LedgerRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		recording.Records = append(recording.Records, record)
+	}
+	return recording, scanner.Err()
+}
+
+// RecordingLedgerService passes queries to the next ledger service and appends them with responses to the recording file
+type RecordingLedgerService struct {
+	next LedgerServiceI
+	mu   *sync.Mutex
+	file *os.File
+}
+
+// NewRecordingLedgerService truncates the recording file and writes its header
+func NewRecordingLedgerService(next LedgerServiceI, path string) (RecordingLedgerService, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return RecordingLedgerService{}, err
+	}
+	header, err := json.Marshal(ledgerRecordingHeader{
+		Version:    LedgerRecordingFormatVersion,
+		CreatedAt:  time.Now().UTC(),
+		Namespaces: next.GetNamespaces(),
+	})
+	if err == nil {
+		_, err = file.Write(append(header, '\n'))
+	}
+	if err != nil {
+		file.Close()
+		return RecordingLedgerService{}, err
+	}
+	return RecordingLedgerService{next: next, mu: &sync.Mutex{}, file: file}, nil
+}
+
+func (rls RecordingLedgerService) Close() error {
+	return rls.file.Close()
+}
+
+func (rls RecordingLedgerService) QueryDIDDoc(did string, version string) (*didTypes.DidDocWithMetadata, *types.IdentityError) {
+	didDoc, err := rls.next.QueryDIDDoc(did, version)
+	record(rls, LedgerRecord{Query: QueryDIDDocRecord, Did: did, Version: version}, []*didTypes.DidDocWithMetadata{didDoc}, err)
+	return didDoc, err
+}
+
+func (rls RecordingLedgerService) QueryAllDidDocVersionsMetadata(did string) ([]*didTypes.Metadata, *types.IdentityError) {
+	versions, err := rls.next.QueryAllDidDocVersionsMetadata(did)
+	record(rls, LedgerRecord{Query: QueryAllDidDocVersionsMetadataRecord, Did: did}, versions, err)
+	return versions, err
+}
+
+func (rls RecordingLedgerService) QueryResource(did string, resourceId string) (*resourceTypes.ResourceWithMetadata, *types.IdentityError) {
+	resource, err := rls.next.QueryResource(did, resourceId)
+	record(rls, LedgerRecord{Query: QueryResourceRecord, Did: did, ResourceId: resourceId}, []*resourceTypes.ResourceWithMetadata{resource}, err)
+	return resource, err
+}
+
+func (rls RecordingLedgerService) QueryCollectionResources(did string) ([]*resourceTypes.Metadata, *types.IdentityError) {
+	resources, err := rls.next.QueryCollectionResources(did)
+	record(rls, LedgerRecord{Query: QueryCollectionResourcesRecord, Did: did}, resources, err)
+	return resources, err
+}
+
+func (rls RecordingLedgerService) QueryCollectionResourcesPage(did string, page *query.PageRequest) ([]*resourceTypes.Metadata, *query.PageResponse, *types.IdentityError) {
+	resources, pagination, err := rls.next.QueryCollectionResourcesPage(did, page)
+	entry := LedgerRecord{Query: QueryCollectionResourcesPageRecord, Did: did, PageKey: page.Key, PageLimit: page.Limit, PageCountTotal: page.CountTotal}
+	record(rls, entry, []*resourceTypes.QueryCollectionResourcesResponse{{Resources: resources, Pagination: pagination}}, err)
+	return resources, pagination, err
+}
+
+func (rls RecordingLedgerService) GetNamespaces() []string {
+	return rls.next.GetNamespaces()
+}
+
+// record writes the record with either the error or the response. Responses of single objects
+// are passed as a slice of one element and stored without the array.
+func record[T proto.Message](rls RecordingLedgerService, entry LedgerRecord, response []T, queryErr *types.IdentityError) {
+	var line []byte
+	var err error
+	if queryErr != nil {
+		entry.Error = newRecordedError(queryErr)
+	} else {
+		entry.Response, err = marshalRecordedMessages(entry.Query, response)
+	}
+	if err == nil {
+		line, err = json.Marshal(entry)
+	}
+	if err == nil {
+		rls.mu.Lock()
+		_, err = rls.file.Write(append(line, '\n'))
+		rls.mu.Unlock()
+	}
+	if err != nil {
+		log.Warn().Err(err).Msgf("Failed to record %s of %s", entry.Query, entry.Did)
+	}
+}
+
+func marshalRecordedMessages[T proto.Message](query string, messages []T) (json.RawMessage, error) {
+	raw := make([]json.RawMessage, len(messages))
+	for i, message := range messages {
+		data, err := protojson.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+		raw[i] = data
+	}
+	if isSingleObjectQuery(query) {
+		return raw[0], nil
+	}
+	return json.Marshal(raw)
+}
+
+func unmarshalRecordedMessages[T any, P interface {
+	*T
+	proto.Message
+}](query string, data json.RawMessage) ([]P, error) {
+	raw := []json.RawMessage{data}
+	if !isSingleObjectQuery(query) {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+	}
+	messages := make([]P, len(raw))
+	for i, item := range raw {
+		messages[i] = P(new(T))
+		if err := protojson.Unmarshal(item, messages[i]); err != nil {
+			return nil, err
+		}
+	}
+	return messages, nil
+}
+
+func isSingleObjectQuery(query string) bool {
+	return query == QueryDIDDocRecord || query == QueryResourceRecord || query == QueryCollectionResourcesPageRecord
+}
@@ -5,11 +5,14 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc/credentials"
 
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
 	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
 	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
 	"github.com/cheqd/did-resolver/types"
@@ -21,6 +24,8 @@ import (
 
 const (
 	DELIMITER = ":"
+	// Number of resources requested from the ledger at once
+	collectionResourcesPageLimit = 100
 )
 
 type LedgerServiceI interface {
@@ -28,22 +33,35 @@ type LedgerServiceI interface {
 	QueryAllDidDocVersionsMetadata(did string) ([]*didTypes.Metadata, *types.IdentityError)
 	QueryResource(collectionDid string, resourceId string) (*resourceTypes.ResourceWithMetadata, *types.IdentityError)
 	QueryCollectionResources(did string) ([]*resourceTypes.Metadata, *types.IdentityError)
+	// QueryCollectionResourcesPage reads one page of the collection. The key of the page is NextKey of the previous one.
+	QueryCollectionResourcesPage(did string, page *query.PageRequest) ([]*resourceTypes.Metadata, *query.PageResponse, *types.IdentityError)
 	GetNamespaces() []string
 }
 
 type LedgerService struct {
-	ledgers         map[string]types.Network // namespace -> endpoint with configs
+	ledgers map[string]types.Network // namespace -> endpoint with configs
+	// Ledgers are registered at runtime by the admin API, while queries read them
+	ledgersMutex    *sync.RWMutex
 	endpointManager *EndpointManager
 }
 
 func NewLedgerService(endpointManager *EndpointManager) LedgerService {
 	ls := LedgerService{}
 	ls.ledgers = make(map[string]types.Network)
+	ls.ledgersMutex = &sync.RWMutex{}
 	ls.endpointManager = endpointManager
 
 	return ls
 }
 
+func (ls LedgerService) isRegistered(method string, namespace string) bool {
+	ls.ledgersMutex.RLock()
+	defer ls.ledgersMutex.RUnlock()
+
+	_, namespaceFound := ls.ledgers[method+DELIMITER+namespace]
+	return namespaceFound
+}
+
 // GetHealthyConnection handles endpoint selection, connection, and automatic fallback
 func (ls LedgerService) GetHealthyConnection(namespace string, did string) (*grpc.ClientConn, *types.IdentityError) {
 	// Get healthy network from endpoint manager
@@ -92,8 +110,7 @@ func (ls LedgerService) GetHealthyConnection(namespace string, did string) (*grp
 
 func (ls LedgerService) QueryDIDDoc(did string, version string) (*didTypes.DidDocWithMetadata, *types.IdentityError) {
 	method, namespace, _, _ := utils.TrySplitDID(did)
-	_, namespaceFound := ls.ledgers[method+DELIMITER+namespace]
-	if !namespaceFound {
+	if !ls.isRegistered(method, namespace) {
 		return nil, types.NewInvalidDidError(did, types.JSON, nil, false)
 	}
 
@@ -126,8 +143,7 @@ func (ls LedgerService) QueryDIDDoc(did string, version string) (*didTypes.DidDo
 
 func (ls LedgerService) QueryAllDidDocVersionsMetadata(did string) ([]*didTypes.Metadata, *types.IdentityError) {
 	method, namespace, _, _ := utils.TrySplitDID(did)
-	_, namespaceFound := ls.ledgers[method+DELIMITER+namespace]
-	if !namespaceFound {
+	if !ls.isRegistered(method, namespace) {
 		return nil, types.NewInvalidDidError(did, types.JSON, nil, false)
 	}
 
@@ -152,8 +168,7 @@ func (ls LedgerService) QueryAllDidDocVersionsMetadata(did string) ([]*didTypes.
 
 func (ls LedgerService) QueryResource(did string, resourceId string) (*resourceTypes.ResourceWithMetadata, *types.IdentityError) {
 	method, namespace, collectionId, _ := utils.TrySplitDID(did)
-	_, namespaceFound := ls.ledgers[method+DELIMITER+namespace]
-	if !namespaceFound {
+	if !ls.isRegistered(method, namespace) {
 		return nil, types.NewInvalidDidError(did, types.JSON, nil, true)
 	}
 
@@ -177,15 +192,10 @@ func (ls LedgerService) QueryResource(did string, resourceId string) (*resourceT
 	return resourceResponse.Resource, nil
 }
 
+// QueryCollectionResources reads all resources of the collection page by page,
+// so collections with thousands of resources don't hit the gRPC message size limit
 func (ls LedgerService) QueryCollectionResources(did string) ([]*resourceTypes.Metadata, *types.IdentityError) {
-	method, namespace, collectionId, _ := utils.TrySplitDID(did)
-	_, namespaceFound := ls.ledgers[method+DELIMITER+namespace]
-	if !namespaceFound {
-		return nil, types.NewInvalidDidError(did, types.JSON, nil, false)
-	}
-
-	// Get healthy connection with automatic fallback
-	conn, err := ls.GetHealthyConnection(namespace, did)
+	conn, err := ls.collectionConnection(did)
 	if err != nil {
 		return nil, err
 	}
@@ -195,12 +205,98 @@ func (ls LedgerService) QueryCollectionResources(did string) ([]*resourceTypes.M
 	log.Info().Msgf("Querying DID resources: %s", did)
 
 	client := resourceTypes.NewQueryClient(conn)
-	resourceResponse, grpcErr := client.CollectionResources(context.Background(), &resourceTypes.QueryCollectionResourcesRequest{CollectionId: collectionId})
+	var resources []*resourceTypes.Metadata
+	var nextKey []byte
+	for {
+		page, pagination, err := queryCollectionResourcesPage(client, did, &query.PageRequest{Key: nextKey, Limit: collectionResourcesPageLimit})
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, page...)
+		if pagination == nil || len(pagination.NextKey) == 0 {
+			return resources, nil
+		}
+		nextKey = pagination.NextKey
+	}
+}
+
+func (ls LedgerService) QueryCollectionResourcesPage(did string, page *query.PageRequest) ([]*resourceTypes.Metadata, *query.PageResponse, *types.IdentityError) {
+	conn, err := ls.collectionConnection(did)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer mustCloseGRPCConnection(conn)
+
+	log.Info().Msgf("Querying page of DID resources: %s", did)
+
+	return queryCollectionResourcesPage(resourceTypes.NewQueryClient(conn), did, page)
+}
+
+// collectionConnection connects to a healthy node of the namespace of the collection DID
+func (ls LedgerService) collectionConnection(did string) (*grpc.ClientConn, *types.IdentityError) {
+	method, namespace, _, _ := utils.TrySplitDID(did)
+	if !ls.isRegistered(method, namespace) {
+		return nil, types.NewInvalidDidError(did, types.JSON, nil, false)
+	}
+
+	// Get healthy connection with automatic fallback
+	return ls.GetHealthyConnection(namespace, did)
+}
+
+func queryCollectionResourcesPage(client resourceTypes.QueryClient, did string, page *query.PageRequest) ([]*resourceTypes.Metadata, *query.PageResponse, *types.IdentityError) {
+	_, _, collectionId, _ := utils.TrySplitDID(did)
+	resourceResponse, grpcErr := client.CollectionResources(context.Background(), &resourceTypes.QueryCollectionResourcesRequest{
+		CollectionId: collectionId,
+		Pagination:   page,
+	})
 	if grpcErr != nil {
-		return nil, types.NewNotFoundError(did, types.JSON, grpcErr, false)
+		return nil, nil, types.NewNotFoundError(did, types.JSON, grpcErr, false)
 	}
+	return resourceResponse.Resources, resourceResponse.Pagination, nil
+}
+
+// queryCollection reads all resources of the collection, or the page of them if the page is set
+func queryCollection(ledgerService LedgerServiceI, did string, page *query.PageRequest) ([]*resourceTypes.Metadata, *types.ResourcePagination, *types.IdentityError) {
+	if page == nil {
+		resources, err := ledgerService.QueryCollectionResources(did)
+		return resources, nil, err
+	}
+
+	resources, pagination, err := ledgerService.QueryCollectionResourcesPage(did, page)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resources, types.NewResourcePagination(page, pagination), nil
+}
+
+// PageCollectionResources returns the page of resources which are already in memory, e.g. in a snapshot.
+// Like the ledger, it takes the key of the first resource of the page, which is its id here, and counts
+// resources only for the page requested without the key.
+func PageCollectionResources(resources []*resourceTypes.Metadata, page *query.PageRequest) ([]*resourceTypes.Metadata, *query.PageResponse, error) {
+	start := 0
+	if len(page.Key) != 0 {
+		start = slices.IndexFunc(resources, func(resource *resourceTypes.Metadata) bool { return resource.Id == string(page.Key) })
+		if start == -1 {
+			return nil, nil, fmt.Errorf("resource %s of the page key is not found", page.Key)
+		}
+	}
+
+	limit := page.Limit
+	if limit == 0 {
+		limit = collectionResourcesPageLimit
+	}
+	end := start + int(min(limit, uint64(len(resources)-start)))
 
-	return resourceResponse.Resources, nil
+	response := &query.PageResponse{}
+	if end < len(resources) {
+		response.NextKey = []byte(resources[end].Id)
+	}
+	if page.CountTotal && len(page.Key) == 0 {
+		response.Total = uint64(len(resources))
+	}
+	return resources[start:end], response, nil
 }
 
 func (ls *LedgerService) RegisterLedger(method string, endpoint types.Network) error {
@@ -214,11 +310,58 @@ func (ls *LedgerService) RegisterLedger(method string, endpoint types.Network) e
 		return errors.New("ledger node must have at least one endpoint configured")
 	}
 
+	ls.ledgersMutex.Lock()
 	ls.ledgers[method+DELIMITER+endpoint.Namespace] = endpoint
+	ls.ledgersMutex.Unlock()
 
 	return nil
 }
 
+// UnregisterLedger stops serving DIDs of the namespace, e.g. after all its endpoints are removed by the admin API
+func (ls *LedgerService) UnregisterLedger(method string, namespace string) {
+	ls.ledgersMutex.Lock()
+	defer ls.ledgersMutex.Unlock()
+
+	delete(ls.ledgers, method+DELIMITER+namespace)
+}
+
+// Reconfigure replaces the endpoints and registered networks of the method with the ones of the config.
+// The change is rejected, and the current networks are kept, if none of the new endpoints is healthy,
+// or if endpoints were added or removed by the admin API.
+func (ls *LedgerService) Reconfigure(method string, config types.Config) error {
+	for _, network := range config.Networks {
+		if network.Namespace == "" || len(network.Endpoints) == 0 {
+			return fmt.Errorf("network %q must have a namespace and at least one endpoint", network.Namespace)
+		}
+	}
+	endpoints, generation, err := ls.endpointManager.prepareEndpoints(config)
+	if err != nil {
+		return err
+	}
+
+	// Queries check the registration before they ask for the endpoint, so both are replaced at once
+	ls.ledgersMutex.Lock()
+	defer ls.ledgersMutex.Unlock()
+
+	if err := ls.endpointManager.setEndpoints(config, endpoints, generation); err != nil {
+		return err
+	}
+	for key := range ls.ledgers {
+		if strings.HasPrefix(key, method+DELIMITER) {
+			delete(ls.ledgers, key)
+		}
+	}
+	for _, network := range config.Networks {
+		ls.ledgers[method+DELIMITER+network.Namespace] = network
+	}
+	return nil
+}
+
+// EndpointManager returns the endpoint manager which routes queries of the registered networks
+func (ls LedgerService) EndpointManager() *EndpointManager {
+	return ls.endpointManager
+}
+
 func (ls LedgerService) openGRPCConnection(endpoint types.Network) (conn *grpc.ClientConn, err error) {
 	// Use the first endpoint in the slice (guaranteed to be primary)
 	if len(endpoint.Endpoints) == 0 {
@@ -241,6 +384,9 @@ func mustCloseGRPCConnection(conn *grpc.ClientConn) {
 }
 
 func (ls LedgerService) GetNamespaces() []string {
+	ls.ledgersMutex.RLock()
+	defer ls.ledgersMutex.RUnlock()
+
 	keys := make([]string, 0, len(ls.ledgers))
 	for k := range ls.ledgers {
 		namespace := strings.Split(k, DELIMITER)[1]
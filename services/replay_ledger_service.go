@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/rs/zerolog/log"
+)
+
+// ReplayLedgerService serves the recorded ledger traffic without access to the ledger.
+//
+// If the same query was recorded several times, e.g. before and after an update of the DID Document,
+// its responses are served in the recorded order and the last one is repeated afterwards.
+// Queries which weren't recorded fail with internalError, so a replayed test can't silently take another path.
+type ReplayLedgerService struct {
+	recording *LedgerRecording
+	// Query key -> indexes of its records
+	records map[string][]int
+	mu      *sync.Mutex
+	// Query key -> number of served records
+	served map[string]int
+}
+
+func NewReplayLedgerService(recording *LedgerRecording) ReplayLedgerService {
+	rls := ReplayLedgerService{
+		recording: recording,
+		records:   make(map[string][]int),
+		mu:        &sync.Mutex{},
+		served:    make(map[string]int),
+	}
+	for i, record := range recording.Records {
+		key := replayKey(record.Query, record.Did, record.Version+record.ResourceId+replayPageArgument(record.PageKey, record.PageLimit, record.PageCountTotal))
+		rls.records[key] = append(rls.records[key], i)
+	}
+	return rls
+}
+
+func replayKey(query string, did string, argument string) string {
+	return query + DELIMITER + did + DELIMITER + argument
+}
+
+// replayPageArgument identifies the page of QueryCollectionResourcesPage, it's empty for other queries
+func replayPageArgument(key []byte, limit uint64, countTotal bool) string {
+	if len(key) == 0 && limit == 0 && !countTotal {
+		return ""
+	}
+	return fmt.Sprintf("%x/%d/%t", key, limit, countTotal)
+}
+
+// next returns the next record of the query
+func (rls ReplayLedgerService) next(query string, did string, argument string, isDereferencing bool) (*LedgerRecord, *types.IdentityError) {
+	key := replayKey(query, did, argument)
+	rls.mu.Lock()
+	defer rls.mu.Unlock()
+
+	indexes := rls.records[key]
+	if len(indexes) == 0 {
+		log.Warn().Msgf("%s of %s %s isn't recorded", query, did, argument)
+		return nil, types.NewInternalError(did, types.JSON, errors.New("query isn't recorded"), isDereferencing)
+	}
+	served := rls.served[key]
+	rls.served[key]++
+	record := &rls.recording.Records[indexes[min(served, len(indexes)-1)]]
+	if record.Error != nil {
+		return nil, record.Error.IdentityError()
+	}
+	return record, nil
+}
+
+func (rls ReplayLedgerService) QueryDIDDoc(did string, version string) (*didTypes.DidDocWithMetadata, *types.IdentityError) {
+	record, err := rls.next(QueryDIDDocRecord, did, version, false)
+	if err != nil {
+		return nil, err
+	}
+	didDocs, decodeErr := unmarshalRecordedMessages[didTypes.DidDocWithMetadata](record.Query, record.Response)
+	if decodeErr != nil {
+		return nil, types.NewInternalError(did, types.JSON, fmt.Errorf("invalid recording: %w", decodeErr), false)
+	}
+	return didDocs[0], nil
+}
+
+func (rls ReplayLedgerService) QueryAllDidDocVersionsMetadata(did string) ([]*didTypes.Metadata, *types.IdentityError) {
+	record, err := rls.next(QueryAllDidDocVersionsMetadataRecord, did, "", false)
+	if err != nil {
+		return nil, err
+	}
+	versions, decodeErr := unmarshalRecordedMessages[didTypes.Metadata](record.Query, record.Response)
+	if decodeErr != nil {
+		return nil, types.NewInternalError(did, types.JSON, fmt.Errorf("invalid recording: %w", decodeErr), false)
+	}
+	return versions, nil
+}
+
+func (rls ReplayLedgerService) QueryResource(did string, resourceId string) (*resourceTypes.ResourceWithMetadata, *types.IdentityError) {
+	record, err := rls.next(QueryResourceRecord, did, resourceId, true)
+	if err != nil {
+		return nil, err
+	}
+	resources, decodeErr := unmarshalRecordedMessages[resourceTypes.ResourceWithMetadata](record.Query, record.Response)
+	if decodeErr != nil {
+		return nil, types.NewInternalError(did, types.JSON, fmt.Errorf("invalid recording: %w", decodeErr), true)
+	}
+	return resources[0], nil
+}
+
+func (rls ReplayLedgerService) QueryCollectionResources(did string) ([]*resourceTypes.Metadata, *types.IdentityError) {
+	record, err := rls.next(QueryCollectionResourcesRecord, did, "", false)
+	if err != nil {
+		return nil, err
+	}
+	resources, decodeErr := unmarshalRecordedMessages[resourceTypes.Metadata](record.Query, record.Response)
+	if decodeErr != nil {
+		return nil, types.NewInternalError(did, types.JSON, fmt.Errorf("invalid recording: %w", decodeErr), false)
+	}
+	return resources, nil
+}
+
+func (rls ReplayLedgerService) QueryCollectionResourcesPage(did string, page *query.PageRequest) ([]*resourceTypes.Metadata, *query.PageResponse, *types.IdentityError) {
+	record, err := rls.next(QueryCollectionResourcesPageRecord, did, replayPageArgument(page.Key, page.Limit, page.CountTotal), false)
+	if err != nil {
+		return nil, nil, err
+	}
+	responses, decodeErr := unmarshalRecordedMessages[resourceTypes.QueryCollectionResourcesResponse](record.Query, record.Response)
+	if decodeErr != nil {
+		return nil, nil, types.NewInternalError(did, types.JSON, fmt.Errorf("invalid recording: %w", decodeErr), false)
+	}
+	return responses[0].Resources, responses[0].Pagination, nil
+}
+
+func (rls ReplayLedgerService) GetNamespaces() []string {
+	return rls.recording.Namespaces
+}
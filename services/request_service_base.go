@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"strings"
 
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
 	"github.com/cheqd/did-resolver/migrations"
 	"github.com/cheqd/did-resolver/types"
 	"github.com/cheqd/did-resolver/utils"
@@ -51,6 +52,11 @@ func (dd *BaseRequestService) BasicPrepare(c ResolverContext) error {
 		return types.NewRepresentationNotSupportedError(dd.GetDid(), types.JSON, nil, dd.IsDereferencing)
 	}
 
+	return dd.PrepareDidAndQueries(c)
+}
+
+// PrepareDidAndQueries gets DID and queries from the request
+func (dd *BaseRequestService) PrepareDidAndQueries(c ResolverContext) error {
 	// Get DID from request
 	did, err := GetDidParam(c)
 	if err != nil {
@@ -118,6 +124,10 @@ func (dd *BaseRequestService) Query(c ResolverContext) error {
 }
 
 func (dd BaseRequestService) SetupResponse(c ResolverContext) error {
+	if err := dd.ApplyResourceListOptions(); err != nil {
+		return err
+	}
+
 	responseHeader := dd.Result.GetContentType()
 	if dd.Profile != "" && responseHeader == string(types.JSONLD) {
 		responseHeader = dd.Result.GetContentType() + ";profile=\"" + dd.Profile + "\""
@@ -149,6 +159,71 @@ func (dd BaseRequestService) FormatMetadataContentType(c ResolverContext) types.
 	return dd.Result
 }
 
+// ValidateResourceListQueries checks that only queries of linkedResourceMetadata list are placed.
+// resourceMetadata can be only summary here.
+func (dd BaseRequestService) ValidateResourceListQueries() error {
+	if len(types.ResourceListQueries.DiffWithUrlValues(dd.Queries)) > 0 {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.GetContentType(), nil, dd.IsDereferencing)
+	}
+
+	if resourceMetadata := dd.GetQueryParam(types.ResourceMetadata); resourceMetadata != "" && resourceMetadata != types.ResourceMetadataSummary {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.GetContentType(), nil, dd.IsDereferencing)
+	}
+
+	if _, err := types.NewResourceListOptions(dd.Queries); err != nil {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.GetContentType(), err, dd.IsDereferencing)
+	}
+	return nil
+}
+
+// ResourcePageRequest returns the page of the collection to be requested from the ledger, or nil if the request doesn't paginate resources
+func (dd BaseRequestService) ResourcePageRequest() *query.PageRequest {
+	options, err := types.NewResourceListOptions(dd.Queries)
+	if err != nil || options == nil {
+		return nil
+	}
+	return options.PageRequest()
+}
+
+// ApplyResourceListOptions paginates, sorts and selects fields of linkedResourceMetadata in the result,
+// or replaces it with the summary
+func (dd BaseRequestService) ApplyResourceListOptions() error {
+	options, err := types.NewResourceListOptions(dd.Queries)
+	if err != nil {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.GetContentType(), err, dd.IsDereferencing)
+	}
+	if options == nil {
+		return nil
+	}
+
+	switch result := dd.Result.(type) {
+	case *types.DidResolution:
+		if result.Metadata != nil {
+			err = options.ApplyToMetadata(result.Metadata)
+		}
+	case *types.DidDereferencing:
+		switch contentStream := result.ContentStream.(type) {
+		case *types.ResolutionDidDocMetadata:
+			err = options.ApplyToMetadata(contentStream)
+		case *types.DereferencedDidVersionsList:
+			for i := range contentStream.Versions {
+				if err = options.ApplyToMetadata(&contentStream.Versions[i]); err != nil {
+					break
+				}
+			}
+		}
+	case *types.ResourceDereferencing:
+		if result.Metadata != nil {
+			err = options.ApplyToResourceMetadata(result.Metadata)
+		}
+	}
+
+	if err != nil {
+		return types.NewInvalidDidUrlError(dd.GetDid(), dd.GetContentType(), err, dd.IsDereferencing)
+	}
+	return nil
+}
+
 // Setters
 
 // SetResponse sets the response result
@@ -162,6 +237,15 @@ func (dd *BaseRequestService) SetResponse(response types.ResolutionResultI) erro
 // RespondWithResourceData responds with the resource data
 func (dd *BaseRequestService) RespondWithResourceData(c ResolverContext) error {
 	c.Response().Header().Set(echo.HeaderContentType, dd.Result.GetContentType())
+	if result, ok := dd.Result.(*types.ResourceDereferencing); ok && result.DereferencingMetadata.ChecksumVerified {
+		// Content-Digest covers the bytes as sent, which are compressed later by the gzip middleware,
+		// so gzipped responses only get Repr-Digest, which covers the data before content coding
+		digest := utils.ContentDigest(result.GetBytes())
+		c.Response().Header().Set(types.REPR_DIGEST_HEADER, digest)
+		if !utils.IsGzipAccepted(c) {
+			c.Response().Header().Set(types.CONTENT_DIGEST_HEADER, digest)
+		}
+	}
 
 	return c.Blob(http.StatusOK, dd.Result.GetContentType(), dd.Result.GetBytes())
 }
@@ -9,29 +9,117 @@ import (
 // ResourceDataEchoHandler godoc
 //
 //	@Summary		Fetch specific Resource
-//	@Description	Get specific Resource within a DID Resource Collection
+//	@Description	Get specific Resource within a DID Resource Collection. Resource data is negotiated with the Accept header:
+//	@Description	JSON Resources can be also fetched as application/json, application/ld+json, application/cbor or application/yaml.
+//	@Description	Resources of any media type are returned as is for application/did+json and application/did+ld+json.
+//	@Description	Expanded and compacted forms of JSON-LD aren't supported, application/ld+json with a profile returns 406.
+//	@Description	Media type of the Resource is sniffed if it's missing or wrong.
+//	@Description	With validateAgainst, JSON data is validated against the Resource with JSON Schema (2020-12 by default),
+//	@Description	or against the one declared by the data in $schema with validateAgainst=$schema. The result is returned in dereferencingMetadata.schemaValidation.
 //	@Tags			Resource Resolution
 //	@Accept			*/*
 //	@Produce		*/*
-//	@Param			did			path		string	true	"Full DID with unique identifier"
-//	@Param			resourceId	path		string	true	"Resource-specific unique-identifier"
-//	@Success		200			{object}	[]byte
-//	@Failure		400			{object}	types.IdentityError
-//	@Failure		404			{object}	types.IdentityError
-//	@Failure		406			{object}	types.IdentityError
-//	@Failure		500			{object}	types.IdentityError
-//	@Failure		501			{object}	types.IdentityError
+//	@Param			did				path		string	true	"Full DID with unique identifier"
+//	@Param			resourceId		path		string	true	"Resource-specific unique-identifier"
+//	@Param			validateAgainst	query		string	false	"DID URL of the Resource with JSON Schema to validate the data against, or $schema for the schema declared by the data"
+//	@Success		200				{object}	[]byte
+//	@Failure		400				{object}	types.IdentityError
+//	@Failure		404				{object}	types.IdentityError
+//	@Failure		406				{object}	types.IdentityError
+//	@Failure		500				{object}	types.IdentityError
+//	@Failure		501				{object}	types.IdentityError
 //	@Router			/{did}/resources/{resourceId} [get]
 func ResourceDataEchoHandler(c echo.Context) error {
 	// Get Accept header
 	contentType, profile := services.GetPriorityContentType(c.Request().Header.Get(echo.HeaderAccept), true)
-	if contentType == types.JSONLD && profile == types.W3IDDIDURL {
+	// Result of schema validation is returned in dereferencing metadata
+	if (contentType == types.JSONLD && profile == types.W3IDDIDURL) || c.QueryParam(types.ValidateAgainstQ) != "" {
 		return services.EchoWrapHandler(&ResourceDataWithMetadataDereferencingService{})(c)
 	}
 
 	return services.EchoWrapHandler(&ResourceDataDereferencingService{})(c)
 }
 
+// ResourceCollectionEchoHandler godoc
+//
+//	@Summary		Fetch Resources of a DID Resource Collection
+//	@Description	Get metadata of Resources within a DID Resource Collection which match the filters, from the newest to the oldest.
+//	@Description	Resources created at the same time keep their order on the ledger.
+//	@Tags			Resource Resolution
+//	@Accept			application/did+ld+json,application/ld+json,application/did+json
+//	@Produce		application/did+ld+json,application/ld+json,application/did+json
+//	@Param			did					path		string	true	"Full DID with unique identifier"
+//	@Param			resourceType		query		string	false	"Filter by Resource Type"
+//	@Param			resourceName		query		string	false	"Filter by Resource Name"
+//	@Param			mediaType			query		string	false	"Filter by Media Type"
+//	@Param			createdAfter		query		string	false	"Only Resources created after the time"
+//	@Param			createdBefore		query		string	false	"Only Resources created before the time"
+//	@Param			resourceVersion		query		string	false	"Filter by Resource Version, or latest or first version of every Resource Name and Type"
+//	@Param			resourceMetadata	query		string	false	"summary to count resources per type and name"
+//	@Param			resourceLimit		query		int		false	"Maximum number of resources in linkedResourceMetadata"
+//	@Param			resourceCursor		query		string	false	"nextCursor of the previous page of resources"
+//	@Param			resourceSort		query		string	false	"Sort resources by created, name or version. Prefix - sorts in descending order"
+//	@Param			resourceFields		query		string	false	"Comma separated fields of resources to return"
+//	@Success		200					{object}	types.ResourceDereferencing
+//	@Failure		400					{object}	types.IdentityError
+//	@Failure		404					{object}	types.IdentityError
+//	@Failure		406					{object}	types.IdentityError
+//	@Failure		500					{object}	types.IdentityError
+//	@Failure		501					{object}	types.IdentityError
+//	@Router			/{did}/resources [get]
+func ResourceCollectionEchoHandler(c echo.Context) error {
+	return services.EchoWrapHandler(&ResourceCollectionDereferencingService{})(c)
+}
+
+// ResourceByNameEchoHandler godoc
+//
+//	@Summary		Fetch Resource by its type and name
+//	@Description	Get the latest Resource with the given type and name, or its version, within a DID Resource Collection.
+//	@Description	Depending on RESOURCE_BY_NAME_REDIRECT configuration, the client is redirected to the canonical URL of the Resource
+//	@Description	with 303 See Other, or the Resource is served as by the query ?resourceType=&resourceName=&resourceVersion=.
+//	@Tags			Resource Resolution
+//	@Accept			*/*
+//	@Produce		*/*
+//	@Param			did		path		string	true	"Full DID with unique identifier"
+//	@Param			type	path		string	true	"Resource Type"
+//	@Param			name	path		string	true	"Resource Name"
+//	@Success		200		{object}	[]byte
+//	@Success		303		"Redirect to the canonical URL of the Resource"
+//	@Failure		400		{object}	types.IdentityError
+//	@Failure		404		{object}	types.IdentityError
+//	@Failure		406		{object}	types.IdentityError
+//	@Failure		500		{object}	types.IdentityError
+//	@Failure		501		{object}	types.IdentityError
+//	@Router			/{did}/resources/by-name/{type}/{name} [get]
+func ResourceByNameEchoHandler(c echo.Context) error {
+	return services.EchoWrapHandler(&ResourceByNameDereferencingService{})(c)
+}
+
+// ResourceByNameVersionEchoHandler godoc
+//
+//	@Summary		Fetch version of Resource by its type and name
+//	@Description	Get the Resource with the given type, name and version within a DID Resource Collection.
+//	@Description	Version is either the value of resourceVersion, or latest or first version in the order of creation.
+//	@Description	latest and first always select by the order of creation, even if a Resource has such a value of resourceVersion.
+//	@Tags			Resource Resolution
+//	@Accept			*/*
+//	@Produce		*/*
+//	@Param			did		path		string	true	"Full DID with unique identifier"
+//	@Param			type	path		string	true	"Resource Type"
+//	@Param			name	path		string	true	"Resource Name"
+//	@Param			version	path		string	true	"Resource Version"
+//	@Success		200		{object}	[]byte
+//	@Success		303		"Redirect to the canonical URL of the Resource"
+//	@Failure		400		{object}	types.IdentityError
+//	@Failure		404		{object}	types.IdentityError
+//	@Failure		406		{object}	types.IdentityError
+//	@Failure		500		{object}	types.IdentityError
+//	@Failure		501		{object}	types.IdentityError
+//	@Router			/{did}/resources/by-name/{type}/{name}/{version} [get]
+func ResourceByNameVersionEchoHandler(c echo.Context) error {
+	return ResourceByNameEchoHandler(c)
+}
+
 // ResourceMetadataEchoHandler godoc
 //
 //	@Summary		Fetch Resource-specific metadata
@@ -51,3 +139,95 @@ func ResourceDataEchoHandler(c echo.Context) error {
 func ResourceMetadataEchoHandler(c echo.Context) error {
 	return services.EchoWrapHandler(&ResourceMetadataDereferencingService{})(c)
 }
+
+// ResourceAnonCredsEchoHandler godoc
+//
+//	@Summary		Fetch AnonCreds object
+//	@Description	Get schema, credential definition, revocation registry definition or revocation status list stored as a Resource
+//	@Description	in the format defined by AnonCreds Methods Registry. issuerId is set to the DID, timestamp of status list is the creation time of the Resource.
+//	@Tags			Resource Resolution
+//	@Accept			application/did+ld+json,application/ld+json,application/did+json
+//	@Produce		application/did+ld+json,application/ld+json,application/did+json
+//	@Param			did			path		string	true	"Full DID with unique identifier"
+//	@Param			resourceId	path		string	true	"Resource-specific unique identifier"
+//	@Success		200			{object}	types.ResourceDereferencing{contentStream=types.AnonCredsSchema}
+//	@Failure		400			{object}	types.IdentityError
+//	@Failure		404			{object}	types.IdentityError
+//	@Failure		406			{object}	types.IdentityError
+//	@Failure		500			{object}	types.IdentityError
+//	@Failure		501			{object}	types.IdentityError
+//	@Router			/{did}/resources/{resourceId}/anoncreds [get]
+func ResourceAnonCredsEchoHandler(c echo.Context) error {
+	return services.EchoWrapHandler(&ResourceAnonCredsDereferencingService{})(c)
+}
+
+// ResourceVersionsEchoHandler godoc
+//
+//	@Summary		Fetch all versions of a Resource
+//	@Description	Get metadata of all versions of a Resource, i.e. Resources with the same name and type, from the oldest to the newest
+//	@Tags			Resource Resolution
+//	@Accept			application/did+ld+json,application/ld+json,application/did+json
+//	@Produce		application/did+ld+json,application/ld+json,application/did+json
+//	@Param			did					path		string	true	"Full DID with unique identifier"
+//	@Param			resourceId			path		string	true	"Resource-specific unique identifier of any version"
+//	@Param			resourceMetadata	query		string	false	"summary to count resources per type and name"
+//	@Param			resourceLimit		query		int		false	"Maximum number of resources in linkedResourceMetadata"
+//	@Param			resourceCursor		query		string	false	"nextCursor of the previous page of resources"
+//	@Param			resourceSort		query		string	false	"Sort resources by created, name or version. Prefix - sorts in descending order"
+//	@Param			resourceFields		query		string	false	"Comma separated fields of resources to return"
+//	@Success		200					{object}	types.ResourceDereferencing
+//	@Failure		400					{object}	types.IdentityError
+//	@Failure		404					{object}	types.IdentityError
+//	@Failure		406					{object}	types.IdentityError
+//	@Failure		500					{object}	types.IdentityError
+//	@Failure		501					{object}	types.IdentityError
+//	@Router			/{did}/resources/{resourceId}/versions [get]
+func ResourceVersionsEchoHandler(c echo.Context) error {
+	return services.EchoWrapHandler(&ResourceVersionsDereferencingService{})(c)
+}
+
+// ResourceAnonCredsStatusListEchoHandler godoc
+//
+//	@Summary		Fetch AnonCreds revocation status list at a point in time
+//	@Description	Get the revocation status list which was current at the given time, and the time when it was replaced by the next one.
+//	@Description	The revocation registry is identified either by id of its definition, or by the name of its status lists.
+//	@Tags			Resource Resolution
+//	@Accept			application/did+ld+json,application/ld+json,application/did+json
+//	@Produce		application/did+ld+json,application/ld+json,application/did+json
+//	@Param			did				path		string	true	"Full DID with unique identifier"
+//	@Param			revRegDefId		query		string	false	"Resource-specific unique identifier of revocation registry definition"
+//	@Param			resourceName	query		string	false	"Name of status lists"
+//	@Param			timestamp		query		string	false	"Unix timestamp or time string. Current time by default"
+//	@Success		200				{object}	types.AnonCredsStatusListDereferencing
+//	@Failure		400				{object}	types.IdentityError
+//	@Failure		404				{object}	types.IdentityError
+//	@Failure		406				{object}	types.IdentityError
+//	@Failure		500				{object}	types.IdentityError
+//	@Failure		501				{object}	types.IdentityError
+//	@Router			/{did}/anoncreds/statusList [get]
+func ResourceAnonCredsStatusListEchoHandler(c echo.Context) error {
+	return services.EchoWrapHandler(&ResourceAnonCredsStatusListDereferencingService{})(c)
+}
+
+// ResourceCredentialStatusEchoHandler godoc
+//
+//	@Summary		Check credential status in a status list
+//	@Description	Dereference Bitstring Status List or StatusList2021 credential stored as a Resource and read the status of the credential.
+//	@Description	Query parameters are the fields of credentialStatus entry of the credential.
+//	@Tags			Resource Resolution
+//	@Accept			application/did+ld+json,application/ld+json,application/did+json
+//	@Produce		application/did+ld+json,application/ld+json,application/did+json
+//	@Param			statusListCredential	query		string	true	"DID URL or resolver URL of the status list resource"
+//	@Param			statusListIndex			query		int		true	"Index of the credential in the status list"
+//	@Param			statusPurpose			query		string	false	"Purpose of the status. Required if the status list has several purposes"
+//	@Param			statusSize				query		int		false	"Number of bits per status. 1 by default"
+//	@Success		200						{object}	types.ResourceDereferencing{contentStream=types.CredentialStatus}
+//	@Failure		400						{object}	types.IdentityError
+//	@Failure		404						{object}	types.IdentityError
+//	@Failure		406						{object}	types.IdentityError
+//	@Failure		500						{object}	types.IdentityError
+//	@Failure		501						{object}	types.IdentityError
+//	@Router			/credentialStatus [get]
+func ResourceCredentialStatusEchoHandler(c echo.Context) error {
+	return services.EchoWrapHandler(&ResourceCredentialStatusService{})(c)
+}
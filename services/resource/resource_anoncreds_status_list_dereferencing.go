@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cheqd/did-resolver/migrations"
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+type ResourceAnonCredsStatusListDereferencingService struct {
+	services.BaseRequestService
+	RevRegDefId  string
+	ResourceName string
+	Timestamp    string
+}
+
+func (dr *ResourceAnonCredsStatusListDereferencingService) Setup(c services.ResolverContext) error {
+	dr.IsDereferencing = true
+	return nil
+}
+
+func (dr *ResourceAnonCredsStatusListDereferencingService) SpecificPrepare(c services.ResolverContext) error {
+	dr.RevRegDefId = dr.GetQueryParam(types.RevRegDefIdQ)
+	dr.ResourceName = dr.GetQueryParam(types.ResourceName)
+
+	// AnonCreds uses Unix timestamps, but time in any format supported for versionTime is accepted as well.
+	// If timestamp is not set, the latest status list is returned
+	timestamp := dr.GetQueryParam(types.TimestampQ)
+	switch unixTime, err := strconv.ParseInt(timestamp, 10, 64); {
+	case timestamp == "":
+		dr.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	case err == nil:
+		dr.Timestamp = time.Unix(unixTime, 0).UTC().Format(time.RFC3339)
+	default:
+		dr.Timestamp = timestamp
+	}
+	return nil
+}
+
+func (dr ResourceAnonCredsStatusListDereferencingService) Redirect(c services.ResolverContext) error {
+	migratedDid := migrations.MigrateDID(dr.GetDid())
+	queryRaw, _ := services.PrepareQueries(c)
+
+	path := types.RESOLVER_PATH + migratedDid + types.ANONCREDS_PATH + types.STATUS_LIST_PATH + utils.GetQuery(queryRaw)
+	return c.Redirect(http.StatusMovedPermanently, path)
+}
+
+func (dr *ResourceAnonCredsStatusListDereferencingService) SpecificValidation(c services.ResolverContext) error {
+	if len(types.AnonCredsStatusListQueries.DiffWithUrlValues(dr.Queries)) > 0 {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
+	}
+
+	// Registry should be identified either by revRegDefId or by the name of status lists
+	if (dr.RevRegDefId == "") == (dr.ResourceName == "") {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
+	}
+
+	if dr.RevRegDefId != "" && !utils.IsValidUUID(dr.RevRegDefId) {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
+	}
+
+	if _, err := utils.ParseFromStringTimeToGoTime(dr.Timestamp); err != nil {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, err, dr.IsDereferencing)
+	}
+	return nil
+}
+
+func (dr *ResourceAnonCredsStatusListDereferencingService) Query(c services.ResolverContext) error {
+	result, err := c.ResourceService.DereferenceAnonCredsStatusList(dr.GetDid(), dr.RevRegDefId, dr.ResourceName, dr.Timestamp, dr.GetContentType())
+	if err != nil {
+		err.IsDereferencing = dr.IsDereferencing
+		return err
+	}
+
+	return dr.SetResponse(result)
+}
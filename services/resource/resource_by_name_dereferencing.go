@@ -0,0 +1,103 @@
+package resources
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/cheqd/did-resolver/migrations"
+	"github.com/cheqd/did-resolver/services"
+	didDocServices "github.com/cheqd/did-resolver/services/diddoc"
+	"github.com/cheqd/did-resolver/types"
+)
+
+// ResourceByNameDereferencingService resolves the resource addressed by type, name and optionally version in the path
+// through the same resource query handlers as ?resourceType=&resourceName=&resourceVersion= queries.
+type ResourceByNameDereferencingService struct {
+	didDocServices.QueryDIDDocRequestService
+	ResourceType    string
+	ResourceName    string
+	ResourceVersion string
+	// Redirect to the canonical URL of the resource instead of serving it
+	RedirectToResource bool
+}
+
+func (dr *ResourceByNameDereferencingService) Setup(c services.ResolverContext) error {
+	dr.IsDereferencing = true
+	return nil
+}
+
+func (dr *ResourceByNameDereferencingService) SpecificPrepare(c services.ResolverContext) error {
+	var err error
+	if dr.ResourceType, err = url.PathUnescape(c.Param("type")); err != nil {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, err, dr.IsDereferencing)
+	}
+	if dr.ResourceName, err = url.PathUnescape(c.Param("name")); err != nil {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, err, dr.IsDereferencing)
+	}
+	if dr.ResourceVersion, err = url.PathUnescape(c.Param("version")); err != nil {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, err, dr.IsDereferencing)
+	}
+	dr.RedirectToResource = c.ResourceService.IsByNameRedirect()
+
+	// Path segments can't be mixed with queries
+	if len(dr.Queries) != 0 {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
+	}
+
+	dr.Queries = url.Values{}
+	dr.Queries.Set(types.ResourceType, dr.ResourceType)
+	dr.Queries.Set(types.ResourceName, dr.ResourceName)
+	if dr.ResourceVersion != "" {
+		dr.Queries.Set(types.ResourceVersion, dr.ResourceVersion)
+	}
+	// Only id of the resource is needed for redirect
+	if dr.RedirectToResource {
+		dr.Queries.Set(types.ResourceMetadata, "true")
+	}
+	return dr.QueryDIDDocRequestService.SpecificPrepare(c)
+}
+
+func (dr ResourceByNameDereferencingService) Redirect(c services.ResolverContext) error {
+	migratedDid := migrations.MigrateDID(dr.GetDid())
+
+	path := types.RESOLVER_PATH + migratedDid + types.RESOURCE_PATH + types.RESOURCE_BY_NAME_PATH +
+		url.PathEscape(dr.ResourceType) + "/" + url.PathEscape(dr.ResourceName)
+	if dr.ResourceVersion != "" {
+		path += "/" + url.PathEscape(dr.ResourceVersion)
+	}
+	return c.Redirect(http.StatusMovedPermanently, path)
+}
+
+func (dr ResourceByNameDereferencingService) Respond(c services.ResolverContext) error {
+	if !dr.RedirectToResource {
+		return dr.QueryDIDDocRequestService.Respond(c)
+	}
+
+	resourceId := dr.latestResourceId()
+	if resourceId == "" {
+		return types.NewNotFoundError(dr.GetDid(), dr.GetContentType(), nil, dr.IsDereferencing)
+	}
+	return c.Redirect(http.StatusSeeOther, types.RESOLVER_PATH+dr.GetDid()+types.RESOURCE_PATH+resourceId)
+}
+
+// latestResourceId returns id of the newest resource from the metadata found by the query handlers
+func (dr ResourceByNameDereferencingService) latestResourceId() string {
+	var resources types.DereferencedResourceList
+	switch result := dr.Result.(type) {
+	case *types.ResourceDereferencing:
+		if result.Metadata != nil && result.Metadata.Resources != nil {
+			resources = *result.Metadata.Resources
+		}
+	case *types.DidResolution:
+		if result.Metadata != nil {
+			resources = result.Metadata.Resources
+		}
+	}
+	if len(resources) == 0 {
+		return ""
+	}
+
+	sort.Stable(resources)
+	return resources[0].ResourceId
+}
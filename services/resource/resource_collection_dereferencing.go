@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"net/http"
+
+	"github.com/cheqd/did-resolver/migrations"
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+type ResourceCollectionDereferencingService struct {
+	services.BaseRequestService
+	Filter types.ResourceFilter
+}
+
+func (dr *ResourceCollectionDereferencingService) Setup(c services.ResolverContext) error {
+	dr.IsDereferencing = true
+	return nil
+}
+
+func (dr *ResourceCollectionDereferencingService) SpecificPrepare(c services.ResolverContext) error {
+	filter, err := types.NewResourceFilter(dr.Queries)
+	if err != nil {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, err, dr.IsDereferencing)
+	}
+	dr.Filter = *filter
+	return nil
+}
+
+func (dr ResourceCollectionDereferencingService) Redirect(c services.ResolverContext) error {
+	migratedDid := migrations.MigrateDID(dr.GetDid())
+	queryRaw, _ := services.PrepareQueries(c)
+
+	path := types.RESOLVER_PATH + migratedDid + types.RESOURCES_PATH + utils.GetQuery(queryRaw)
+	return c.Redirect(http.StatusMovedPermanently, path)
+}
+
+func (dr *ResourceCollectionDereferencingService) SpecificValidation(c services.ResolverContext) error {
+	if !dr.RequestedContentType.IsSupported() {
+		return types.NewRepresentationNotSupportedError(dr.GetDid(), types.JSON, nil, dr.IsDereferencing)
+	}
+
+	if len(types.ResourceCollectionQueries.DiffWithUrlValues(dr.Queries)) > 0 {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
+	}
+
+	if resourceMetadata := dr.GetQueryParam(types.ResourceMetadata); resourceMetadata != "" && resourceMetadata != types.ResourceMetadataSummary {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
+	}
+
+	if _, err := types.NewResourceListOptions(dr.Queries); err != nil {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, err, dr.IsDereferencing)
+	}
+	return nil
+}
+
+func (dr *ResourceCollectionDereferencingService) Query(c services.ResolverContext) error {
+	// Resources are sorted from the newest to the oldest before they're paginated, so the listing isn't paginated by the ledger
+	result, err := c.ResourceService.DereferenceCollectionResources(dr.GetDid(), dr.Filter, dr.GetContentType())
+	if err != nil {
+		err.IsDereferencing = dr.IsDereferencing
+		return err
+	}
+	return dr.SetResponse(result)
+}
@@ -0,0 +1,88 @@
+package resources
+
+import (
+	"strconv"
+
+	"github.com/cheqd/did-resolver/migrations"
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+// Status size of Bitstring Status List is limited to keep the status value in int
+const maxStatusSize = 32
+
+type ResourceCredentialStatusService struct {
+	services.BaseRequestService
+	ResourceId       string
+	ResourceName     string
+	ResourceType     string
+	CredentialStatus types.CredentialStatus
+}
+
+func (dr *ResourceCredentialStatusService) Setup(c services.ResolverContext) error {
+	dr.IsDereferencing = true
+	return nil
+}
+
+// SpecificPrepare takes the DID from statusListCredential, which may be either a DID URL
+// or a URL of this resolver, e.g. https://resolver.cheqd.net/1.0/identifiers/did:cheqd:mainnet:...
+func (dr *ResourceCredentialStatusService) SpecificPrepare(c services.ResolverContext) error {
+	statusListCredential := dr.GetQueryParam(types.StatusListCredentialQ)
+	dr.CredentialStatus.StatusListCredential = statusListCredential
+	dr.CredentialStatus.StatusPurpose = dr.GetQueryParam(types.StatusPurposeQ)
+
+	resourceDidUrl, err := types.ParseResourceDidUrl(statusListCredential)
+	if err != nil {
+		return types.NewInvalidDidUrlError(statusListCredential, dr.RequestedContentType, err, dr.IsDereferencing)
+	}
+	dr.Did = migrations.MigrateDID(resourceDidUrl.Did)
+	dr.ResourceId = resourceDidUrl.ResourceId
+	dr.ResourceName = resourceDidUrl.ResourceName
+	dr.ResourceType = resourceDidUrl.ResourceType
+
+	dr.CredentialStatus.StatusListIndex, err = strconv.Atoi(dr.GetQueryParam(types.StatusListIndexQ))
+	if err != nil {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, err, dr.IsDereferencing)
+	}
+
+	dr.CredentialStatus.StatusSize = 1
+	if statusSize := dr.GetQueryParam(types.StatusSizeQ); statusSize != "" {
+		if dr.CredentialStatus.StatusSize, err = strconv.Atoi(statusSize); err != nil {
+			return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, err, dr.IsDereferencing)
+		}
+	}
+	return nil
+}
+
+func (dr *ResourceCredentialStatusService) SpecificValidation(c services.ResolverContext) error {
+	if len(types.CredentialStatusQueries.DiffWithUrlValues(dr.Queries)) > 0 {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
+	}
+
+	if dr.ResourceId != "" && !utils.IsValidUUID(dr.ResourceId) {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
+	}
+
+	// The latest status list can be found only by name and type
+	if dr.ResourceId == "" && (dr.ResourceName == "" || dr.ResourceType == "") {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
+	}
+
+	if dr.CredentialStatus.StatusListIndex < 0 || dr.CredentialStatus.StatusSize < 1 || dr.CredentialStatus.StatusSize > maxStatusSize {
+		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
+	}
+	return nil
+}
+
+func (dr *ResourceCredentialStatusService) Query(c services.ResolverContext) error {
+	result, err := c.ResourceService.CheckCredentialStatus(
+		dr.GetDid(), dr.ResourceId, dr.ResourceName, dr.ResourceType, dr.CredentialStatus, dr.GetContentType(),
+	)
+	if err != nil {
+		err.IsDereferencing = dr.IsDereferencing
+		return err
+	}
+
+	return dr.SetResponse(result)
+}
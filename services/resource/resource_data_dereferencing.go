@@ -7,11 +7,14 @@ import (
 	"github.com/cheqd/did-resolver/services"
 	"github.com/cheqd/did-resolver/types"
 	"github.com/cheqd/did-resolver/utils"
+	"github.com/labstack/echo/v4"
 )
 
 type ResourceDataDereferencingService struct {
 	services.BaseRequestService
 	ResourceId string
+	// Accept header is negotiated against the media type of the resource
+	Accept string
 }
 
 func (dr *ResourceDataDereferencingService) Setup(c services.ResolverContext) error {
@@ -19,6 +22,17 @@ func (dr *ResourceDataDereferencingService) Setup(c services.ResolverContext) er
 	return nil
 }
 
+// BasicPrepare accepts any media type, as the representation of resource data is negotiated after the query.
+// Errors are represented in the requested resolution media type, or in JSON-LD if another one is requested.
+func (dr *ResourceDataDereferencingService) BasicPrepare(c services.ResolverContext) error {
+	dr.Accept = c.Request().Header.Get(echo.HeaderAccept)
+	dr.RequestedContentType, dr.Profile = services.GetPriorityContentType(dr.Accept, true)
+	if !dr.RequestedContentType.IsSupported() {
+		dr.RequestedContentType, dr.Profile = types.JSONLD, ""
+	}
+	return dr.PrepareDidAndQueries(c)
+}
+
 func (dr *ResourceDataDereferencingService) SpecificPrepare(c services.ResolverContext) error {
 	dr.ResourceId = c.Param("resource")
 	return nil
@@ -49,9 +63,17 @@ func (dr *ResourceDataDereferencingService) Query(c services.ResolverContext) er
 		err.IsDereferencing = dr.IsDereferencing
 		return err
 	}
+
+	contentType, data, nErr := types.NegotiateResourceData(dr.Accept, result.DereferencingMetadata.ContentType, result.GetBytes())
+	if nErr != nil {
+		return types.NewRepresentationNotSupportedError(dr.GetDid(), dr.GetContentType(), nErr, dr.IsDereferencing)
+	}
+	result.ContentStream = types.NewDereferencedResourceData(data)
+	result.DereferencingMetadata.ContentType = contentType
 	return dr.SetResponse(result)
 }
 
 func (dr ResourceDataDereferencingService) Respond(c services.ResolverContext) error {
+	c.Response().Header().Set(echo.HeaderVary, echo.HeaderAccept)
 	return dr.RespondWithResourceData(c)
 }
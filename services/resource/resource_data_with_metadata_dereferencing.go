@@ -36,8 +36,8 @@ func (dr *ResourceDataWithMetadataDereferencingService) SpecificValidation(c ser
 		return types.NewInvalidDidUrlError(dr.ResourceId, dr.RequestedContentType, nil, dr.IsDereferencing)
 	}
 
-	// We not allow query here
-	if len(dr.Queries) != 0 {
+	// Only JSON Schema to validate the data against is allowed in query
+	if len(types.ResourceDataWithMetadataQueries.DiffWithUrlValues(dr.Queries)) != 0 {
 		return types.NewInvalidDidUrlError(dr.GetDid(), dr.RequestedContentType, nil, dr.IsDereferencing)
 	}
 	return nil
@@ -50,5 +50,14 @@ func (dr *ResourceDataWithMetadataDereferencingService) Query(c services.Resolve
 		return err
 	}
 
+	if validateAgainst := dr.GetQueryParam(types.ValidateAgainstQ); validateAgainst != "" {
+		validation, err := c.ResourceService.ValidateResourceData(dr.GetDid(), result.ContentStream.GetBytes(), validateAgainst, dr.GetContentType())
+		if err != nil {
+			err.IsDereferencing = dr.IsDereferencing
+			return err
+		}
+		result.DereferencingMetadata.SchemaValidation = validation
+	}
+
 	return dr.SetResponse(result)
 }
@@ -0,0 +1,51 @@
+package resources
+
+import (
+	"net/http"
+
+	"github.com/cheqd/did-resolver/migrations"
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+type ResourceVersionsDereferencingService struct {
+	services.BaseRequestService
+	ResourceId string
+}
+
+func (dr *ResourceVersionsDereferencingService) Setup(c services.ResolverContext) error {
+	dr.IsDereferencing = true
+	return nil
+}
+
+func (dr *ResourceVersionsDereferencingService) SpecificPrepare(c services.ResolverContext) error {
+	dr.ResourceId = c.Param("resource")
+	return nil
+}
+
+func (dr ResourceVersionsDereferencingService) Redirect(c services.ResolverContext) error {
+	migratedDid := migrations.MigrateDID(dr.GetDid())
+	queryRaw, _ := services.PrepareQueries(c)
+
+	path := types.RESOLVER_PATH + migratedDid + types.RESOURCE_PATH + dr.ResourceId + types.RESOURCE_VERSIONS_PATH + utils.GetQuery(queryRaw)
+	return c.Redirect(http.StatusMovedPermanently, path)
+}
+
+func (dr *ResourceVersionsDereferencingService) SpecificValidation(c services.ResolverContext) error {
+	if !utils.IsValidUUID(dr.ResourceId) {
+		return types.NewInvalidDidUrlError(dr.ResourceId, dr.RequestedContentType, nil, dr.IsDereferencing)
+	}
+
+	// Only queries of linked resources list are allowed
+	return dr.ValidateResourceListQueries()
+}
+
+func (dr *ResourceVersionsDereferencingService) Query(c services.ResolverContext) error {
+	result, err := c.ResourceService.DereferenceResourceVersions(dr.GetDid(), dr.ResourceId, dr.GetContentType())
+	if err != nil {
+		err.IsDereferencing = dr.IsDereferencing
+		return err
+	}
+	return dr.SetResponse(result)
+}
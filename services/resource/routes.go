@@ -6,6 +6,13 @@ import (
 )
 
 func SetRoutes(e *echo.Echo) {
+	e.GET(types.RESOLVER_PATH+":did"+types.RESOURCES_PATH, ResourceCollectionEchoHandler)
 	e.GET(types.RESOLVER_PATH+":did"+types.RESOURCE_PATH+":resource", ResourceDataEchoHandler)
+	e.GET(types.RESOLVER_PATH+":did"+types.RESOURCE_PATH+types.RESOURCE_BY_NAME_PATH+":type/:name", ResourceByNameEchoHandler)
+	e.GET(types.RESOLVER_PATH+":did"+types.RESOURCE_PATH+types.RESOURCE_BY_NAME_PATH+":type/:name/:version", ResourceByNameVersionEchoHandler)
 	e.GET(types.RESOLVER_PATH+":did"+types.RESOURCE_PATH+":resource/metadata", ResourceMetadataEchoHandler)
+	e.GET(types.RESOLVER_PATH+":did"+types.RESOURCE_PATH+":resource"+types.ANONCREDS_PATH, ResourceAnonCredsEchoHandler)
+	e.GET(types.RESOLVER_PATH+":did"+types.RESOURCE_PATH+":resource"+types.RESOURCE_VERSIONS_PATH, ResourceVersionsEchoHandler)
+	e.GET(types.RESOLVER_PATH+":did"+types.ANONCREDS_PATH+types.STATUS_LIST_PATH, ResourceAnonCredsStatusListEchoHandler)
+	e.GET(types.RESOLVER_PATH+types.CREDENTIAL_STATUS_PATH, ResourceCredentialStatusEchoHandler)
 }
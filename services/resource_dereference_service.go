@@ -4,23 +4,52 @@ import (
 	// jsonpb Marshaller is deprecated, but is needed because there's only one way to proto
 	// marshal in combination with our proto generator version
 
+	"errors"
+	"fmt"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"github.com/rs/zerolog/log"
+
+	"github.com/cheqd/did-resolver/migrations"
 	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
 )
 
+// Number of resources served by the resolver whose data didn't match the checksum on the ledger
+var checksumMismatchCount atomic.Uint64
+
+// ChecksumMismatchCount is logged on every mismatch, and also reported by the admin API
+func ChecksumMismatchCount() uint64 {
+	return checksumMismatchCount.Load()
+}
+
 type ResourceService struct {
 	didMethod     string
 	ledgerService LedgerServiceI
+	// Resources addressed by name and type are redirected to their canonical URL instead of being served directly
+	byNameRedirect bool
 }
 
 func NewResourceService(didMethod string, ledgerService LedgerServiceI) ResourceService {
 	return ResourceService{
-		didMethod:     didMethod,
-		ledgerService: ledgerService,
+		didMethod:      didMethod,
+		ledgerService:  ledgerService,
+		byNameRedirect: true,
 	}
 }
 
+func (rds *ResourceService) SetByNameRedirect(redirect bool) {
+	rds.byNameRedirect = redirect
+}
+
+func (rds ResourceService) IsByNameRedirect() bool {
+	return rds.byNameRedirect
+}
+
 func (rds ResourceService) DereferenceResourceMetadata(did string, resourceId string, contentType types.ContentType) (*types.ResourceDereferencing, *types.IdentityError) {
 	dereferenceMetadata := types.NewDereferencingMetadata(did, contentType, "")
 
@@ -39,7 +68,44 @@ func (rds ResourceService) DereferenceResourceMetadata(did string, resourceId st
 	return &types.ResourceDereferencing{Context: context, Metadata: &types.ResolutionResourceMetadata{ContentMetadata: metadata}, DereferencingMetadata: dereferenceMetadata}, nil
 }
 
-func (rds ResourceService) ResolveMetadataResources(did string, contentType types.ContentType) (*types.DidResolution, *types.IdentityError) {
+// DereferenceResourceVersions returns all versions of the resource, i.e. resources with the same name and type, from the oldest to the newest
+func (rds ResourceService) DereferenceResourceVersions(did string, resourceId string, contentType types.ContentType) (*types.ResourceDereferencing, *types.IdentityError) {
+	collection, err := rds.ledgerService.QueryCollectionResources(did)
+	if err != nil {
+		err.ContentType = contentType
+		return nil, err
+	}
+
+	versions := types.NewDereferencedResourceListStruct(did, collection).Resources.GetVersions(strings.ToLower(resourceId))
+	if len(versions) == 0 {
+		return nil, types.NewNotFoundError(did, contentType, nil, true)
+	}
+
+	return types.NewResourceDereferencingFromResources(did, contentType, &versions), nil
+}
+
+// DereferenceCollectionResources returns metadata of the collection resources which match the filter, from the newest to the oldest.
+// The whole collection is queried, so pages of the list are cut by the resolver in the same order.
+func (rds ResourceService) DereferenceCollectionResources(did string, filter types.ResourceFilter, contentType types.ContentType) (*types.ResourceDereferencing, *types.IdentityError) {
+	// Collection of not existent DID is not found rather than empty
+	if _, err := rds.ledgerService.QueryDIDDoc(did, ""); err != nil {
+		err.ContentType = contentType
+		return nil, err
+	}
+
+	collection, err := rds.ledgerService.QueryCollectionResources(did)
+	if err != nil {
+		err.ContentType = contentType
+		return nil, err
+	}
+
+	resources := filter.Apply(types.NewDereferencedResourceListStruct(did, collection).Resources)
+	return types.NewResourceDereferencingFromResources(did, contentType, &resources), nil
+}
+
+// ResolveMetadataResources returns metadata of the latest DID Document version with all resources of the collection,
+// or with the page of them if the page is set
+func (rds ResourceService) ResolveMetadataResources(did string, page *query.PageRequest, contentType types.ContentType) (*types.DidResolution, *types.IdentityError) {
 	resolutionMetadata := types.NewResolutionMetadata(did, contentType, "")
 
 	didDoc, err := rds.ledgerService.QueryDIDDoc(did, "")
@@ -47,7 +113,7 @@ func (rds ResourceService) ResolveMetadataResources(did string, contentType type
 		return nil, err
 	}
 
-	resources, err := rds.ledgerService.QueryCollectionResources(did)
+	resources, pagination, err := queryCollection(rds.ledgerService, did, page)
 	if err != nil {
 		err.ContentType = contentType
 		return nil, err
@@ -59,6 +125,7 @@ func (rds ResourceService) ResolveMetadataResources(did string, contentType type
 	}
 
 	metadata := types.NewResolutionDidDocMetadata(did, didDoc.Metadata, resources)
+	metadata.ResourcePagination = pagination
 
 	return &types.DidResolution{Context: context, Metadata: metadata, ResolutionMetadata: resolutionMetadata}, nil
 }
@@ -96,8 +163,14 @@ func (rds ResourceService) DereferenceResourceData(did string, resourceId string
 		return nil, err
 	}
 
+	if err := verifyResourceChecksum(did, resource, contentType); err != nil {
+		return nil, err
+	}
+	dereferenceMetadata.ChecksumVerified = true
+
 	result := types.DereferencedResourceData(resource.Resource.Data)
-	dereferenceMetadata.ContentType = types.ContentType(resource.Metadata.MediaType)
+	// Media type may be missing or wrong on the ledger
+	dereferenceMetadata.ContentType = types.ContentType(utils.ResolveMediaType(resource.Metadata.MediaType, resource.Resource.Data))
 
 	return &types.ResourceDereferencing{ContentStream: &result, DereferencingMetadata: dereferenceMetadata}, nil
 }
@@ -111,6 +184,11 @@ func (rds ResourceService) DereferenceResourceDataWithMetadata(did string, resou
 		return nil, err
 	}
 
+	if err := verifyResourceChecksum(did, resource, contentType); err != nil {
+		return nil, err
+	}
+	dereferenceMetadata.ChecksumVerified = true
+
 	var context string
 	if contentType == types.DIDJSONLD || contentType == types.JSONLD {
 		context = types.ResolutionSchemaJSONLD
@@ -119,7 +197,8 @@ func (rds ResourceService) DereferenceResourceDataWithMetadata(did string, resou
 	var result types.ContentStreamI
 	result = types.NewDereferencedResourceData(resource.Resource.Data)
 	metadata := types.NewDereferencedResource(did, resource.Metadata)
-	if metadata.MediaType == string(types.JSON) || metadata.MediaType == string(types.TEXT) {
+	mediaType := utils.ResolveMediaType(metadata.MediaType, resource.Resource.Data)
+	if utils.IsJSONMediaType(mediaType) || utils.BaseMediaType(mediaType) == string(types.TEXT) {
 		if res, err := types.NewResourceData(resource.Resource.Data); err == nil {
 			result = res
 		}
@@ -127,3 +206,266 @@ func (rds ResourceService) DereferenceResourceDataWithMetadata(did string, resou
 
 	return &types.ResourceDereferencing{Context: context, ContentStream: result, Metadata: &types.ResolutionResourceMetadata{ContentMetadata: metadata}, DereferencingMetadata: dereferenceMetadata}, nil
 }
+
+// DereferenceAnonCredsObject returns the resource in the shape of AnonCreds object defined by AnonCreds Methods Registry
+func (rds ResourceService) DereferenceAnonCredsObject(did string, resourceId string, contentType types.ContentType) (*types.ResourceDereferencing, *types.IdentityError) {
+	dereferenceMetadata := types.NewDereferencingMetadata(did, contentType, "")
+
+	resource, err := rds.ledgerService.QueryResource(did, strings.ToLower(resourceId))
+	if err != nil {
+		err.ContentType = contentType
+		return nil, err
+	}
+
+	if err := verifyResourceChecksum(did, resource, contentType); err != nil {
+		return nil, err
+	}
+	dereferenceMetadata.ChecksumVerified = true
+
+	object, aErr := types.NewAnonCredsObject(did, resource)
+	if aErr != nil {
+		return nil, anonCredsObjectError(did, contentType, aErr)
+	}
+
+	var context string
+	if contentType == types.DIDJSONLD || contentType == types.JSONLD {
+		context = types.ResolutionSchemaJSONLD
+	}
+
+	metadata := types.NewDereferencedResource(did, resource.Metadata)
+	return &types.ResourceDereferencing{Context: context, ContentStream: object, Metadata: &types.ResolutionResourceMetadata{ContentMetadata: metadata}, DereferencingMetadata: dereferenceMetadata}, nil
+}
+
+// anonCredsObjectError rejects the DID URL of a resource which isn't an AnonCreds object,
+// while invalid data of an AnonCreds resource is the problem of the ledger
+func anonCredsObjectError(did string, contentType types.ContentType, err error) *types.IdentityError {
+	if errors.Is(err, types.ErrNotAnonCredsObject) {
+		return types.NewInvalidDidUrlError(did, contentType, err, true)
+	}
+	return types.NewInternalError(did, contentType, err, true)
+}
+
+// DereferenceAnonCredsStatusList returns the revocation status list which was current at the given time.
+// The registry is identified either by the id of revocation registry definition or by the name of status lists.
+func (rds ResourceService) DereferenceAnonCredsStatusList(did string, revRegDefId string, name string, timestamp string, contentType types.ContentType) (*types.AnonCredsStatusListDereferencing, *types.IdentityError) {
+	dereferenceMetadata := types.NewDereferencingMetadata(did, contentType, "")
+
+	collection, err := rds.ledgerService.QueryCollectionResources(did)
+	if err != nil {
+		err.ContentType = contentType
+		return nil, err
+	}
+	resources := types.NewDereferencedResourceListStruct(did, collection).Resources
+
+	// Status lists of the registry have the same name as its revocation registry definition
+	if revRegDefId != "" {
+		revRegDefs := resources.GetByResourceId(strings.ToLower(revRegDefId)).FilterByResourceType(types.AnonCredsRevocationRegistryDefinitionResourceType)
+		if len(revRegDefs) == 0 {
+			return nil, types.NewNotFoundError(did, contentType, nil, true)
+		}
+		name = revRegDefs[0].Name
+	}
+
+	statusLists := resources.FilterByResourceType(types.AnonCredsRevocationStatusListResourceType).FilterByResourceName(name)
+	resourceId, tErr := statusLists.FindBeforeTime(timestamp)
+	if tErr != nil {
+		return nil, types.NewInvalidDidUrlError(did, contentType, tErr, true)
+	}
+	if resourceId == "" {
+		return nil, types.NewNotFoundError(did, contentType, nil, true)
+	}
+
+	resource, err := rds.ledgerService.QueryResource(did, resourceId)
+	if err != nil {
+		err.ContentType = contentType
+		return nil, err
+	}
+
+	if err := verifyResourceChecksum(did, resource, contentType); err != nil {
+		return nil, err
+	}
+	dereferenceMetadata.ChecksumVerified = true
+
+	object, aErr := types.NewAnonCredsObject(did, resource)
+	if aErr != nil {
+		return nil, anonCredsObjectError(did, contentType, aErr)
+	}
+
+	var context string
+	if contentType == types.DIDJSONLD || contentType == types.JSONLD {
+		context = types.ResolutionSchemaJSONLD
+	}
+
+	metadata := &types.AnonCredsStatusListMetadata{
+		DereferencedResource: *types.NewDereferencedResource(did, resource.Metadata),
+		NextUpdate:           statusLists.FindNextVersionTime(resourceId),
+	}
+	return &types.AnonCredsStatusListDereferencing{
+		Context:               context,
+		DereferencingMetadata: dereferenceMetadata,
+		ContentStream:         object.(*types.AnonCredsRevocationStatusList),
+		Metadata:              metadata,
+	}, nil
+}
+
+// CheckCredentialStatus reads the status of the credential from Bitstring Status List or StatusList2021 credential.
+// If resourceId is not set, the latest status list with the given name and type is used.
+func (rds ResourceService) CheckCredentialStatus(
+	did string, resourceId string, name string, resourceType string, credentialStatus types.CredentialStatus, contentType types.ContentType,
+) (*types.ResourceDereferencing, *types.IdentityError) {
+	dereferenceMetadata := types.NewDereferencingMetadata(did, contentType, "")
+
+	if resourceId == "" {
+		var err *types.IdentityError
+		if resourceId, err = rds.findLatestResourceId(did, name, resourceType, contentType); err != nil {
+			return nil, err
+		}
+	}
+
+	resource, err := rds.ledgerService.QueryResource(did, strings.ToLower(resourceId))
+	if err != nil {
+		err.ContentType = contentType
+		return nil, err
+	}
+
+	if err := verifyResourceChecksum(did, resource, contentType); err != nil {
+		return nil, err
+	}
+	dereferenceMetadata.ChecksumVerified = true
+
+	statusList, sErr := types.ParseStatusListCredential(resource.Resource.Data)
+	if sErr != nil {
+		return nil, types.NewRepresentationNotSupportedError(did, contentType, sErr, true)
+	}
+	bitstring, sErr := statusList.DecodeBitstring()
+	if sErr != nil {
+		return nil, types.NewRepresentationNotSupportedError(did, contentType, sErr, true)
+	}
+
+	credentialStatus.StatusPurpose, sErr = statusList.GetStatusPurpose(credentialStatus.StatusPurpose)
+	if sErr != nil {
+		return nil, types.NewInvalidDidUrlError(did, contentType, sErr, true)
+	}
+	credentialStatus.Status, sErr = utils.GetStatusFromBitstring(bitstring, credentialStatus.StatusListIndex, credentialStatus.StatusSize)
+	if sErr != nil {
+		return nil, types.NewInvalidDidUrlError(did, contentType, sErr, true)
+	}
+
+	var context string
+	if contentType == types.DIDJSONLD || contentType == types.JSONLD {
+		context = types.ResolutionSchemaJSONLD
+	}
+
+	metadata := types.NewDereferencedResource(did, resource.Metadata)
+	return &types.ResourceDereferencing{Context: context, ContentStream: &credentialStatus, Metadata: &types.ResolutionResourceMetadata{ContentMetadata: metadata}, DereferencingMetadata: dereferenceMetadata}, nil
+}
+
+// ValidateResourceData validates already dereferenced JSON data of the resource against JSON Schema stored as another resource.
+// If schemaDidUrl is ValidateAgainstDeclared, the schema is taken from $schema of the data, and nil is returned if it isn't a DID URL.
+// Schemas referenced by $ref are dereferenced from the ledger as well.
+func (rds ResourceService) ValidateResourceData(did string, data []byte, schemaDidUrl string, contentType types.ContentType) (*types.SchemaValidation, *types.IdentityError) {
+	isDeclared := schemaDidUrl == types.ValidateAgainstDeclared
+	if isDeclared {
+		if schemaDidUrl = types.GetSchemaDidUrl(data); schemaDidUrl == "" {
+			return nil, nil
+		}
+	}
+
+	schema, err := rds.dereferenceSchema(schemaDidUrl, contentType)
+	if err != nil {
+		// Resource is still served if the schema it declares can't be dereferenced
+		if isDeclared {
+			return &types.SchemaValidation{Schema: schemaDidUrl, Errors: []string{"schema can't be dereferenced: " + err.Message}}, nil
+		}
+		return nil, err
+	}
+
+	loadSchema := func(url string) ([]byte, error) {
+		schema, err := rds.dereferenceSchema(url, contentType)
+		if err != nil {
+			return nil, err
+		}
+		return schema, nil
+	}
+	violations, vErr := utils.ValidateJSONSchema(schemaDidUrl, schema, data, loadSchema)
+	if vErr != nil {
+		if isDeclared {
+			return &types.SchemaValidation{Schema: schemaDidUrl, Errors: []string{vErr.Error()}}, nil
+		}
+		return nil, types.NewRepresentationNotSupportedError(did, contentType, vErr, true)
+	}
+
+	return &types.SchemaValidation{Schema: schemaDidUrl, Valid: len(violations) == 0, Errors: violations}, nil
+}
+
+// dereferenceSchema returns data of the resource with JSON Schema. Only DID URLs of resources are dereferenced
+func (rds ResourceService) dereferenceSchema(schemaDidUrl string, contentType types.ContentType) ([]byte, *types.IdentityError) {
+	schemaUrl, _, _ := strings.Cut(schemaDidUrl, "#")
+	resourceDidUrl, pErr := types.ParseResourceDidUrl(schemaUrl)
+	if pErr != nil {
+		return nil, types.NewInvalidDidUrlError(schemaDidUrl, contentType, pErr, true)
+	}
+
+	did := migrations.MigrateDID(resourceDidUrl.Did)
+	resourceId := resourceDidUrl.ResourceId
+	if resourceId == "" {
+		if resourceDidUrl.ResourceName == "" || resourceDidUrl.ResourceType == "" {
+			return nil, types.NewInvalidDidUrlError(schemaDidUrl, contentType, nil, true)
+		}
+		var err *types.IdentityError
+		if resourceId, err = rds.findLatestResourceId(did, resourceDidUrl.ResourceName, resourceDidUrl.ResourceType, contentType); err != nil {
+			return nil, err
+		}
+	}
+
+	schema, err := rds.DereferenceResourceData(did, resourceId, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return schema.ContentStream.GetBytes(), nil
+}
+
+// findLatestResourceId returns id of the latest version of the resource with the given name and type
+func (rds ResourceService) findLatestResourceId(did string, name string, resourceType string, contentType types.ContentType) (string, *types.IdentityError) {
+	collection, err := rds.ledgerService.QueryCollectionResources(did)
+	if err != nil {
+		err.ContentType = contentType
+		return "", err
+	}
+	resources := types.NewDereferencedResourceListStruct(did, collection).Resources
+	if name != "" {
+		resources = resources.FilterByResourceName(name)
+	}
+	if resourceType != "" {
+		resources = resources.FilterByResourceType(resourceType)
+	}
+	resourceId, tErr := resources.FindBeforeTime(time.Now().UTC().Format(time.RFC3339))
+	if tErr != nil {
+		return "", types.NewInvalidDidUrlError(did, contentType, tErr, true)
+	}
+	if resourceId == "" {
+		return "", types.NewNotFoundError(did, contentType, nil, true)
+	}
+	return resourceId, nil
+}
+
+// verifyResourceChecksum recomputes SHA-256 checksum of the resource data and compares it with the one stored on the ledger
+func verifyResourceChecksum(did string, resource *resourceTypes.ResourceWithMetadata, contentType types.ContentType) *types.IdentityError {
+	checksum := utils.Sha256Checksum(resource.Resource.Data)
+	if strings.EqualFold(checksum, resource.Metadata.Checksum) {
+		return nil
+	}
+
+	count := checksumMismatchCount.Add(1)
+	log.Error().
+		Str("did", did).
+		Str("resourceId", resource.Metadata.Id).
+		Str("expected", resource.Metadata.Checksum).
+		Str("actual", checksum).
+		Uint64("checksumMismatchCount", count).
+		Msg("Resource checksum mismatch")
+
+	return types.NewChecksumMismatchError(
+		did, contentType, fmt.Errorf("checksum of resource %s doesn't match the ledger", resource.Metadata.Id), true,
+	)
+}
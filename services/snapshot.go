@@ -0,0 +1,392 @@
+package services
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"github.com/cheqd/did-resolver/utils"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// Version of the snapshot layout, bumped on incompatible changes
+	SnapshotFormatVersion = 1
+
+	snapshotManifestFile = "manifest.json"
+	snapshotDidsDir      = "dids"
+)
+
+// Snapshot is a copy of DID Documents with all their versions and resources, read from the ledger for offline resolution.
+//
+// On disk it's a directory, or a .tar.gz archive of it, with manifest.json and one JSON file per DID
+// at dids/<namespace>/<id>.json. Ledger objects are stored in protobuf JSON mapping.
+type Snapshot struct {
+	CreatedAt  time.Time
+	Namespaces []string
+	Dids       map[string]*SnapshotDid
+}
+
+type SnapshotDid struct {
+	Versions  []*didTypes.DidDocWithMetadata
+	Resources []*resourceTypes.ResourceWithMetadata
+}
+
+type snapshotManifest struct {
+	Version    int       `json:"version"`
+	CreatedAt  time.Time `json:"createdAt"`
+	Namespaces []string  `json:"namespaces"`
+	Dids       []string  `json:"dids"`
+}
+
+type rawSnapshotDid struct {
+	Did       string            `json:"did"`
+	Versions  []json.RawMessage `json:"versions"`
+	Resources []json.RawMessage `json:"resources"`
+}
+
+func NewSnapshot() *Snapshot {
+	return &Snapshot{
+		CreatedAt: time.Now().UTC(),
+		Dids:      make(map[string]*SnapshotDid),
+	}
+}
+
+// ExportSnapshot crawls the DIDs through the ledger service and returns all versions of their DID Documents and resources
+func ExportSnapshot(ledgerService LedgerServiceI, dids []string) (*Snapshot, error) {
+	snapshot := NewSnapshot()
+	for _, did := range dids {
+		if _, ok := snapshot.Dids[did]; ok {
+			continue
+		}
+		log.Info().Msgf("Exporting DID: %s", did)
+
+		snapshotDid, err := exportSnapshotDid(ledgerService, did)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", did, err)
+		}
+		snapshot.Dids[did] = snapshotDid
+
+		_, namespace, _, _ := utils.TrySplitDID(did)
+		if !slices.Contains(snapshot.Namespaces, namespace) {
+			snapshot.Namespaces = append(snapshot.Namespaces, namespace)
+		}
+	}
+	return snapshot, nil
+}
+
+func exportSnapshotDid(ledgerService LedgerServiceI, did string) (*SnapshotDid, error) {
+	versions, err := ledgerService.QueryAllDidDocVersionsMetadata(did)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshotDid SnapshotDid
+	for _, version := range versions {
+		didDoc, err := ledgerService.QueryDIDDoc(did, version.VersionId)
+		if err != nil {
+			return nil, err
+		}
+		snapshotDid.Versions = append(snapshotDid.Versions, didDoc)
+	}
+
+	resources, err := ledgerService.QueryCollectionResources(did)
+	if err != nil {
+		return nil, err
+	}
+	for _, metadata := range resources {
+		resource, err := ledgerService.QueryResource(did, metadata.Id)
+		if err != nil {
+			return nil, err
+		}
+		snapshotDid.Resources = append(snapshotDid.Resources, resource)
+	}
+
+	if err := snapshotDid.verify(did); err != nil {
+		return nil, err
+	}
+	return &snapshotDid, nil
+}
+
+// collection returns metadata of the resources in the order of the snapshot
+func (sd *SnapshotDid) collection() []*resourceTypes.Metadata {
+	resources := make([]*resourceTypes.Metadata, 0, len(sd.Resources))
+	for _, resource := range sd.Resources {
+		resources = append(resources, resource.Metadata)
+	}
+	return resources
+}
+
+// verify checks that the DID Document versions and resources belong to the DID and resource data match their checksums
+func (sd *SnapshotDid) verify(did string) error {
+	if len(sd.Versions) == 0 {
+		return errors.New("no DID Document versions")
+	}
+	for _, version := range sd.Versions {
+		if version.DidDoc.Id != did {
+			return fmt.Errorf("DID Document version %s belongs to %s", version.Metadata.VersionId, version.DidDoc.Id)
+		}
+	}
+
+	_, _, collectionId, _ := utils.TrySplitDID(did)
+	for _, resource := range sd.Resources {
+		if resource.Metadata.CollectionId != collectionId {
+			return fmt.Errorf("resource %s belongs to collection %s", resource.Metadata.Id, resource.Metadata.CollectionId)
+		}
+		if checksum := utils.Sha256Checksum(resource.Resource.Data); checksum != resource.Metadata.Checksum {
+			return fmt.Errorf("resource %s: checksum mismatch: expected %s, got %s", resource.Metadata.Id, resource.Metadata.Checksum, checksum)
+		}
+	}
+	return nil
+}
+
+// ReadSnapshot reads a snapshot directory, or a .tar.gz archive, and verifies its integrity
+func ReadSnapshot(snapshotPath string) (*Snapshot, error) {
+	var files map[string][]byte
+	var err error
+	if isSnapshotArchive(snapshotPath) {
+		files, err = readSnapshotArchive(snapshotPath)
+	} else {
+		files, err = readSnapshotDirectory(snapshotPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	manifestData, ok := files[snapshotManifestFile]
+	if !ok {
+		return nil, fmt.Errorf("%s: %s is missing", snapshotPath, snapshotManifestFile)
+	}
+	var manifest snapshotManifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("%s: %w", snapshotManifestFile, err)
+	}
+	if manifest.Version != SnapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d, expected %d", manifest.Version, SnapshotFormatVersion)
+	}
+
+	snapshot := &Snapshot{
+		CreatedAt:  manifest.CreatedAt,
+		Namespaces: manifest.Namespaces,
+		Dids:       make(map[string]*SnapshotDid, len(manifest.Dids)),
+	}
+	for _, did := range manifest.Dids {
+		data, ok := files[snapshotDidFile(did)]
+		if !ok {
+			return nil, fmt.Errorf("%s: %s is missing", did, snapshotDidFile(did))
+		}
+		snapshotDid, err := unmarshalSnapshotDid(did, data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", did, err)
+		}
+		snapshot.Dids[did] = snapshotDid
+	}
+	return snapshot, nil
+}
+
+func unmarshalSnapshotDid(did string, data []byte) (*SnapshotDid, error) {
+	var raw rawSnapshotDid
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw.Did != did {
+		return nil, fmt.Errorf("file contains %s", raw.Did)
+	}
+
+	var snapshotDid SnapshotDid
+	for _, rawVersion := range raw.Versions {
+		var didDoc didTypes.DidDocWithMetadata
+		if err := protojson.Unmarshal(rawVersion, &didDoc); err != nil {
+			return nil, err
+		}
+		snapshotDid.Versions = append(snapshotDid.Versions, &didDoc)
+	}
+	for _, rawResource := range raw.Resources {
+		var resource resourceTypes.ResourceWithMetadata
+		if err := protojson.Unmarshal(rawResource, &resource); err != nil {
+			return nil, err
+		}
+		snapshotDid.Resources = append(snapshotDid.Resources, &resource)
+	}
+
+	if err := snapshotDid.verify(did); err != nil {
+		return nil, err
+	}
+	return &snapshotDid, nil
+}
+
+// WriteSnapshot writes the snapshot to a directory, or to a .tar.gz archive if the path has such extension
+func WriteSnapshot(snapshot *Snapshot, snapshotPath string) error {
+	files, err := marshalSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+	if isSnapshotArchive(snapshotPath) {
+		return writeSnapshotArchive(files, snapshotPath)
+	}
+	return writeSnapshotDirectory(files, snapshotPath)
+}
+
+func marshalSnapshot(snapshot *Snapshot) (map[string][]byte, error) {
+	manifest := snapshotManifest{
+		Version:    SnapshotFormatVersion,
+		CreatedAt:  snapshot.CreatedAt,
+		Namespaces: snapshot.Namespaces,
+		Dids:       make([]string, 0, len(snapshot.Dids)),
+	}
+	files := make(map[string][]byte, len(snapshot.Dids)+1)
+	for did, snapshotDid := range snapshot.Dids {
+		raw := rawSnapshotDid{Did: did}
+		for _, version := range snapshotDid.Versions {
+			data, err := protojson.Marshal(version)
+			if err != nil {
+				return nil, err
+			}
+			raw.Versions = append(raw.Versions, data)
+		}
+		for _, resource := range snapshotDid.Resources {
+			data, err := protojson.Marshal(resource)
+			if err != nil {
+				return nil, err
+			}
+			raw.Resources = append(raw.Resources, data)
+		}
+
+		data, err := json.MarshalIndent(raw, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		files[snapshotDidFile(did)] = data
+		manifest.Dids = append(manifest.Dids, did)
+	}
+	slices.Sort(manifest.Dids)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files[snapshotManifestFile] = data
+	return files, nil
+}
+
+// snapshotDidFile returns the path of the DID file inside of the snapshot
+func snapshotDidFile(did string) string {
+	_, namespace, id, _ := utils.TrySplitDID(did)
+	return path.Join(snapshotDidsDir, namespace, id+".json")
+}
+
+func isSnapshotArchive(snapshotPath string) bool {
+	return strings.HasSuffix(snapshotPath, ".tar.gz") || strings.HasSuffix(snapshotPath, ".tgz")
+}
+
+func readSnapshotDirectory(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	root := os.DirFS(dir)
+	err := fs.WalkDir(root, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(root, name)
+		if err != nil {
+			return err
+		}
+		files[name] = data
+		return nil
+	})
+	return files, err
+}
+
+func readSnapshotArchive(archivePath string) (map[string][]byte, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+
+	files := make(map[string][]byte)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Clean(header.Name)] = data
+	}
+}
+
+func writeSnapshotDirectory(files map[string][]byte, dir string) error {
+	for name, data := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filePath, data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSnapshotArchive(files map[string][]byte, archivePath string) error {
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(files[name])), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(files[name]); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	return file.Close()
+}
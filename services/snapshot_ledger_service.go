@@ -0,0 +1,131 @@
+package services
+
+import (
+	"errors"
+	"slices"
+
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+// SnapshotLedgerService serves namespaces of the snapshot without access to the ledger.
+// DIDs of other namespaces are passed to the next ledger service, if any.
+type SnapshotLedgerService struct {
+	snapshot *Snapshot
+	next     LedgerServiceI
+}
+
+func NewSnapshotLedgerService(snapshot *Snapshot, next LedgerServiceI) SnapshotLedgerService {
+	return SnapshotLedgerService{snapshot: snapshot, next: next}
+}
+
+// lookup returns the DID from the snapshot, or the next ledger service if the namespace isn't in the snapshot
+func (sls SnapshotLedgerService) lookup(did string, isDereferencing bool) (*SnapshotDid, LedgerServiceI, *types.IdentityError) {
+	method, namespace, _, _ := utils.TrySplitDID(did)
+	if method != types.DID_METHOD || !slices.Contains(sls.snapshot.Namespaces, namespace) {
+		if sls.next != nil {
+			return nil, sls.next, nil
+		}
+		return nil, nil, types.NewInvalidDidError(did, types.JSON, nil, isDereferencing)
+	}
+
+	snapshotDid, ok := sls.snapshot.Dids[did]
+	if !ok {
+		return nil, nil, types.NewNotFoundError(did, types.JSON, errors.New("DID is not in the snapshot"), isDereferencing)
+	}
+	return snapshotDid, nil, nil
+}
+
+func (sls SnapshotLedgerService) QueryDIDDoc(did string, version string) (*didTypes.DidDocWithMetadata, *types.IdentityError) {
+	snapshotDid, next, err := sls.lookup(did, false)
+	if err != nil {
+		return nil, err
+	}
+	if next != nil {
+		return next.QueryDIDDoc(did, version)
+	}
+
+	for _, didDoc := range snapshotDid.Versions {
+		if version == "" && didDoc.Metadata.NextVersionId == "" || version != "" && didDoc.Metadata.VersionId == version {
+			return didDoc, nil
+		}
+	}
+	return nil, types.NewNotFoundError(did, types.JSON, errors.New("DID Document version is not in the snapshot"), false)
+}
+
+func (sls SnapshotLedgerService) QueryAllDidDocVersionsMetadata(did string) ([]*didTypes.Metadata, *types.IdentityError) {
+	snapshotDid, next, err := sls.lookup(did, false)
+	if err != nil {
+		return nil, err
+	}
+	if next != nil {
+		return next.QueryAllDidDocVersionsMetadata(did)
+	}
+
+	versions := make([]*didTypes.Metadata, 0, len(snapshotDid.Versions))
+	for _, didDoc := range snapshotDid.Versions {
+		versions = append(versions, didDoc.Metadata)
+	}
+	return versions, nil
+}
+
+func (sls SnapshotLedgerService) QueryResource(did string, resourceId string) (*resourceTypes.ResourceWithMetadata, *types.IdentityError) {
+	snapshotDid, next, err := sls.lookup(did, true)
+	if err != nil {
+		return nil, err
+	}
+	if next != nil {
+		return next.QueryResource(did, resourceId)
+	}
+
+	for _, resource := range snapshotDid.Resources {
+		if resource.Metadata.Id == resourceId {
+			return resource, nil
+		}
+	}
+	return nil, types.NewNotFoundError(did, types.JSON, errors.New("resource is not in the snapshot"), true)
+}
+
+func (sls SnapshotLedgerService) QueryCollectionResources(did string) ([]*resourceTypes.Metadata, *types.IdentityError) {
+	snapshotDid, next, err := sls.lookup(did, false)
+	if err != nil {
+		return nil, err
+	}
+	if next != nil {
+		return next.QueryCollectionResources(did)
+	}
+
+	return snapshotDid.collection(), nil
+}
+
+func (sls SnapshotLedgerService) QueryCollectionResourcesPage(did string, page *query.PageRequest) ([]*resourceTypes.Metadata, *query.PageResponse, *types.IdentityError) {
+	snapshotDid, next, err := sls.lookup(did, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if next != nil {
+		return next.QueryCollectionResourcesPage(did, page)
+	}
+
+	resources, pagination, pageErr := PageCollectionResources(snapshotDid.collection(), page)
+	if pageErr != nil {
+		return nil, nil, types.NewInvalidDidUrlError(did, types.JSON, pageErr, false)
+	}
+	return resources, pagination, nil
+}
+
+func (sls SnapshotLedgerService) GetNamespaces() []string {
+	namespaces := slices.Clone(sls.snapshot.Namespaces)
+	if sls.next == nil {
+		return namespaces
+	}
+	for _, namespace := range sls.next.GetNamespaces() {
+		if !slices.Contains(namespaces, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
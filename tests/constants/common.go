@@ -45,7 +45,6 @@ func generateDIDDoc() didTypes.DidDoc {
 
 func generateResource() []resourceTypes.ResourceWithMetadata {
 	data := []byte("{\"attr\":[\"name\",\"age\"]}")
-	checksum := sha256.New().Sum(data)
 	return []resourceTypes.ResourceWithMetadata{
 		{
 			Resource: &resourceTypes.Resource{
@@ -57,7 +56,7 @@ func generateResource() []resourceTypes.ResourceWithMetadata {
 				Name:         "Existing Resource Name",
 				ResourceType: "string",
 				MediaType:    "application/json",
-				Checksum:     fmt.Sprintf("%x", checksum),
+				Checksum:     generateChecksum(data),
 			},
 		},
 	}
@@ -125,6 +125,7 @@ var (
 		Checksum:          ValidResourceMetadata.Checksum,
 		PreviousVersionId: nil,
 		NextVersionId:     nil,
+		Links:             types.NewResourceVersionLinks(ExistentDid, &ValidResourceMetadata),
 	}
 )
 
@@ -0,0 +1,75 @@
+package fakenode
+
+import (
+	_ "embed"
+	"encoding/json"
+	"os"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"github.com/cheqd/did-resolver/utils"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+//go:embed testdata/ledger.json
+var defaultFixtures []byte
+
+// Fixtures is the state of the ledger served by the fake node.
+// All versions of DID Documents are listed, the latest one has no nextVersionId.
+type Fixtures struct {
+	DidDocs   []*didTypes.DidDocWithMetadata
+	Resources []*resourceTypes.ResourceWithMetadata
+}
+
+// rawFixtures keeps ledger objects in protobuf JSON mapping, as they are returned by the REST API of cheqd node
+type rawFixtures struct {
+	DidDocs   []json.RawMessage `json:"didDocs"`
+	Resources []json.RawMessage `json:"resources"`
+}
+
+// LoadFixtures parses fixtures from JSON. Missing checksums of resources are computed from their data.
+func LoadFixtures(data []byte) (*Fixtures, error) {
+	var raw rawFixtures
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	var fixtures Fixtures
+	for _, rawDidDoc := range raw.DidDocs {
+		var didDoc didTypes.DidDocWithMetadata
+		if err := protojson.Unmarshal(rawDidDoc, &didDoc); err != nil {
+			return nil, err
+		}
+		fixtures.DidDocs = append(fixtures.DidDocs, &didDoc)
+	}
+
+	for _, rawResource := range raw.Resources {
+		var resource resourceTypes.ResourceWithMetadata
+		if err := protojson.Unmarshal(rawResource, &resource); err != nil {
+			return nil, err
+		}
+		if resource.Metadata.Checksum == "" {
+			resource.Metadata.Checksum = utils.Sha256Checksum(resource.Resource.Data)
+		}
+		fixtures.Resources = append(fixtures.Resources, &resource)
+	}
+	return &fixtures, nil
+}
+
+// LoadFixturesFile parses fixtures from JSON file
+func LoadFixturesFile(path string) (*Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadFixtures(data)
+}
+
+// DefaultFixtures returns the testnet DID with two versions of DID Document and three resources
+func DefaultFixtures() *Fixtures {
+	fixtures, err := LoadFixtures(defaultFixtures)
+	if err != nil {
+		panic(err)
+	}
+	return fixtures
+}
@@ -0,0 +1,265 @@
+// Package fakenode is an in-process cheqd node which serves DID and resource queries from fixtures over gRPC
+// on a loopback port. It lets tests go through the real LedgerService and EndpointManager without network access.
+package fakenode
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Node struct {
+	fixtures *Fixtures
+	server   *grpc.Server
+	listener net.Listener
+
+	mutex sync.RWMutex
+	// Error returned by all queries instead of the fixtures
+	err      error
+	requests atomic.Int64
+}
+
+// Start serves the fixtures on a free loopback port until Stop is called
+func Start(fixtures *Fixtures) (*Node, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	node := &Node{fixtures: fixtures, server: grpc.NewServer(), listener: listener}
+	didTypes.RegisterQueryServer(node.server, &didQueryServer{node: node})
+	resourceTypes.RegisterQueryServer(node.server, &resourceQueryServer{node: node})
+
+	go func() {
+		_ = node.server.Serve(listener)
+	}()
+	return node, nil
+}
+
+// Address returns host:port of the node in the format of endpoint configuration, without TLS
+func (n *Node) Address() string {
+	return n.listener.Addr().String()
+}
+
+// Stop closes the listener and all connections, so the node becomes unreachable
+func (n *Node) Stop() {
+	n.server.Stop()
+}
+
+// FailWith makes all queries return the error, e.g. status.Error(codes.Unavailable, ...).
+// Nil error restores serving of the fixtures.
+func (n *Node) FailWith(err error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.err = err
+}
+
+// Requests returns the number of queries received by the node, including health checks
+func (n *Node) Requests() int64 {
+	return n.requests.Load()
+}
+
+func (n *Node) receive() error {
+	n.requests.Add(1)
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return n.err
+}
+
+// DID module
+
+type didQueryServer struct {
+	didTypes.UnimplementedQueryServer
+	node *Node
+}
+
+func (s *didQueryServer) versions(id string) []*didTypes.DidDocWithMetadata {
+	var versions []*didTypes.DidDocWithMetadata
+	for _, didDoc := range s.node.fixtures.DidDocs {
+		if didDoc.DidDoc.Id == id {
+			versions = append(versions, didDoc)
+		}
+	}
+	return versions
+}
+
+func (s *didQueryServer) DidDoc(ctx context.Context, request *didTypes.QueryDidDocRequest) (*didTypes.QueryDidDocResponse, error) {
+	if err := s.node.receive(); err != nil {
+		return nil, err
+	}
+
+	versions := s.versions(request.Id)
+	for _, version := range versions {
+		if version.Metadata.NextVersionId == "" {
+			return &didTypes.QueryDidDocResponse{Value: version}, nil
+		}
+	}
+	if len(versions) != 0 {
+		return &didTypes.QueryDidDocResponse{Value: versions[len(versions)-1]}, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "%s: DID Doc not found", request.Id)
+}
+
+func (s *didQueryServer) DidDocVersion(ctx context.Context, request *didTypes.QueryDidDocVersionRequest) (*didTypes.QueryDidDocVersionResponse, error) {
+	if err := s.node.receive(); err != nil {
+		return nil, err
+	}
+
+	for _, version := range s.versions(request.Id) {
+		if version.Metadata.VersionId == request.Version {
+			return &didTypes.QueryDidDocVersionResponse{Value: version}, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "%s: DID Doc version %s not found", request.Id, request.Version)
+}
+
+func (s *didQueryServer) AllDidDocVersionsMetadata(ctx context.Context, request *didTypes.QueryAllDidDocVersionsMetadataRequest) (*didTypes.QueryAllDidDocVersionsMetadataResponse, error) {
+	if err := s.node.receive(); err != nil {
+		return nil, err
+	}
+
+	versions := s.versions(request.Id)
+	if len(versions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "%s: DID Doc not found", request.Id)
+	}
+	response := &didTypes.QueryAllDidDocVersionsMetadataResponse{}
+	for _, version := range versions {
+		response.Versions = append(response.Versions, version.Metadata)
+	}
+	return response, nil
+}
+
+func (s *didQueryServer) Params(ctx context.Context, request *didTypes.QueryParamsRequest) (*didTypes.QueryParamsResponse, error) {
+	if err := s.node.receive(); err != nil {
+		return nil, err
+	}
+	return &didTypes.QueryParamsResponse{Params: &didTypes.FeeParams{}}, nil
+}
+
+// Resource module
+
+type resourceQueryServer struct {
+	resourceTypes.UnimplementedQueryServer
+	node *Node
+}
+
+func (s *resourceQueryServer) find(collectionId string, id string) *resourceTypes.ResourceWithMetadata {
+	for _, resource := range s.node.fixtures.Resources {
+		if resource.Metadata.CollectionId == collectionId && strings.EqualFold(resource.Metadata.Id, id) {
+			return resource
+		}
+	}
+	return nil
+}
+
+func (s *resourceQueryServer) findLatest(collectionId string, name string, resourceType string) *resourceTypes.ResourceWithMetadata {
+	for _, resource := range s.node.fixtures.Resources {
+		metadata := resource.Metadata
+		if metadata.CollectionId == collectionId && metadata.Name == name && metadata.ResourceType == resourceType && metadata.NextVersionId == "" {
+			return resource
+		}
+	}
+	return nil
+}
+
+func (s *resourceQueryServer) Resource(ctx context.Context, request *resourceTypes.QueryResourceRequest) (*resourceTypes.QueryResourceResponse, error) {
+	if err := s.node.receive(); err != nil {
+		return nil, err
+	}
+
+	resource := s.find(request.CollectionId, request.Id)
+	if resource == nil {
+		return nil, status.Errorf(codes.NotFound, "resource %s:%s: not found", request.CollectionId, request.Id)
+	}
+	return &resourceTypes.QueryResourceResponse{Resource: resource}, nil
+}
+
+func (s *resourceQueryServer) ResourceMetadata(ctx context.Context, request *resourceTypes.QueryResourceMetadataRequest) (*resourceTypes.QueryResourceMetadataResponse, error) {
+	if err := s.node.receive(); err != nil {
+		return nil, err
+	}
+
+	resource := s.find(request.CollectionId, request.Id)
+	if resource == nil {
+		return nil, status.Errorf(codes.NotFound, "resource %s:%s: not found", request.CollectionId, request.Id)
+	}
+	return &resourceTypes.QueryResourceMetadataResponse{Resource: resource.Metadata}, nil
+}
+
+func (s *resourceQueryServer) LatestResourceVersion(ctx context.Context, request *resourceTypes.QueryLatestResourceVersionRequest) (*resourceTypes.QueryLatestResourceVersionResponse, error) {
+	if err := s.node.receive(); err != nil {
+		return nil, err
+	}
+
+	resource := s.findLatest(request.CollectionId, request.Name, request.ResourceType)
+	if resource == nil {
+		return nil, status.Errorf(codes.NotFound, "resource %s:%s: not found", request.CollectionId, request.Name)
+	}
+	return &resourceTypes.QueryLatestResourceVersionResponse{Resource: resource}, nil
+}
+
+func (s *resourceQueryServer) LatestResourceVersionMetadata(ctx context.Context, request *resourceTypes.QueryLatestResourceVersionMetadataRequest) (*resourceTypes.QueryLatestResourceVersionMetadataResponse, error) {
+	if err := s.node.receive(); err != nil {
+		return nil, err
+	}
+
+	resource := s.findLatest(request.CollectionId, request.Name, request.ResourceType)
+	if resource == nil {
+		return nil, status.Errorf(codes.NotFound, "resource %s:%s: not found", request.CollectionId, request.Name)
+	}
+	return &resourceTypes.QueryLatestResourceVersionMetadataResponse{Resource: resource.Metadata}, nil
+}
+
+// CollectionResources returns metadata page by page. The key of the next page is the offset of its first resource.
+func (s *resourceQueryServer) CollectionResources(ctx context.Context, request *resourceTypes.QueryCollectionResourcesRequest) (*resourceTypes.QueryCollectionResourcesResponse, error) {
+	if err := s.node.receive(); err != nil {
+		return nil, err
+	}
+
+	var resources []*resourceTypes.Metadata
+	for _, resource := range s.node.fixtures.Resources {
+		if resource.Metadata.CollectionId == request.CollectionId {
+			resources = append(resources, resource.Metadata)
+		}
+	}
+
+	offset, limit := 0, len(resources)
+	if request.Pagination != nil {
+		if len(request.Pagination.Key) != 0 {
+			var err error
+			if offset, err = strconv.Atoi(string(request.Pagination.Key)); err != nil || offset > len(resources) {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid pagination key")
+			}
+		}
+		if request.Pagination.Limit != 0 {
+			limit = int(request.Pagination.Limit)
+		}
+	}
+
+	end := min(offset+limit, len(resources))
+	response := &resourceTypes.QueryCollectionResourcesResponse{
+		Resources:  resources[offset:end],
+		Pagination: &query.PageResponse{Total: uint64(len(resources))},
+	}
+	if end < len(resources) {
+		response.Pagination.NextKey = []byte(strconv.Itoa(end))
+	}
+	return response, nil
+}
+
+func (s *resourceQueryServer) Params(ctx context.Context, request *resourceTypes.QueryParamsRequest) (*resourceTypes.QueryParamsResponse, error) {
+	if err := s.node.receive(); err != nil {
+		return nil, err
+	}
+	return &resourceTypes.QueryParamsResponse{Params: &resourceTypes.FeeParams{}}, nil
+}
@@ -56,7 +56,7 @@ var _ = DescribeTable("Positive: Get Service param", func(testCase utils.Positiv
 			),
 			ResolutionType:         testconstants.DefaultResolutionType,
 			ExpectedStatusCode:     http.StatusSeeOther,
-			ExpectedLocationHeader: expectedLocationHeader + "foo",
+			ExpectedLocationHeader: expectedLocationHeader + "/foo",
 		},
 	),
 
@@ -65,6 +65,7 @@ func AssertResourceDataWithMetadata(expected ResourceDereferencingResult, receiv
 	Expect(expected.DereferencingMetadata.ContentType).To(Equal(received.DereferencingMetadata.ContentType))
 	Expect(expected.DereferencingMetadata.ResolutionError).To(Equal(received.DereferencingMetadata.ResolutionError))
 	Expect(expected.DereferencingMetadata.DidProperties).To(Equal(received.DereferencingMetadata.DidProperties))
+	Expect(expected.DereferencingMetadata.ChecksumVerified).To(Equal(received.DereferencingMetadata.ChecksumVerified))
 	Expect(expected.ContentStream).To(Equal(received.ContentStream))
 	Expect(isBase64Encoded(received.ContentStream)).To(BeFalse())
 	Expect(expected.Metadata.ResourceType).To(Equal(received.Metadata.ResourceType))
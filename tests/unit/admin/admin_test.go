@@ -0,0 +1,140 @@
+//go:build unit
+
+package admin_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/services/admin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	fixtureDid = "did:cheqd:testnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0"
+	devnetDid  = "did:cheqd:devnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0"
+)
+
+func decode[T any](body []byte) T {
+	var value T
+	Expect(json.Unmarshal(body, &value)).To(Succeed())
+	return value
+}
+
+var _ = Describe("Admin API", func() {
+	It("rejects requests without the valid token", func() {
+		adminServer, _, _ := startAdmin(nil)
+
+		Expect(call(adminServer, http.MethodGet, "/endpoints", "", "").Code).To(Equal(http.StatusBadRequest))
+		Expect(call(adminServer, http.MethodGet, "/endpoints", "", "wrong-token").Code).To(Equal(http.StatusUnauthorized))
+		Expect(call(adminServer, http.MethodGet, "/endpoints", "", adminToken).Code).To(Equal(http.StatusOK))
+	})
+
+	It("lists endpoints with their health", func() {
+		adminServer, _, node := startAdmin(nil)
+
+		response := call(adminServer, http.MethodGet, "/endpoints", "", adminToken)
+		endpoints := decode[[]admin.EndpointResponse](response.Body.Bytes())
+		Expect(endpoints).To(HaveLen(1))
+		Expect(endpoints[0].Namespace).To(Equal("testnet"))
+		Expect(endpoints[0].URL).To(Equal(node.Address()))
+		Expect(endpoints[0].Healthy).To(BeTrue())
+		Expect(endpoints[0].Drained).To(BeFalse())
+	})
+
+	It("drains the endpoint and returns it to service", func() {
+		adminServer, resolver, node := startAdmin(nil)
+		mark := func(healthy bool) admin.EndpointResponse {
+			body := fmt.Sprintf(`{"namespace": "testnet", "url": %q, "healthy": %t}`, node.Address(), healthy)
+			response := call(adminServer, http.MethodPost, "/endpoints/mark", body, adminToken)
+			Expect(response.Code).To(Equal(http.StatusOK))
+			return decode[admin.EndpointResponse](response.Body.Bytes())
+		}
+
+		drained := mark(false)
+		Expect(drained.Drained).To(BeTrue())
+		Expect(drained.Healthy).To(BeFalse())
+		Expect(resolve(resolver, fixtureDid)).To(Equal(http.StatusInternalServerError))
+
+		// Health checks don't return drained endpoints to service
+		response := call(adminServer, http.MethodPost, "/health-check", "", adminToken)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(decode[[]admin.EndpointResponse](response.Body.Bytes())[0].Healthy).To(BeFalse())
+
+		Expect(mark(true).Healthy).To(BeTrue())
+		Expect(resolve(resolver, fixtureDid)).To(Equal(http.StatusOK))
+	})
+
+	It("reports unknown endpoints", func() {
+		adminServer, _, _ := startAdmin(nil)
+
+		body := `{"namespace": "testnet", "url": "localhost:1", "healthy": false}`
+		Expect(call(adminServer, http.MethodPost, "/endpoints/mark", body, adminToken).Code).To(Equal(http.StatusNotFound))
+		Expect(call(adminServer, http.MethodDelete, "/endpoints?namespace=testnet&url=localhost:1", "", adminToken).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("registers a new namespace with its first endpoint and unregisters it with the last one", func() {
+		adminServer, resolver, node := startAdmin(nil)
+		Expect(resolve(resolver, devnetDid)).To(Equal(http.StatusBadRequest))
+
+		body := fmt.Sprintf(`{"namespace": "devnet", "url": %q, "timeout": "5s"}`, node.Address())
+		response := call(adminServer, http.MethodPost, "/endpoints", body, adminToken)
+		Expect(response.Code).To(Equal(http.StatusCreated))
+		added := decode[admin.EndpointResponse](response.Body.Bytes())
+		Expect(added.Namespace).To(Equal("devnet"))
+		Expect(added.Healthy).To(BeTrue())
+		// The fake node serves only testnet DIDs, so the DID is looked up but not found
+		Expect(resolve(resolver, devnetDid)).To(Equal(http.StatusNotFound))
+
+		Expect(call(adminServer, http.MethodPost, "/endpoints", body, adminToken).Code).To(Equal(http.StatusConflict))
+
+		response = call(adminServer, http.MethodDelete, "/endpoints?namespace=devnet&url="+url.QueryEscape(node.Address()), "", adminToken)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(decode[admin.RemoveEndpointResponse](response.Body.Bytes()).NamespaceRemoved).To(BeTrue())
+		Expect(resolve(resolver, devnetDid)).To(Equal(http.StatusBadRequest))
+		Expect(resolve(resolver, fixtureDid)).To(Equal(http.StatusOK))
+	})
+
+	DescribeTable("rejects invalid endpoints",
+		func(body string) {
+			adminServer, _, _ := startAdmin(nil)
+			Expect(call(adminServer, http.MethodPost, "/endpoints", body, adminToken).Code).To(Equal(http.StatusBadRequest))
+		},
+		Entry("namespace with dash", `{"namespace": "dev-net", "url": "localhost:1", "timeout": "5s"}`),
+		Entry("empty namespace", `{"url": "localhost:1", "timeout": "5s"}`),
+		Entry("missing url", `{"namespace": "devnet", "timeout": "5s"}`),
+		Entry("unknown role", `{"namespace": "devnet", "url": "localhost:1", "role": "backup", "timeout": "5s"}`),
+		Entry("missing timeout", `{"namespace": "devnet", "url": "localhost:1"}`),
+	)
+
+	It("flushes the cache", func() {
+		cache, err := services.NewDiskCache(GinkgoT().TempDir(), 1<<20)
+		Expect(err).To(BeNil())
+		Expect(cache.Put("a", []byte("1"))).To(Succeed())
+		Expect(cache.Put("b", []byte("2"))).To(Succeed())
+		adminServer, _, _ := startAdmin(cache)
+
+		response := call(adminServer, http.MethodPost, "/cache/flush", "", adminToken)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(decode[admin.FlushCacheResponse](response.Body.Bytes()).Removed).To(Equal(2))
+		Expect(cache.Len()).To(BeZero())
+		Expect(cache.Size()).To(BeZero())
+	})
+
+	It("reports the disabled cache", func() {
+		adminServer, _, _ := startAdmin(nil)
+		Expect(call(adminServer, http.MethodPost, "/cache/flush", "", adminToken).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("reports the number of checksum mismatches", func() {
+		adminServer, _, _ := startAdmin(nil)
+
+		response := call(adminServer, http.MethodGet, "/stats", "", adminToken)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(decode[admin.StatsResponse](response.Body.Bytes()).ChecksumMismatchCount).To(Equal(services.ChecksumMismatchCount()))
+	})
+})
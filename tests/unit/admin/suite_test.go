@@ -0,0 +1,96 @@
+//go:build unit
+
+package admin_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/services/admin"
+	didDocServices "github.com/cheqd/did-resolver/services/diddoc"
+	"github.com/cheqd/did-resolver/tests/fakenode"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const adminToken = "test-admin-token"
+
+func TestAdmin(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "[Unit Test]: Admin API")
+}
+
+// startAdmin returns the admin API and the resolver sharing the ledger service of the testnet fake node
+func startAdmin(cache *services.DiskCache) (adminServer *echo.Echo, resolver *echo.Echo, node *fakenode.Node) {
+	node, err := fakenode.Start(fakenode.DefaultFixtures())
+	Expect(err).To(BeNil())
+	DeferCleanup(node.Stop)
+
+	network := newNetwork("testnet", node.Address())
+	endpointManager := services.NewEndpointManager(types.Config{Networks: []types.Network{network}})
+	ledgerService := services.NewLedgerService(endpointManager)
+	Expect(ledgerService.RegisterLedger(types.DID_METHOD, network)).To(Succeed())
+
+	adminService := &admin.Service{EndpointManager: endpointManager, LedgerService: &ledgerService, Cache: cache}
+	adminServer = echo.New()
+	adminService.SetRoutes(adminServer, adminToken)
+	return adminServer, newResolver(ledgerService), node
+}
+
+func newNetwork(namespace string, url string) types.Network {
+	return types.Network{
+		Namespace: namespace,
+		Endpoints: []types.Endpoint{{URL: url, Timeout: 5 * time.Second, Role: types.EndpointRolePrimary}},
+	}
+}
+
+func newResolver(ledgerService services.LedgerServiceI) *echo.Echo {
+	didService := services.NewDIDDocService(types.DID_METHOD, ledgerService)
+	resourceService := services.NewResourceService(types.DID_METHOD, ledgerService)
+
+	e := echo.New()
+	e.HTTPErrorHandler = services.CustomHTTPErrorHandler
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return next(services.ResolverContext{
+				Context:         c,
+				LedgerService:   ledgerService,
+				DidDocService:   didService,
+				ResourceService: resourceService,
+			})
+		}
+	})
+	didDocServices.SetRoutes(e)
+	return e
+}
+
+// call sends the request to the admin API with the token, if any
+func call(e *echo.Echo, method string, path string, body string, token string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	request := httptest.NewRequest(method, admin.ADMIN_PATH+path, reader)
+	if body != "" {
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	if token != "" {
+		request.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func resolve(e *echo.Echo, did string) int {
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, types.RESOLVER_PATH+did, nil))
+	return recorder.Code
+}
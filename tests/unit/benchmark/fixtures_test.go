@@ -0,0 +1,117 @@
+//go:build unit
+
+package benchmark
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"github.com/cheqd/did-resolver/services"
+	didDocServices "github.com/cheqd/did-resolver/services/diddoc"
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	"github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Request logging would dominate the measurements
+func init() {
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+}
+
+// Sizes of resource collections, from a typical DID to one with a long status list history
+var collectionSizes = []int{10, 100, 1000}
+
+// Number of distinct resource names in generated collections, each of them has several versions
+const resourceNames = 5
+
+var created = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func resourceId(i int) string {
+	return fmt.Sprintf("%08x-0000-4000-8000-%012x", i, i)
+}
+
+// generateResources makes a collection of ledger resources, where every name has a chain of versions
+func generateResources(n int) []resourceTypes.ResourceWithMetadata {
+	resources := make([]resourceTypes.ResourceWithMetadata, n)
+	for i := range resources {
+		data := []byte(fmt.Sprintf(`{"version":%d}`, i))
+		metadata := &resourceTypes.Metadata{
+			CollectionId: testconstants.ValidIdentifier,
+			Id:           resourceId(i),
+			Name:         fmt.Sprintf("Resource %d", i%resourceNames),
+			ResourceType: "JSONSchema2020",
+			MediaType:    "application/json",
+			Version:      fmt.Sprint(i / resourceNames),
+			Created:      timestamppb.New(created.Add(time.Duration(i) * time.Minute)),
+			Checksum:     utils.Sha256Checksum(data),
+		}
+		if i >= resourceNames {
+			metadata.PreviousVersionId = resourceId(i - resourceNames)
+		}
+		if i+resourceNames < n {
+			metadata.NextVersionId = resourceId(i + resourceNames)
+		}
+		resources[i] = resourceTypes.ResourceWithMetadata{
+			Resource: &resourceTypes.Resource{Data: data},
+			Metadata: metadata,
+		}
+	}
+	return resources
+}
+
+func generateResourceList(n int) types.DereferencedResourceList {
+	resources := generateResources(n)
+	list := make(types.DereferencedResourceList, n)
+	for i := range resources {
+		list[i] = *types.NewDereferencedResource(testconstants.ValidDid, resources[i].Metadata)
+	}
+	return list
+}
+
+func newLedgerService(resources int) unit.MockLedgerService {
+	return unit.NewMockLedgerService(
+		&testconstants.ValidDIDDoc,
+		[]*didTypes.Metadata{&testconstants.ValidMetadata},
+		generateResources(resources),
+	)
+}
+
+// newServer routes requests through the same handlers as the resolver, without logging and compression
+func newServer(ledgerService services.LedgerServiceI) *echo.Echo {
+	didService := services.NewDIDDocService(types.DID_METHOD, ledgerService)
+	resourceService := services.NewResourceService(types.DID_METHOD, ledgerService)
+
+	e := echo.New()
+	e.HTTPErrorHandler = services.CustomHTTPErrorHandler
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return next(services.ResolverContext{
+				Context:         c,
+				LedgerService:   ledgerService,
+				DidDocService:   didService,
+				ResourceService: resourceService,
+			})
+		}
+	})
+	didDocServices.SetRoutes(e)
+	resourceServices.SetRoutes(e)
+	return e
+}
+
+func serve(e *echo.Echo, didUrl string, accept string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, types.RESOLVER_PATH+strings.ReplaceAll(didUrl, "#", "%23"), nil)
+	request.Header.Set(echo.HeaderAccept, accept)
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
@@ -0,0 +1,79 @@
+//go:build unit
+
+package benchmark
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type handlerCase struct {
+	name   string
+	didUrl string
+	accept string
+	status int
+}
+
+func benchmarkHandler(b *testing.B, resources int, testCase handlerCase) {
+	e := newServer(newLedgerService(resources))
+	if recorder := serve(e, testCase.didUrl, testCase.accept); recorder.Code != testCase.status {
+		b.Fatalf("%s: expected status %d, got %d: %s", testCase.didUrl, testCase.status, recorder.Code, recorder.Body.String())
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		serve(e, testCase.didUrl, testCase.accept)
+	}
+}
+
+// BenchmarkResolveDIDDoc resolves the latest DID Document in every representation
+func BenchmarkResolveDIDDoc(b *testing.B) {
+	for _, accept := range []types.ContentType{types.DIDRES, types.DIDJSONLD, types.DIDJSON, types.JSONLD} {
+		b.Run(string(accept), func(b *testing.B) {
+			benchmarkHandler(b, 10, handlerCase{didUrl: testconstants.ValidDid, accept: string(accept), status: http.StatusOK})
+		})
+	}
+}
+
+// BenchmarkQueryDIDDocRequestService runs DID URLs with queries through the chain of query handlers
+func BenchmarkQueryDIDDocRequestService(b *testing.B) {
+	did := testconstants.ValidDid
+	cases := []handlerCase{
+		{name: "versionId", didUrl: did + "?versionId=" + testconstants.ValidVersionId, status: http.StatusOK},
+		{name: "transformKeys", didUrl: did + "?transformKeys=" + string(types.Ed25519VerificationKey2020), status: http.StatusOK},
+		{name: "service", didUrl: did + "?service=" + testconstants.ValidServiceId, status: http.StatusSeeOther},
+		{name: "resourceName", didUrl: did + "?resourceName=Resource%201&resourceType=JSONSchema2020", status: http.StatusOK},
+		{name: "resourceVersionTime", didUrl: did + "?resourceName=Resource%201&resourceType=JSONSchema2020&resourceVersionTime=2023-01-01T06:00:00Z", status: http.StatusOK},
+		{name: "resourceMetadata", didUrl: did + "?resourceType=JSONSchema2020&resourceMetadata=true", status: http.StatusOK},
+	}
+	for _, size := range collectionSizes {
+		for _, testCase := range cases {
+			b.Run(fmt.Sprintf("%s/resources=%d", testCase.name, size), func(b *testing.B) {
+				benchmarkHandler(b, size, testCase)
+			})
+		}
+	}
+}
+
+// BenchmarkDereferenceFragment dereferences a verification method of the DID Document
+func BenchmarkDereferenceFragment(b *testing.B) {
+	benchmarkHandler(b, 10, handlerCase{didUrl: testconstants.ValidDid + "#key-1", accept: string(types.JSONLD), status: http.StatusOK})
+}
+
+// BenchmarkDereferenceResource dereferences data and metadata of a resource
+func BenchmarkDereferenceResource(b *testing.B) {
+	resource := testconstants.ValidDid + types.RESOURCE_PATH + resourceId(7)
+	for _, size := range collectionSizes {
+		b.Run(fmt.Sprintf("data/resources=%d", size), func(b *testing.B) {
+			benchmarkHandler(b, size, handlerCase{didUrl: resource, accept: "*/*", status: http.StatusOK})
+		})
+		b.Run(fmt.Sprintf("metadata/resources=%d", size), func(b *testing.B) {
+			benchmarkHandler(b, size, handlerCase{didUrl: resource + "/metadata", status: http.StatusOK})
+		})
+	}
+}
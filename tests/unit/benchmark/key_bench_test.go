@@ -0,0 +1,79 @@
+//go:build unit
+
+package benchmark
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"testing"
+
+	"github.com/cheqd/did-resolver/utils"
+)
+
+var publicKey = ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+
+type encodedKeys struct {
+	base58    string
+	multibase string
+	jwk       interface{}
+}
+
+func mustEncodeKeys(b *testing.B) encodedKeys {
+	multibase, err := utils.GenerateEd25519VerificationKey2020(publicKey)
+	if err != nil {
+		b.Fatal(err)
+	}
+	key, err := utils.GenerateJSONWebKey2020(publicKey)
+	if err != nil {
+		b.Fatal(err)
+	}
+	// Keys in DID Documents are JSON objects rather than jwk.Key
+	data, err := json.Marshal(key)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var jwk interface{}
+	if err := json.Unmarshal(data, &jwk); err != nil {
+		b.Fatal(err)
+	}
+	return encodedKeys{base58: utils.GenerateEd25519VerificationKey2018(publicKey), multibase: multibase, jwk: jwk}
+}
+
+// BenchmarkTransformKeys converts verification keys between the types supported by transformKeys query
+func BenchmarkTransformKeys(b *testing.B) {
+	keys := mustEncodeKeys(b)
+	cases := []struct {
+		name      string
+		transform func() (interface{}, error)
+	}{
+		{"Ed25519VerificationKey2018ToEd25519VerificationKey2020", func() (interface{}, error) {
+			return utils.Ed25519VerificationKey2018ToEd25519VerificationKey2020(keys.base58)
+		}},
+		{"Ed25519VerificationKey2018ToJSONWebKey2020", func() (interface{}, error) {
+			return utils.Ed25519VerificationKey2018ToJSONWebKey2020(keys.base58)
+		}},
+		{"Ed25519VerificationKey2020ToEd25519VerificationKey2018", func() (interface{}, error) {
+			return utils.Ed25519VerificationKey2020ToEd25519VerificationKey2018(keys.multibase)
+		}},
+		{"Ed25519VerificationKey2020ToJSONWebKey2020", func() (interface{}, error) {
+			return utils.Ed25519VerificationKey2020ToJSONWebKey2020(keys.multibase)
+		}},
+		{"JSONWebKey2020ToEd25519VerificationKey2018", func() (interface{}, error) {
+			return utils.JSONWebKey2020ToEd25519VerificationKey2018(keys.jwk)
+		}},
+		{"JSONWebKey2020ToEd25519VerificationKey2020", func() (interface{}, error) {
+			return utils.JSONWebKey2020ToEd25519VerificationKey2020(keys.jwk)
+		}},
+	}
+	for _, testCase := range cases {
+		b.Run(testCase.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := testCase.transform(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
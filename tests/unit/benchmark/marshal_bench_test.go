@@ -0,0 +1,67 @@
+//go:build unit
+
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cheqd/did-resolver/services"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	"github.com/cheqd/did-resolver/types"
+)
+
+// BenchmarkMarshalDidResolution marshals resolution results, whose metadata lists linked resources
+func BenchmarkMarshalDidResolution(b *testing.B) {
+	for _, size := range collectionSizes {
+		b.Run(fmt.Sprintf("resources=%d", size), func(b *testing.B) {
+			didService := services.NewDIDDocService(types.DID_METHOD, newLedgerService(size))
+			resolution, err := didService.Resolve(testconstants.ValidDid, "", types.DIDRES)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				if _, err := json.Marshal(resolution); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkNewDidResolution converts the ledger DID Document and metadata to the resolution result
+func BenchmarkNewDidResolution(b *testing.B) {
+	for _, size := range collectionSizes {
+		b.Run(fmt.Sprintf("resources=%d", size), func(b *testing.B) {
+			ledgerService := newLedgerService(size)
+			didService := services.NewDIDDocService(types.DID_METHOD, ledgerService)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				if _, err := didService.Resolve(testconstants.ValidDid, "", types.DIDRES); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkMarshalDereferencedResourceList marshals linked resources of the collection
+func BenchmarkMarshalDereferencedResourceList(b *testing.B) {
+	for _, size := range collectionSizes {
+		resources := generateResourceList(size)
+		b.Run(fmt.Sprintf("resources=%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := json.Marshal(resources); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
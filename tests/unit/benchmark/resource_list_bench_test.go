@@ -0,0 +1,116 @@
+//go:build unit
+
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"testing"
+
+	"github.com/cheqd/did-resolver/types"
+)
+
+func benchmarkResourceList(b *testing.B, name string, run func(b *testing.B, resources types.DereferencedResourceList)) {
+	for _, size := range collectionSizes {
+		resources := generateResourceList(size)
+		b.Run(fmt.Sprintf("%s/resources=%d", name, size), func(b *testing.B) {
+			b.ReportAllocs()
+			run(b, resources)
+		})
+	}
+}
+
+func mustResourceFilter(b *testing.B, queries url.Values) types.ResourceFilter {
+	filter, err := types.NewResourceFilter(queries)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return *filter
+}
+
+func mustResourceListOptions(b *testing.B, queries url.Values) types.ResourceListOptions {
+	options, err := types.NewResourceListOptions(queries)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return *options
+}
+
+// BenchmarkDereferencedResourceListFilter filters collections the way resource queries do
+func BenchmarkDereferencedResourceListFilter(b *testing.B) {
+	benchmarkResourceList(b, "nameAndType", func(b *testing.B, resources types.DereferencedResourceList) {
+		for range b.N {
+			resources.FilterByResourceName("Resource 1").FilterByResourceType("JSONSchema2020")
+		}
+	})
+	benchmarkResourceList(b, "latestVersions", func(b *testing.B, resources types.DereferencedResourceList) {
+		for range b.N {
+			resources.FilterLatestVersions()
+		}
+	})
+	benchmarkResourceList(b, "resourceFilter", func(b *testing.B, resources types.DereferencedResourceList) {
+		filter := mustResourceFilter(b, url.Values{
+			types.ResourceType:   {"JSONSchema2020"},
+			types.CreatedAfterQ:  {"2023-01-01T01:00:00Z"},
+			types.CreatedBeforeQ: {"2023-01-02T00:00:00Z"},
+		})
+		b.ResetTimer()
+		for range b.N {
+			filter.Apply(resources)
+		}
+	})
+}
+
+// BenchmarkDereferencedResourceListSort sorts collections by creation and searches versions
+func BenchmarkDereferencedResourceListSort(b *testing.B) {
+	benchmarkResourceList(b, "byCreated", func(b *testing.B, resources types.DereferencedResourceList) {
+		unsorted := make(types.DereferencedResourceList, len(resources))
+		for range b.N {
+			copy(unsorted, resources)
+			sort.Sort(unsorted)
+		}
+	})
+	benchmarkResourceList(b, "getVersions", func(b *testing.B, resources types.DereferencedResourceList) {
+		for range b.N {
+			resources.GetVersions(resourceId(1))
+		}
+	})
+	benchmarkResourceList(b, "findBeforeTime", func(b *testing.B, resources types.DereferencedResourceList) {
+		versions := resources.FilterByResourceName("Resource 1")
+		for range b.N {
+			if _, err := versions.FindBeforeTime("2023-01-01T06:00:00Z"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkResourceListOptions paginates, sorts, selects fields and summarizes collections
+func BenchmarkResourceListOptions(b *testing.B) {
+	cases := []struct {
+		name    string
+		queries url.Values
+	}{
+		{name: "sortByName", queries: url.Values{types.ResourceSortQ: {types.ResourceSortName}}},
+		{name: "page", queries: url.Values{types.ResourceSortQ: {"-" + types.ResourceSortCreated}, types.ResourceLimitQ: {"50"}}},
+		{name: "fields", queries: url.Values{types.ResourceFieldsQ: {"resourceId,resourceName"}}},
+		{name: "summary", queries: url.Values{types.ResourceMetadata: {types.ResourceMetadataSummary}}},
+	}
+	for _, testCase := range cases {
+		benchmarkResourceList(b, testCase.name, func(b *testing.B, resources types.DereferencedResourceList) {
+			options := mustResourceListOptions(b, testCase.queries)
+			b.ResetTimer()
+			for range b.N {
+				page, _, _, err := options.Apply(resources)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := json.Marshal(page); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
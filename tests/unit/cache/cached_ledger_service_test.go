@@ -0,0 +1,134 @@
+//go:build unit
+
+package cache_test
+
+import (
+	"time"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/tests/fakenode"
+	"github.com/cheqd/did-resolver/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	fixtureDid        = "did:cheqd:testnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0"
+	fixtureVersionId1 = "0ce23d04-5b67-4ea6-a315-788588e53f4e"
+	fixtureVersionId2 = "cbcd7b9b-bc3e-4ccc-a7c4-1d8c4d7a2a5c"
+	// The first version of PersonSchema, superseded by the second one
+	fixtureSchemaId1 = "9ba3922e-d5f5-4f53-b265-fc0d4e988c77"
+	fixtureNoteId    = "5e16a3f9-7c6e-40b4-89cc-f0a8c7b3e2d1"
+)
+
+// countingLedgerService counts queries of objects and marks all versions deactivated once the DID is deactivated, like cheqd-node
+type countingLedgerService struct {
+	services.LedgerServiceI
+	didDocQueries   int
+	resourceQueries int
+	deactivated     bool
+}
+
+func (cls *countingLedgerService) QueryDIDDoc(did string, version string) (*didTypes.DidDocWithMetadata, *types.IdentityError) {
+	cls.didDocQueries++
+	didDoc, err := cls.LedgerServiceI.QueryDIDDoc(did, version)
+	if err == nil {
+		didDoc.Metadata.Deactivated = cls.deactivated
+	}
+	return didDoc, err
+}
+
+func (cls *countingLedgerService) QueryAllDidDocVersionsMetadata(did string) ([]*didTypes.Metadata, *types.IdentityError) {
+	versions, err := cls.LedgerServiceI.QueryAllDidDocVersionsMetadata(did)
+	for _, version := range versions {
+		version.Deactivated = cls.deactivated
+	}
+	return versions, err
+}
+
+func (cls *countingLedgerService) QueryResource(did string, resourceId string) (*resourceTypes.ResourceWithMetadata, *types.IdentityError) {
+	cls.resourceQueries++
+	return cls.LedgerServiceI.QueryResource(did, resourceId)
+}
+
+var _ = Describe("CachedLedgerService", func() {
+	var (
+		dir           string
+		ledgerService *countingLedgerService
+	)
+
+	newCachedLedgerService := func() services.CachedLedgerService {
+		cache, err := services.NewDiskCache(dir, 1<<20)
+		Expect(err).To(BeNil())
+		return services.NewCachedLedgerService(ledgerService, cache)
+	}
+
+	BeforeEach(func() {
+		node, err := fakenode.Start(fakenode.DefaultFixtures())
+		Expect(err).To(BeNil())
+		DeferCleanup(node.Stop)
+		dir = GinkgoT().TempDir()
+
+		network := types.Network{
+			Namespace: "testnet",
+			Endpoints: []types.Endpoint{{URL: node.Address(), Timeout: 5 * time.Second, Role: types.EndpointRolePrimary}},
+		}
+		networkLedgerService := services.NewLedgerService(services.NewEndpointManager(types.Config{Networks: []types.Network{network}}))
+		Expect(networkLedgerService.RegisterLedger(types.DID_METHOD, network)).To(Succeed())
+		ledgerService = &countingLedgerService{LedgerServiceI: networkLedgerService}
+	})
+
+	It("serves DID Documents of versions from the cache after restart", func() {
+		for _, versionId := range []string{fixtureVersionId1, fixtureVersionId2} {
+			for range 2 {
+				didDoc, err := newCachedLedgerService().QueryDIDDoc(fixtureDid, versionId)
+				Expect(err).To(BeNil())
+				Expect(didDoc.Metadata.VersionId).To(Equal(versionId))
+			}
+		}
+		Expect(ledgerService.didDocQueries).To(Equal(2))
+	})
+
+	It("serves the current metadata of cached versions", func() {
+		cachedLedgerService := newCachedLedgerService()
+		didDoc, err := cachedLedgerService.QueryDIDDoc(fixtureDid, fixtureVersionId1)
+		Expect(err).To(BeNil())
+		Expect(didDoc.Metadata.Deactivated).To(BeFalse())
+
+		ledgerService.deactivated = true
+		didDoc, err = cachedLedgerService.QueryDIDDoc(fixtureDid, fixtureVersionId1)
+		Expect(err).To(BeNil())
+		Expect(didDoc.Metadata.Deactivated).To(BeTrue())
+		Expect(didDoc.Metadata.NextVersionId).To(Equal(fixtureVersionId2))
+		Expect(ledgerService.didDocQueries).To(Equal(1))
+	})
+
+	It("always queries the latest version of DID Document without versionId", func() {
+		cachedLedgerService := newCachedLedgerService()
+		for range 2 {
+			didDoc, err := cachedLedgerService.QueryDIDDoc(fixtureDid, "")
+			Expect(err).To(BeNil())
+			Expect(didDoc.Metadata.VersionId).To(Equal(fixtureVersionId2))
+		}
+		Expect(ledgerService.didDocQueries).To(Equal(2))
+	})
+
+	It("serves data of all versions of resources from the cache", func() {
+		cachedLedgerService := newCachedLedgerService()
+		for range 2 {
+			schema, err := cachedLedgerService.QueryResource(fixtureDid, fixtureSchemaId1)
+			Expect(err).To(BeNil())
+			Expect(schema.Metadata.Id).To(Equal(fixtureSchemaId1))
+			Expect(schema.Metadata.NextVersionId).ToNot(BeEmpty())
+
+			// The latest version
+			note, err := cachedLedgerService.QueryResource(fixtureDid, fixtureNoteId)
+			Expect(err).To(BeNil())
+			Expect(string(note.Resource.Data)).To(Equal("Hello, cheqd!"))
+			Expect(note.Metadata.Id).To(Equal(fixtureNoteId))
+		}
+		Expect(ledgerService.resourceQueries).To(Equal(2))
+	})
+})
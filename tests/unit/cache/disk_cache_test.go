@@ -0,0 +1,162 @@
+//go:build unit
+
+package cache_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cheqd/did-resolver/services"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// Each entry takes the value and its SHA-256 checksum on disk
+const entrySize = 100 + 32
+
+func value(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 100)
+}
+
+var _ = Describe("DiskCache", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("returns stored values", func() {
+		cache, err := services.NewDiskCache(dir, 10*entrySize)
+		Expect(err).To(BeNil())
+		Expect(cache.Put("a", value('a'))).To(Succeed())
+
+		data, ok := cache.Get("a")
+		Expect(ok).To(BeTrue())
+		Expect(data).To(Equal(value('a')))
+
+		_, ok = cache.Get("b")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("keeps values after restart", func() {
+		cache, err := services.NewDiskCache(dir, 10*entrySize)
+		Expect(err).To(BeNil())
+		Expect(cache.Put("a", value('a'))).To(Succeed())
+
+		reopened, err := services.NewDiskCache(dir, 10*entrySize)
+		Expect(err).To(BeNil())
+		Expect(reopened.Len()).To(Equal(1))
+		Expect(reopened.Size()).To(Equal(int64(entrySize)))
+		data, ok := reopened.Get("a")
+		Expect(ok).To(BeTrue())
+		Expect(data).To(Equal(value('a')))
+	})
+
+	It("evicts the least recently used values above the size limit", func() {
+		cache, err := services.NewDiskCache(dir, 3*entrySize)
+		Expect(err).To(BeNil())
+		Expect(cache.Put("a", value('a'))).To(Succeed())
+		Expect(cache.Put("b", value('b'))).To(Succeed())
+		Expect(cache.Put("c", value('c'))).To(Succeed())
+		_, ok := cache.Get("a")
+		Expect(ok).To(BeTrue())
+
+		Expect(cache.Put("d", value('d'))).To(Succeed())
+		Expect(cache.Len()).To(Equal(3))
+		_, ok = cache.Get("b")
+		Expect(ok).To(BeFalse())
+		for _, key := range []string{"a", "c", "d"} {
+			_, ok = cache.Get(key)
+			Expect(ok).To(BeTrue())
+		}
+	})
+
+	It("restores the order of values after restart", func() {
+		cache, err := services.NewDiskCache(dir, 3*entrySize)
+		Expect(err).To(BeNil())
+		Expect(cache.Put("a", value('a'))).To(Succeed())
+		time.Sleep(10 * time.Millisecond)
+		Expect(cache.Put("b", value('b'))).To(Succeed())
+		time.Sleep(10 * time.Millisecond)
+		_, ok := cache.Get("a")
+		Expect(ok).To(BeTrue())
+
+		reopened, err := services.NewDiskCache(dir, entrySize)
+		Expect(err).To(BeNil())
+		Expect(reopened.Len()).To(Equal(1))
+		_, ok = reopened.Get("a")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("skips values larger than the size limit", func() {
+		cache, err := services.NewDiskCache(dir, entrySize-1)
+		Expect(err).To(BeNil())
+		Expect(cache.Put("a", value('a'))).To(Succeed())
+		Expect(cache.Len()).To(Equal(0))
+	})
+
+	It("drops corrupted values", func() {
+		cache, err := services.NewDiskCache(dir, 10*entrySize)
+		Expect(err).To(BeNil())
+		Expect(cache.Put("a", value('a'))).To(Succeed())
+
+		files, err := os.ReadDir(dir)
+		Expect(err).To(BeNil())
+		Expect(files).To(HaveLen(1))
+		path := filepath.Join(dir, files[0].Name())
+		data, err := os.ReadFile(path)
+		Expect(err).To(BeNil())
+		data[len(data)-1] = 'x'
+		Expect(os.WriteFile(path, data, 0o644)).To(Succeed())
+
+		_, ok := cache.Get("a")
+		Expect(ok).To(BeFalse())
+		Expect(cache.Len()).To(Equal(0))
+		Expect(path).ToNot(BeAnExistingFile())
+	})
+
+	It("leaves files of others in the directory", func() {
+		others := []string{"notes.txt", "upload.123.tmp", strings.Repeat("A", 64)}
+		for _, name := range others {
+			Expect(os.WriteFile(filepath.Join(dir, name), value('x'), 0o644)).To(Succeed())
+		}
+
+		cache, err := services.NewDiskCache(dir, entrySize)
+		Expect(err).To(BeNil())
+		Expect(cache.Len()).To(Equal(0))
+		Expect(cache.Put("a", value('a'))).To(Succeed())
+		Expect(cache.Put("b", value('b'))).To(Succeed())
+		Expect(cache.Flush()).To(Equal(1))
+
+		for _, name := range others {
+			Expect(filepath.Join(dir, name)).To(BeAnExistingFile())
+		}
+	})
+
+	It("serves concurrent reads and writes", func() {
+		cache, err := services.NewDiskCache(dir, 5*entrySize)
+		Expect(err).To(BeNil())
+
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for j := range 50 {
+					key := string(rune('a' + (i+j)%10))
+					Expect(cache.Put(key, value(key[0]))).To(Succeed())
+					if data, ok := cache.Get(key); ok {
+						Expect(data).To(Equal(value(key[0])))
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		Expect(cache.Size()).To(BeNumerically("<=", 5*entrySize))
+	})
+})
@@ -0,0 +1,103 @@
+//go:build unit
+
+package config_test
+
+import (
+	"errors"
+
+	"github.com/cheqd/did-resolver/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewConfig", func() {
+	validRawConfig := func() types.RawConfig {
+		return types.RawConfig{
+			MainnetEndpoint:  "grpc.cheqd.net:443,true,5s",
+			TestnetEndpoint:  "grpc.cheqd.network:443,true,5s",
+			ResolverListener: "0.0.0.0:8080",
+			LogLevel:         "warn",
+			CacheMaxSizeMB:   256,
+		}
+	}
+
+	It("creates networks of both namespaces", func() {
+		config, err := types.NewConfig(validRawConfig())
+		Expect(err).To(BeNil())
+		Expect(config.Networks).To(HaveLen(2))
+		Expect(config.Networks[0].Namespace).To(Equal("mainnet"))
+		Expect(config.Networks[1].Endpoints[0].Role).To(Equal(types.EndpointRolePrimary))
+	})
+
+	It("reports every problem", func() {
+		rawConfig := validRawConfig()
+		rawConfig.LogLevel = "loud"
+		rawConfig.MainnetEndpoint = "grpc.cheqd.net:443"
+		rawConfig.EnableFallbackEndpoints = true
+		rawConfig.MainnetEndpointFallback = "grpc.cheqd.net:443,true,5s"
+		rawConfig.CacheDir = "/var/cache/resolver"
+		rawConfig.CacheMaxSizeMB = 0
+
+		_, err := types.NewConfig(rawConfig)
+		var joined interface{ Unwrap() []error }
+		Expect(errors.As(err, &joined)).To(BeTrue())
+		Expect(joined.Unwrap()).To(HaveLen(4))
+		Expect(err.Error()).To(ContainSubstring("invalid LOG_LEVEL"))
+		Expect(err.Error()).To(ContainSubstring("CACHE_MAX_SIZE_MB must be positive"))
+		Expect(err.Error()).To(ContainSubstring("invalid mainnet endpoint"))
+		Expect(err.Error()).To(ContainSubstring("TESTNET_ENDPOINT_FALLBACK is not configured"))
+	})
+
+	It("skips namespaces without endpoint if the snapshot is configured", func() {
+		rawConfig := validRawConfig()
+		rawConfig.MainnetEndpoint = ""
+		rawConfig.SnapshotPath = "snapshot.tar.gz"
+
+		config, err := types.NewConfig(rawConfig)
+		Expect(err).To(BeNil())
+		Expect(config.Networks).To(HaveLen(1))
+		Expect(config.Networks[0].Namespace).To(Equal("testnet"))
+	})
+
+	It("requires endpoints without the snapshot", func() {
+		rawConfig := validRawConfig()
+		rawConfig.MainnetEndpoint = ""
+
+		_, err := types.NewConfig(rawConfig)
+		Expect(err).To(MatchError(ContainSubstring("invalid mainnet endpoint")))
+	})
+	It("doesn't require endpoints if the ledger recording is replayed", func() {
+		rawConfig := validRawConfig()
+		rawConfig.MainnetEndpoint = ""
+		rawConfig.TestnetEndpoint = ""
+		rawConfig.LedgerReplayPath = "incident.jsonl"
+
+		config, err := types.NewConfig(rawConfig)
+		Expect(err).To(BeNil())
+		Expect(config.Networks).To(BeEmpty())
+		Expect(config.LedgerReplayPath).To(Equal("incident.jsonl"))
+	})
+
+	It("can't record and replay the ledger at the same time", func() {
+		rawConfig := validRawConfig()
+		rawConfig.LedgerRecordPath = "incident.jsonl"
+		rawConfig.LedgerReplayPath = "incident.jsonl"
+
+		_, err := types.NewConfig(rawConfig)
+		Expect(err).To(MatchError(ContainSubstring("LEDGER_RECORD_PATH and LEDGER_REPLAY_PATH")))
+	})
+
+	It("requires the token to enable the admin API and doesn't print it", func() {
+		rawConfig := validRawConfig()
+		rawConfig.AdminListener = "127.0.0.1:8081"
+
+		_, err := types.NewConfig(rawConfig)
+		Expect(err).To(MatchError(ContainSubstring("ADMIN_TOKEN is required")))
+
+		rawConfig.AdminToken = "secret-admin-token"
+		config, err := types.NewConfig(rawConfig)
+		Expect(err).To(BeNil())
+		Expect(config.AdminToken).To(Equal("secret-admin-token"))
+		Expect(config.MustMarshalJson()).NotTo(ContainSubstring("secret-admin-token"))
+	})
+})
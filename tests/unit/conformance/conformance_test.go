@@ -0,0 +1,246 @@
+//go:build unit
+
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cheqd/did-resolver/types"
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/ginkgo/v2"
+	ginkgoTypes "github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// SpecAssertion is a normative statement of DID Resolution or DID URL Dereferencing specification
+type SpecAssertion struct {
+	Id        string `json:"id"`
+	Section   string `json:"section"`
+	Statement string `json:"statement"`
+}
+
+// Vector is a request to the resolver with the expected response
+type Vector struct {
+	Id         string   `json:"id"`
+	Assertions []string `json:"assertions"`
+	Input      string   `json:"input"`
+	Accept     string   `json:"accept,omitempty"`
+	Expect     Expected `json:"expect"`
+}
+
+type Expected struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	// Error of didResolutionMetadata or dereferencingMetadata
+	Error string `json:"error,omitempty"`
+	// Top-level members of JSON response
+	Members  []string `json:"members,omitempty"`
+	Location string   `json:"location,omitempty"`
+}
+
+type vectorsFile struct {
+	Assertions []SpecAssertion `json:"assertions"`
+	Vectors    []Vector        `json:"vectors"`
+}
+
+// Golden is the normalized response to a vector, which catches changes of the response shape
+type Golden struct {
+	Status      int             `json:"status"`
+	ContentType string          `json:"contentType"`
+	Location    string          `json:"location,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+	Text        string          `json:"text,omitempty"`
+}
+
+var vectors = mustLoadVectors("testdata/vectors.json")
+
+func mustLoadVectors(path string) vectorsFile {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		panic(err)
+	}
+	var file vectorsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		panic(err)
+	}
+	return file
+}
+
+func dereference(vector Vector) *httptest.ResponseRecorder {
+	// Fragment is a part of the DID URL for the resolver, so it's escaped to reach the handlers
+	target := types.RESOLVER_PATH + strings.ReplaceAll(vector.Input, "#", "%23")
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	if vector.Accept != "" {
+		request.Header.Set(echo.HeaderAccept, vector.Accept)
+	}
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// resolutionError returns the error of resolution or dereferencing metadata
+func resolutionError(body map[string]any) string {
+	for _, key := range []string{"didResolutionMetadata", "dereferencingMetadata"} {
+		if metadata, ok := body[key].(map[string]any); ok {
+			if err, ok := metadata["error"].(string); ok {
+				return err
+			}
+		}
+	}
+	return ""
+}
+
+// normalize removes the time of retrieval, which differs on each run
+func normalize(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		delete(v, "retrieved")
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+	}
+	return value
+}
+
+func golden(recorder *httptest.ResponseRecorder) Golden {
+	result := Golden{
+		Status:      recorder.Code,
+		ContentType: recorder.Header().Get(echo.HeaderContentType),
+		Location:    recorder.Header().Get(echo.HeaderLocation),
+	}
+	var body any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err == nil {
+		data, err := json.MarshalIndent(normalize(body), "  ", "  ")
+		Expect(err).To(BeNil())
+		result.Body = data
+	} else {
+		result.Text = recorder.Body.String()
+	}
+	return result
+}
+
+func goldenPath(vector Vector) string {
+	return filepath.Join("testdata", "golden", vector.Id+".json")
+}
+
+var _ = Describe("DID Resolution and DID URL Dereferencing", func() {
+	entries := make([]TableEntry, 0, len(vectors.Vectors))
+	for _, vector := range vectors.Vectors {
+		entries = append(entries, Entry(vector.Id, vector))
+	}
+
+	DescribeTable("test vector", func(vector Vector) {
+		recorder := dereference(vector)
+
+		Expect(recorder.Code).To(Equal(vector.Expect.Status), recorder.Body.String())
+		if vector.Expect.ContentType != "" {
+			Expect(recorder.Header().Get(echo.HeaderContentType)).To(Equal(vector.Expect.ContentType))
+		}
+		if vector.Expect.Location != "" {
+			Expect(recorder.Header().Get(echo.HeaderLocation)).To(Equal(vector.Expect.Location))
+		}
+		if vector.Expect.Error != "" || len(vector.Expect.Members) != 0 {
+			var body map[string]any
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &body)).To(Succeed())
+			Expect(resolutionError(body)).To(Equal(vector.Expect.Error))
+			for _, member := range vector.Expect.Members {
+				Expect(body).To(HaveKey(member))
+			}
+		}
+
+		actual := golden(recorder)
+		if *updateGolden {
+			data, err := json.MarshalIndent(actual, "", "  ")
+			Expect(err).To(BeNil())
+			Expect(os.WriteFile(goldenPath(vector), append(data, '\n'), 0o644)).To(Succeed())
+			return
+		}
+		data, err := os.ReadFile(goldenPath(vector))
+		Expect(err).To(BeNil(), "golden response is missing, run with -update-golden")
+		var expected Golden
+		Expect(json.Unmarshal(data, &expected)).To(Succeed())
+		Expect(actual.Status).To(Equal(expected.Status))
+		Expect(actual.ContentType).To(Equal(expected.ContentType))
+		Expect(actual.Location).To(Equal(expected.Location))
+		Expect(actual.Text).To(Equal(expected.Text))
+		if expected.Body != nil {
+			Expect(string(actual.Body)).To(MatchJSON(expected.Body))
+		}
+	}, entries)
+})
+
+// ConformanceReport tells which assertions pass: an assertion passes if all of its vectors pass
+type ConformanceReport struct {
+	Generated  time.Time          `json:"generated"`
+	Passed     int                `json:"passed"`
+	Failed     int                `json:"failed"`
+	Assertions []AssertionOutcome `json:"assertions"`
+}
+
+type AssertionOutcome struct {
+	SpecAssertion
+	Passed  bool            `json:"passed"`
+	Vectors []VectorOutcome `json:"vectors"`
+}
+
+type VectorOutcome struct {
+	Id      string `json:"id"`
+	Passed  bool   `json:"passed"`
+	Failure string `json:"failure,omitempty"`
+}
+
+func writeReport(path string, suiteReport Report) error {
+	outcomes := make(map[string]VectorOutcome)
+	for _, spec := range suiteReport.SpecReports {
+		if spec.LeafNodeType != ginkgoTypes.NodeTypeIt {
+			continue
+		}
+		outcomes[spec.LeafNodeText] = VectorOutcome{
+			Id:      spec.LeafNodeText,
+			Passed:  spec.State.Is(ginkgoTypes.SpecStatePassed),
+			Failure: spec.FailureMessage(),
+		}
+	}
+
+	report := ConformanceReport{Generated: time.Now().UTC()}
+	for _, assertion := range vectors.Assertions {
+		outcome := AssertionOutcome{SpecAssertion: assertion, Passed: true}
+		for _, vector := range vectors.Vectors {
+			if !slices.Contains(vector.Assertions, assertion.Id) {
+				continue
+			}
+			vectorOutcome, ok := outcomes[vector.Id]
+			if !ok {
+				vectorOutcome = VectorOutcome{Id: vector.Id, Failure: "not run"}
+			}
+			outcome.Vectors = append(outcome.Vectors, vectorOutcome)
+			outcome.Passed = outcome.Passed && vectorOutcome.Passed
+		}
+		if outcome.Passed && len(outcome.Vectors) != 0 {
+			report.Passed++
+		} else {
+			outcome.Passed = false
+			report.Failed++
+		}
+		report.Assertions = append(report.Assertions, outcome)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
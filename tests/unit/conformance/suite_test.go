@@ -0,0 +1,67 @@
+//go:build unit
+
+package conformance
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/cheqd/did-resolver/services"
+	didDocServices "github.com/cheqd/did-resolver/services/diddoc"
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	"github.com/cheqd/did-resolver/tests/fakenode"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var (
+	reportPath   = flag.String("conformance-report", "", "path of the JSON report of spec assertions")
+	updateGolden = flag.Bool("update-golden", false, "rewrite golden responses with the actual ones")
+)
+
+// server routes requests the same way as the resolver, with the fake node as the ledger
+var server *echo.Echo
+
+func TestConformance(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "[Unit Test]: W3C DID Resolution conformance")
+}
+
+var _ = BeforeSuite(func() {
+	node, err := fakenode.Start(fakenode.DefaultFixtures())
+	Expect(err).To(BeNil())
+	DeferCleanup(node.Stop)
+
+	network := types.Network{
+		Namespace: "testnet",
+		Endpoints: []types.Endpoint{{URL: node.Address(), Timeout: defaultTimeout, Role: types.EndpointRolePrimary}},
+	}
+	ledgerService := services.NewLedgerService(services.NewEndpointManager(types.Config{Networks: []types.Network{network}}))
+	Expect(ledgerService.RegisterLedger(types.DID_METHOD, network)).To(Succeed())
+	didService := services.NewDIDDocService(types.DID_METHOD, ledgerService)
+	resourceService := services.NewResourceService(types.DID_METHOD, ledgerService)
+
+	server = echo.New()
+	server.HTTPErrorHandler = services.CustomHTTPErrorHandler
+	server.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return next(services.ResolverContext{
+				Context:         c,
+				LedgerService:   ledgerService,
+				DidDocService:   didService,
+				ResourceService: resourceService,
+			})
+		}
+	})
+	didDocServices.SetRoutes(server)
+	resourceServices.SetRoutes(server)
+})
+
+var _ = ReportAfterSuite("conformance report", func(report Report) {
+	if *reportPath == "" {
+		return
+	}
+	Expect(writeReport(*reportPath, report)).To(Succeed())
+})
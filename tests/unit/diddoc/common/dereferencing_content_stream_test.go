@@ -74,6 +74,19 @@ var _ = Describe("DereferencingContentStream Find before time", func() {
 		})
 	})
 
+	Context("FindNextVersionTime", func() {
+		It("should return creation time of the next resource", func() {
+			Expect(versionList.FindNextVersionTime("r1")).To(Equal(&t2))
+			Expect(versionList.FindNextVersionTime("r2")).To(Equal(&t3))
+		})
+		It("should return nil for the latest resource", func() {
+			Expect(versionList.FindNextVersionTime("r3")).To(BeNil())
+		})
+		It("should return nil for not existent resource", func() {
+			Expect(versionList.FindNextVersionTime("r4")).To(BeNil())
+		})
+	})
+
 	Context("FindAllBeforeTime", func() {
 		It("should return 1 resource before the given time", func() {
 			resources, err := versionList.FindAllBeforeTime(t1_2.Format(time.RFC3339))
@@ -201,6 +214,34 @@ var _ = Describe("DereferencingContentStream Find before time", func() {
 			Expect(len(resources)).To(Equal(0))
 		})
 	})
+	Context("FilterLatestVersions", func() {
+		It("should return the newest resource of every name and type", func() {
+			resources := versionList.FilterLatestVersions()
+			Expect(len(resources)).To(Equal(2))
+			Expect(resources[0].ResourceId).To(Equal("r1"))
+			Expect(resources[1].ResourceId).To(Equal("r3"))
+		})
+	})
+	Context("FilterFirstVersions", func() {
+		It("should return the oldest resource of every name and type", func() {
+			resources := versionList.FilterFirstVersions()
+			Expect(len(resources)).To(Equal(2))
+			Expect(resources[0].ResourceId).To(Equal("r1"))
+			Expect(resources[1].ResourceId).To(Equal("r2"))
+		})
+	})
+	Context("GetVersions", func() {
+		It("should return versions of the resource from the oldest to the newest", func() {
+			resources := versionList.GetVersions("r3")
+			Expect(len(resources)).To(Equal(2))
+			Expect(resources[0].ResourceId).To(Equal("r2"))
+			Expect(resources[1].ResourceId).To(Equal("r3"))
+		})
+		It("should return empty list cause resourceId is not placed", func() {
+			resources := versionList.GetVersions("r0")
+			Expect(len(resources)).To(Equal(0))
+		})
+	})
 	Context("AreResourceNamesTheSame", func() {
 		It("should return true", func() {
 			versionList[0].Name = "name2"
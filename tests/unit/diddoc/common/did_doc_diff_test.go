@@ -0,0 +1,126 @@
+//go:build unit
+
+package common
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cheqd/did-resolver/types"
+)
+
+func mustUnmarshalJson(data string) interface{} {
+	var result interface{}
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		panic(err)
+	}
+	return result
+}
+
+var _ = DescribeTable("Test NewJsonPatch function", func(from string, to string, expectedPatch types.JsonPatch) {
+	patch := types.NewJsonPatch(mustUnmarshalJson(from), mustUnmarshalJson(to))
+	Expect(patch).To(Equal(expectedPatch))
+},
+
+	Entry(
+		"equal documents produce an empty patch",
+		`{"id":"did:cheqd:testnet:1","controller":["did:cheqd:testnet:1"]}`,
+		`{"id":"did:cheqd:testnet:1","controller":["did:cheqd:testnet:1"]}`,
+		types.JsonPatch{},
+	),
+
+	Entry(
+		"added, removed and replaced fields",
+		`{"a":"1","b":"2"}`,
+		`{"b":"3","c":false}`,
+		types.JsonPatch{
+			{Op: types.JsonPatchRemove, Path: "/a"},
+			{Op: types.JsonPatchReplace, Path: "/b", Value: "3"},
+			{Op: types.JsonPatchAdd, Path: "/c", Value: false},
+		},
+	),
+
+	Entry(
+		"array elements are removed from the tail",
+		`{"a":["1","2","3"]}`,
+		`{"a":["0"]}`,
+		types.JsonPatch{
+			{Op: types.JsonPatchReplace, Path: "/a/0", Value: "0"},
+			{Op: types.JsonPatchRemove, Path: "/a/2"},
+			{Op: types.JsonPatchRemove, Path: "/a/1"},
+		},
+	),
+
+	Entry(
+		"array elements are appended",
+		`{"a":[{"id":"1"}]}`,
+		`{"a":[{"id":"1"},{"id":"2"}]}`,
+		types.JsonPatch{
+			{Op: types.JsonPatchAdd, Path: "/a/1", Value: map[string]interface{}{"id": "2"}},
+		},
+	),
+
+	Entry(
+		"keys are escaped according to JSON Pointer",
+		`{"a/b":"1","c~d":"1"}`,
+		`{"a/b":"2","c~d":"2"}`,
+		types.JsonPatch{
+			{Op: types.JsonPatchReplace, Path: "/a~1b", Value: "2"},
+			{Op: types.JsonPatchReplace, Path: "/c~0d", Value: "2"},
+		},
+	),
+)
+
+var _ = Describe("Test NewDidDocDiffSummary function", func() {
+	did := "did:cheqd:testnet:b5d70adf-31ca-4662-aa10-d3a54cd8f06c"
+
+	It("reports rotated keys, changed services and controllers", func() {
+		from := types.DidDoc{
+			Id:         did,
+			Controller: []string{did},
+			VerificationMethod: []types.VerificationMethod{
+				{Id: did + "#key-1", Type: "Ed25519VerificationKey2018", PublicKeyBase58: "key-1"},
+				{Id: did + "#key-2", Type: "Ed25519VerificationKey2018", PublicKeyBase58: "key-2"},
+			},
+			Service: []types.Service{
+				{Id: did + "#service-1", Type: types.LinkedDomains, ServiceEndpoint: []string{"https://example.com"}},
+				{Id: did + "#service-2", Type: types.LinkedDomains, ServiceEndpoint: []string{"https://example.org"}},
+			},
+		}
+		to := types.DidDoc{
+			Id:         did,
+			Controller: []string{"did:cheqd:testnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0"},
+			VerificationMethod: []types.VerificationMethod{
+				{Id: did + "#key-2", Type: "Ed25519VerificationKey2018", PublicKeyBase58: "key-2-rotated"},
+				{Id: did + "#key-3", Type: "Ed25519VerificationKey2018", PublicKeyBase58: "key-3"},
+			},
+			Service: []types.Service{
+				{Id: did + "#service-1", Type: types.LinkedDomains, ServiceEndpoint: []string{"https://example.net"}},
+			},
+		}
+
+		summary := types.NewDidDocDiffSummary(from, to)
+		Expect(summary.KeysAdded).To(Equal([]string{did + "#key-3"}))
+		Expect(summary.KeysRemoved).To(Equal([]string{did + "#key-1"}))
+		Expect(summary.KeysChanged).To(Equal([]string{did + "#key-2"}))
+		Expect(summary.ServicesAdded).To(BeEmpty())
+		Expect(summary.ServicesRemoved).To(Equal([]string{did + "#service-2"}))
+		Expect(summary.ServicesChanged).To(Equal([]string{did + "#service-1"}))
+		Expect(summary.ControllersAdded).To(Equal([]string{"did:cheqd:testnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0"}))
+		Expect(summary.ControllersRemoved).To(Equal([]string{did}))
+	})
+
+	It("returns empty lists for equal documents", func() {
+		doc := types.DidDoc{Id: did, Controller: []string{did}}
+
+		summary := types.NewDidDocDiffSummary(doc, doc)
+		Expect(summary.KeysAdded).To(BeEmpty())
+		Expect(summary.KeysRemoved).To(BeEmpty())
+		Expect(summary.KeysChanged).To(BeEmpty())
+		Expect(summary.ServicesChanged).To(BeEmpty())
+		Expect(summary.ControllersAdded).To(BeEmpty())
+		Expect(summary.ControllersRemoved).To(BeEmpty())
+	})
+})
@@ -0,0 +1,67 @@
+//go:build unit
+
+package common
+
+import (
+	"sort"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewDidDocHistory", func() {
+	var (
+		did         = "did:cheqd:testnet:b5d70adf-31ca-4662-aa10-d3a54cd8f06c"
+		tcreated    = utils.MustParseDate("2021-08-23T09:00:00Z")
+		t1          = utils.MustParseDate("2021-08-23T09:30:00Z")
+		t1_2        = utils.MustParseDate("2021-08-23T09:30:01Z")
+		versionList types.DidDocMetadataList
+		didDocs     map[string]*didTypes.DidDoc
+	)
+
+	BeforeEach(func() {
+		resources := []types.DereferencedResource{{Created: &t1_2}}
+		versionList = types.DidDocMetadataList{
+			{VersionId: "1", Created: &tcreated, Resources: resources},
+			{VersionId: "2", Created: &tcreated, Updated: &t1, Resources: resources},
+		}
+		sort.Sort(versionList)
+
+		didDocs = map[string]*didTypes.DidDoc{
+			"1": {Id: did},
+			"2": {Id: did, Controller: []string{did}},
+		}
+	})
+
+	entries := func(history *types.DidDocHistory) []types.DidDocHistoryEntry {
+		var entries []types.DidDocHistoryEntry
+		for i, version := range history.Versions {
+			entries = append(entries, history.NewEntry(i, didDocs[version.VersionId]))
+		}
+		return entries
+	}
+
+	It("should return versions in chronological order with resources before the next version", func() {
+		versions := entries(types.NewDidDocHistory(versionList, types.DIDJSON))
+
+		Expect(versions).To(HaveLen(2))
+		Expect(versions[0].Metadata.VersionId).To(Equal("1"))
+		Expect(versions[0].Did.Controller).To(BeEmpty())
+		Expect(versions[0].Metadata.Resources).To(BeEmpty())
+		Expect(versions[1].Metadata.VersionId).To(Equal("2"))
+		Expect(versions[1].Did.Controller).To(Equal([]string{did}))
+		Expect(versions[1].Metadata.Resources).To(HaveLen(1))
+		Expect(versions[1].Context).To(BeEmpty())
+		Expect(versions[1].Did.Context).To(BeEmpty())
+	})
+
+	It("should add JSON-LD contexts for JSON-LD content types", func() {
+		for _, version := range entries(types.NewDidDocHistory(versionList, types.DIDJSONLD)) {
+			Expect(version.Context).To(Equal(types.ResolutionSchemaJSONLD))
+			Expect(version.Did.Context).To(Equal([]string{types.DIDSchemaJSONLD}))
+		}
+	})
+})
@@ -128,4 +128,57 @@ var _ = Describe("DidDocMetadataList", func() {
 			}))
 		})
 	})
+
+	Context("GetVersionWindow", func() {
+		It("should return the interval until the next version", func() {
+			validFrom, validUntil := versionList.GetVersionWindow("1")
+			Expect(validFrom).To(Equal(&tcreated))
+			Expect(validUntil).To(Equal(&t1))
+		})
+
+		It("should return an open interval for the latest version", func() {
+			validFrom, validUntil := versionList.GetVersionWindow("3")
+			Expect(validFrom).To(Equal(&t2))
+			Expect(validUntil).To(BeNil())
+		})
+
+		It("should return nil for not existent version", func() {
+			validFrom, validUntil := versionList.GetVersionWindow("4")
+			Expect(validFrom).To(BeNil())
+			Expect(validUntil).To(BeNil())
+		})
+
+		It("should keep the order of the list", func() {
+			// Reverse the order of the sorted list
+			versionList[0], versionList[2] = versionList[2], versionList[0]
+			versionList.GetVersionWindow("1")
+			Expect(versionList[0].VersionId).To(Equal("1"))
+			Expect(versionList[2].VersionId).To(Equal("3"))
+		})
+	})
+
+	Context("IsDeactivatedInVersion", func() {
+		BeforeEach(func() {
+			// Ledger marks all the versions as deactivated after deactivation
+			for i := range versionList {
+				versionList[i].Deactivated = true
+			}
+			// Versions are sorted from the newest to the oldest
+			versionList[1].NextVersionId = "3"
+			versionList[2].NextVersionId = "2"
+		})
+
+		It("should return false for versions before deactivation", func() {
+			Expect(versionList.IsDeactivatedInVersion("1")).To(BeFalse())
+			Expect(versionList.IsDeactivatedInVersion("2")).To(BeFalse())
+		})
+
+		It("should return true for the deactivating version", func() {
+			Expect(versionList.IsDeactivatedInVersion("3")).To(BeTrue())
+		})
+
+		It("should return false for not existent version", func() {
+			Expect(versionList.IsDeactivatedInVersion("4")).To(BeFalse())
+		})
+	})
 })
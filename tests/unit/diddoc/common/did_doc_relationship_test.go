@@ -0,0 +1,35 @@
+//go:build unit
+
+package common
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cheqd/did-resolver/types"
+)
+
+var _ = Describe("Test HasVerificationRelationship method", func() {
+	did := "did:cheqd:testnet:b5d70adf-31ca-4662-aa10-d3a54cd8f06c"
+	key1 := did + "#key-1"
+	key2 := did + "#key-2"
+	didDoc := types.DidDoc{
+		Id:             did,
+		Authentication: []string{key1},
+		AssertionMethod: []types.AssertionMethod{
+			{AssertionMethodJSON: &types.VerificationMethod{Id: key2}},
+		},
+		KeyAgreement: []string{"#key-2"},
+	}
+
+	DescribeTable("checks references of verification relationships",
+		func(relationship types.VerificationRelationship, verificationMethodId string, expected bool) {
+			Expect(didDoc.HasVerificationRelationship(relationship, verificationMethodId)).To(Equal(expected))
+		},
+		Entry("absolute reference", types.AuthenticationRelationship, key1, true),
+		Entry("embedded assertion method", types.AssertionMethodRelationship, key2, true),
+		Entry("relative reference", types.KeyAgreementRelationship, key2, true),
+		Entry("not listed key", types.AuthenticationRelationship, key2, false),
+		Entry("empty relationship", types.CapabilityInvocationRelationship, key1, false),
+	)
+})
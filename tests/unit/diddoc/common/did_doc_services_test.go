@@ -0,0 +1,69 @@
+//go:build unit
+
+package common
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	resolverUtils "github.com/cheqd/did-resolver/utils"
+)
+
+var _ = Describe("Test GetServiceEndpoints method", func() {
+	did := "did:cheqd:testnet:b5d70adf-31ca-4662-aa10-d3a54cd8f06c"
+	didDoc := types.DidDoc{
+		Id: did,
+		Service: []types.Service{
+			{Id: did + "#domain-2", Type: types.LinkedDomains, ServiceEndpoint: types.StringOrStringArray{"https://second.example.com"}, Priority: 2},
+			{Id: did + "#domain-1", Type: types.LinkedDomains, ServiceEndpoint: types.StringOrStringArray{"https://first.example.com", "https://mirror.example.com"}, Priority: 1},
+			{Id: did + "#messaging", Type: "DIDCommMessaging", ServiceEndpoint: types.StringOrStringArray{"https://didcomm.example.com"}, Priority: 0},
+		},
+	}
+
+	DescribeTable("selects endpoints of the service with the highest priority",
+		func(serviceId string, serviceType string, expected []string) {
+			endpoints, err := didDoc.GetServiceEndpoints(serviceId, serviceType)
+			Expect(err).To(BeNil())
+			Expect(endpoints).To(Equal(expected))
+		},
+		Entry("by id", "domain-2", "", []string{"https://second.example.com"}),
+		Entry("by not existent id", "domain-3", "", nil),
+		Entry("by type with several services", "", types.LinkedDomains, []string{"https://first.example.com", "https://mirror.example.com"}),
+		Entry("by id and type", "domain-2", types.LinkedDomains, []string{"https://second.example.com"}),
+		Entry("by id and another type", "messaging", types.LinkedDomains, nil),
+		Entry("by id of the service without priority", "messaging", "", []string{"https://didcomm.example.com"}),
+		Entry("by any with the service without priority going last", "", "", []string{"https://first.example.com", "https://mirror.example.com"}),
+	)
+
+	It("redirects to the first endpoint and lists the others as alternates", func() {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		context := echo.New().NewContext(request, rec)
+
+		err := services.RedirectToService(context, types.NewServiceResult("https://first.example.com", "https://mirror.example.com"))
+		Expect(err).To(BeNil())
+		Expect(rec.Code).To(Equal(http.StatusSeeOther))
+		Expect(rec.Header().Get(echo.HeaderLocation)).To(Equal("https://first.example.com"))
+		Expect(rec.Header().Values("Link")).To(Equal([]string{`<https://mirror.example.com>; rel="alternate"`}))
+	})
+})
+
+var _ = DescribeTable("Test JoinURLPath function",
+	func(base string, path string, query string, expected string) {
+		result, err := resolverUtils.JoinURLPath(base, path, query)
+		Expect(err).To(BeNil())
+		Expect(result).To(Equal(expected))
+	},
+	Entry("endpoint without path", "https://example.com", "/some/path", "", "https://example.com/some/path"),
+	Entry("endpoint with path", "https://example.com/app", "/some/path", "", "https://example.com/app/some/path"),
+	Entry("endpoint with path and trailing slash", "https://example.com/app/", "/some/path", "", "https://example.com/app/some/path"),
+	Entry("path segment with colon", "https://example.com/app", "/some:path", "foo=bar", "https://example.com/app/some:path?foo=bar"),
+	Entry("endpoint with query", "https://example.com/app?key=1", "/some/path", "foo=bar", "https://example.com/app/some/path?key=1&foo=bar"),
+	Entry("escaped path", "https://example.com/a%2Fb", "/c%20d", "", "https://example.com/a%2Fb/c%20d"),
+)
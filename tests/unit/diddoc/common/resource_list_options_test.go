@@ -0,0 +1,167 @@
+//go:build unit
+
+package common
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceListOptions", func() {
+	var (
+		t1        = utils.MustParseDate("2021-08-23T09:00:00Z")
+		t2        = utils.MustParseDate("2021-08-23T09:40:00Z")
+		t3        = utils.MustParseDate("2021-08-23T09:50:00Z")
+		r1        = "1b1b7a6e-3f0e-4c56-9d43-51a3e8a3e7a1"
+		r2        = "2c2c8b7f-4a1f-4d67-8e54-62b4f9b4f8b2"
+		r3        = "3d3d9c8a-5b2a-4e78-9f65-73c5a0c5a9c3"
+		resources types.DereferencedResourceList
+	)
+
+	cursor := func(resourceId string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(resourceId))
+	}
+
+	BeforeEach(func() {
+		// Sorted from the newest to the oldest as the ledger responses are
+		resources = types.DereferencedResourceList{
+			{Created: &t3, ResourceId: r3, Name: "b", ResourceType: "type2", Version: "1"},
+			{Created: &t2, ResourceId: r2, Name: "a", ResourceType: "type1", Version: "3"},
+			{Created: &t1, ResourceId: r1, Name: "a", ResourceType: "type1", Version: "2"},
+		}
+	})
+
+	Context("NewResourceListOptions", func() {
+		It("returns nil without resource list queries", func() {
+			Expect(types.NewResourceListOptions(url.Values{types.ResourceMetadata: {"true"}})).To(BeNil())
+		})
+
+		DescribeTable("rejects invalid queries", func(queries url.Values) {
+			_, err := types.NewResourceListOptions(queries)
+			Expect(err).ToNot(BeNil())
+		},
+			Entry("limit is not a number", url.Values{types.ResourceLimitQ: {"ten"}}),
+			Entry("limit is too small", url.Values{types.ResourceLimitQ: {"0"}}),
+			Entry("limit is too big", url.Values{types.ResourceLimitQ: {"1001"}}),
+			Entry("cursor is not base64", url.Values{types.ResourceCursorQ: {"not base64"}}),
+			Entry("not supported sort", url.Values{types.ResourceSortQ: {"-size"}}),
+			Entry("not existent field", url.Values{types.ResourceFieldsQ: {"resourceId,size"}}),
+			Entry("paginated summary", url.Values{types.ResourceMetadata: {types.ResourceMetadataSummary}, types.ResourceLimitQ: {"1"}}),
+		)
+	})
+
+	Context("PageRequest", func() {
+		It("is not set for the list without limit and cursor", func() {
+			Expect(types.ResourceListOptions{Sort: types.ResourceSortName}.PageRequest()).To(BeNil())
+		})
+
+		It("is not set for sorted lists, which are paginated by the resolver", func() {
+			Expect(types.ResourceListOptions{Limit: 10, Sort: "-" + types.ResourceSortCreated}.PageRequest()).To(BeNil())
+		})
+
+		It("counts resources for the first page only", func() {
+			first := types.ResourceListOptions{Limit: 10}.PageRequest()
+			Expect(first.Key).To(BeEmpty())
+			Expect(first.Limit).To(Equal(uint64(10)))
+			Expect(first.CountTotal).To(BeTrue())
+
+			next := types.ResourceListOptions{Limit: 10, Cursor: "key"}.PageRequest()
+			Expect(next.Key).To(Equal([]byte("key")))
+			Expect(next.Limit).To(Equal(uint64(10)))
+			Expect(next.CountTotal).To(BeFalse())
+		})
+
+		It("takes NextKey of the ledger as the cursor", func() {
+			pagination := types.NewResourcePagination(&query.PageRequest{Limit: 10}, &query.PageResponse{NextKey: []byte("key"), Total: 12})
+			Expect(pagination).To(Equal(&types.ResourcePagination{Total: 12, Limit: 10, NextCursor: cursor("key")}))
+		})
+	})
+
+	Context("Apply", func() {
+		It("sorts resources", func() {
+			options := types.ResourceListOptions{Sort: types.ResourceSortCreated}
+			page, _, _, err := options.Apply(resources)
+			Expect(err).To(BeNil())
+			Expect([]string{page[0].ResourceId, page[1].ResourceId, page[2].ResourceId}).To(Equal([]string{r1, r2, r3}))
+
+			options = types.ResourceListOptions{Sort: types.ResourceSortDescending + types.ResourceSortVersion}
+			page, _, _, err = options.Apply(resources)
+			Expect(err).To(BeNil())
+			Expect([]string{page[0].ResourceId, page[1].ResourceId, page[2].ResourceId}).To(Equal([]string{r2, r1, r3}))
+		})
+
+		It("keeps the original order of resources with the same name", func() {
+			options := types.ResourceListOptions{Sort: types.ResourceSortName}
+			page, _, _, err := options.Apply(resources)
+			Expect(err).To(BeNil())
+			Expect([]string{page[0].ResourceId, page[1].ResourceId, page[2].ResourceId}).To(Equal([]string{r2, r1, r3}))
+		})
+
+		It("paginates resources with cursor", func() {
+			options := types.ResourceListOptions{Limit: 2}
+			page, pagination, _, err := options.Apply(resources)
+			Expect(err).To(BeNil())
+			Expect(page).To(HaveLen(2))
+			Expect(pagination).To(Equal(&types.ResourcePagination{Total: 3, Limit: 2, NextCursor: cursor(r2)}))
+
+			options.Cursor = r2
+			page, pagination, _, err = options.Apply(resources)
+			Expect(err).To(BeNil())
+			Expect(page).To(HaveLen(1))
+			Expect(page[0].ResourceId).To(Equal(r1))
+			Expect(pagination).To(Equal(&types.ResourcePagination{Total: 3, Limit: 2}))
+		})
+
+		It("fails for cursor of not existent resource", func() {
+			options := types.ResourceListOptions{Cursor: "4e4e0d9b-6c3b-4f89-a076-84d6b1d6b0d4"}
+			_, _, _, err := options.Apply(resources)
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("selects fields of resources", func() {
+			options := types.ResourceListOptions{Fields: []string{"resourceId", "resourceName", "previousVersionId"}}
+			metadata := types.ResolutionDidDocMetadata{Resources: resources}
+			Expect(options.ApplyToMetadata(&metadata)).To(Succeed())
+
+			bytes, err := json.Marshal(metadata)
+			Expect(err).To(BeNil())
+			Expect(string(bytes)).To(MatchJSON(`{"linkedResourceMetadata": [
+				{"resourceId": "` + r3 + `", "resourceName": "b", "previousVersionId": null},
+				{"resourceId": "` + r2 + `", "resourceName": "a", "previousVersionId": null},
+				{"resourceId": "` + r1 + `", "resourceName": "a", "previousVersionId": null}
+			]}`))
+		})
+
+		It("only sorts the page returned by the ledger", func() {
+			ledgerPagination := &types.ResourcePagination{Total: 5, Limit: 3, NextCursor: cursor("3")}
+			options := types.ResourceListOptions{Limit: 3, Sort: types.ResourceSortCreated}
+			metadata := types.ResolutionDidDocMetadata{Resources: resources, ResourcePagination: ledgerPagination}
+			Expect(options.ApplyToMetadata(&metadata)).To(Succeed())
+
+			Expect(metadata.ResourcePagination).To(Equal(ledgerPagination))
+			Expect([]string{metadata.Resources[0].ResourceId, metadata.Resources[1].ResourceId, metadata.Resources[2].ResourceId}).To(Equal([]string{r1, r2, r3}))
+		})
+
+		It("counts resources per type and name", func() {
+			options := types.ResourceListOptions{Summary: true}
+			page, pagination, summary, err := options.Apply(resources)
+			Expect(err).To(BeNil())
+			Expect(page).To(BeNil())
+			Expect(pagination).To(BeNil())
+			Expect(summary).To(Equal(&types.ResourceSummary{
+				Total: 3,
+				Resources: []types.ResourceSummaryEntry{
+					{ResourceType: "type1", Name: "a", Count: 2, LatestCreated: &t2},
+					{ResourceType: "type2", Name: "b", Count: 1, LatestCreated: &t3},
+				},
+			}))
+		})
+	})
+})
@@ -0,0 +1,229 @@
+//go:build unit
+
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	didDocServices "github.com/cheqd/did-resolver/services/diddoc"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type authorizationDIDDocTestCase struct {
+	didURL                 string
+	resolutionType         types.ContentType
+	expectedAuthorized     bool
+	expectedResolutionPath []string
+	expectedError          error
+}
+
+var (
+	// subjectDid is controlled by controllerDid, which is controlled by rootDid and back by subjectDid.
+	// deactivatedDid lists the key of rootDid, but is deactivated.
+	subjectDid     = "did:cheqd:testnet:2a2b4ac5-0b48-4c36-9a67-1f1b5ac5b7e1"
+	controllerDid  = "did:cheqd:testnet:6f8b3b0e-5d6c-4bb9-9a4f-1d1a3e3bfb1a"
+	rootDid        = "did:cheqd:testnet:9e1c4c51-8d0f-4a3d-9b5e-3f3c4f7d1e2b"
+	deactivatedDid = "did:cheqd:testnet:c8b8e6f4-2b8a-4a0f-8a3b-7c5e2e9d6f3c"
+
+	// branchingDid is controlled by the long chain of longChainDids, which ends with shortcutDid,
+	// and by shortcutDid directly. shortcutDid is controlled by keyHolderDid.
+	branchingDid  = "did:cheqd:testnet:7ee4ad93-99a8-421d-8518-7ff2e2a59ac1"
+	longChainDids = []string{
+		"did:cheqd:testnet:30528e8f-43ec-4ce7-8187-48362d7826bd",
+		"did:cheqd:testnet:fc7ab49f-6d5e-4b1a-8274-0e0e92e99a90",
+		"did:cheqd:testnet:3bbcb79a-a38d-4d9e-a4a2-3392dafc297f",
+	}
+	shortcutDid  = "did:cheqd:testnet:22efa13f-a906-4e3a-b51c-c635f599bb33"
+	keyHolderDid = "did:cheqd:testnet:bd039f8a-d714-4697-83a4-e8d1bc490cb3"
+
+	// brokenControllerDid is controlled by unavailableDid, which cannot be resolved, and by rootDid.
+	brokenControllerDid = "did:cheqd:testnet:70a027c9-adcb-4099-a575-399c4cca0777"
+	unavailableDid      = "did:cheqd:testnet:5d0c2d7e-54d4-4cb8-a3e8-0b1bbf3b2c4d"
+
+	authorizationMockLedger = unavailableDidsLedgerService{
+		MockMultipleDidsLedgerService: utils.NewMockMultipleDidsLedgerService(
+			generateAuthorizationDidDoc(subjectDid, []string{controllerDid}, "", false),
+			generateAuthorizationDidDoc(controllerDid, []string{rootDid, subjectDid, "did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"}, "", false),
+			generateAuthorizationDidDoc(rootDid, []string{controllerDid}, "#key-1", false),
+			generateAuthorizationDidDoc(deactivatedDid, []string{rootDid}, rootDid+"#key-1", true),
+			generateAuthorizationDidDoc(branchingDid, []string{longChainDids[0], shortcutDid}, "", false),
+			generateAuthorizationDidDoc(longChainDids[0], []string{longChainDids[1]}, "", false),
+			generateAuthorizationDidDoc(longChainDids[1], []string{longChainDids[2]}, "", false),
+			generateAuthorizationDidDoc(longChainDids[2], []string{shortcutDid}, "", false),
+			generateAuthorizationDidDoc(shortcutDid, []string{keyHolderDid}, "", false),
+			generateAuthorizationDidDoc(keyHolderDid, nil, "#key-1", false),
+			generateAuthorizationDidDoc(brokenControllerDid, []string{unavailableDid, rootDid}, "", false),
+		),
+		unavailable: []string{unavailableDid},
+	}
+)
+
+// unavailableDidsLedgerService fails to query the given DIDs as if the ledger returned corrupted data
+type unavailableDidsLedgerService struct {
+	utils.MockMultipleDidsLedgerService
+	unavailable []string
+}
+
+func (ls unavailableDidsLedgerService) QueryDIDDoc(did string, version string) (*didTypes.DidDocWithMetadata, *types.IdentityError) {
+	if slices.Contains(ls.unavailable, did) {
+		return nil, types.NewInternalError(did, types.JSON, nil, true)
+	}
+	return ls.MockMultipleDidsLedgerService.QueryDIDDoc(did, version)
+}
+
+func generateAuthorizationDidDoc(did string, controllers []string, authentication string, deactivated bool) *didTypes.DidDocWithMetadata {
+	didDoc := &didTypes.DidDoc{
+		Id:         did,
+		Controller: controllers,
+		VerificationMethod: []*didTypes.VerificationMethod{
+			{
+				Id:                     did + "#key-1",
+				VerificationMethodType: "JsonWebKey2020",
+				Controller:             did,
+				VerificationMaterial:   testconstants.ValidPubKeyJWK,
+			},
+		},
+	}
+	if authentication != "" {
+		didDoc.Authentication = []string{authentication}
+	}
+
+	return &didTypes.DidDocWithMetadata{
+		DidDoc: didDoc,
+		Metadata: &didTypes.Metadata{
+			VersionId:   testconstants.ValidVersionId,
+			Created:     timestamppb.New(DidDocCreated),
+			Deactivated: deactivated,
+		},
+	}
+}
+
+var _ = DescribeTable("Test DidDocAuthorizationEchoHandler function", func(testCase authorizationDIDDocTestCase) {
+	request := httptest.NewRequest(http.MethodGet, testCase.didURL, nil)
+	context, rec := utils.SetupEmptyContext(request, testCase.resolutionType, authorizationMockLedger)
+
+	err := didDocServices.DidDocAuthorizationEchoHandler(context)
+	if testCase.expectedError != nil {
+		Expect(err).ToNot(BeNil())
+		Expect(testCase.expectedError.Error()).To(Equal(err.Error()))
+		return
+	}
+
+	Expect(err).To(BeNil())
+	var dereferencingResult struct {
+		DereferencingMetadata types.DereferencingMetadata   `json:"dereferencingMetadata"`
+		ContentStream         types.ControllerAuthorization `json:"contentStream"`
+	}
+	Expect(json.Unmarshal(rec.Body.Bytes(), &dereferencingResult)).To(BeNil())
+	Expect(dereferencingResult.ContentStream.Authorized).To(Equal(testCase.expectedAuthorized))
+	Expect(dereferencingResult.ContentStream.ResolutionPath).To(Equal(testCase.expectedResolutionPath))
+	Expect(dereferencingResult.DereferencingMetadata.ContentType).To(Equal(testCase.resolutionType))
+},
+
+	Entry(
+		"can authorize a key of the DID itself",
+		authorizationDIDDocTestCase{
+			didURL:                 fmt.Sprintf("/1.0/identifiers/%s/authorization?verificationMethod=%s&relationship=authentication", rootDid, rootDid+"%23key-1"),
+			resolutionType:         types.DIDJSONLD,
+			expectedAuthorized:     true,
+			expectedResolutionPath: []string{rootDid},
+		},
+	),
+
+	Entry(
+		"can authorize a key through the controller chain",
+		authorizationDIDDocTestCase{
+			didURL:                 fmt.Sprintf("/1.0/identifiers/%s/authorization?verificationMethod=%s&relationship=authentication", subjectDid, rootDid+"%23key-1"),
+			resolutionType:         types.DIDJSONLD,
+			expectedAuthorized:     true,
+			expectedResolutionPath: []string{subjectDid, controllerDid, rootDid},
+		},
+	),
+
+	Entry(
+		"can authorize a key through the shortest chain when a longer chain reaches the same controller first",
+		authorizationDIDDocTestCase{
+			didURL:                 fmt.Sprintf("/1.0/identifiers/%s/authorization?verificationMethod=%s&relationship=authentication", branchingDid, keyHolderDid+"%23key-1"),
+			resolutionType:         types.DIDJSONLD,
+			expectedAuthorized:     true,
+			expectedResolutionPath: []string{branchingDid, shortcutDid, keyHolderDid},
+		},
+	),
+
+	Entry(
+		"can authorize a key through other controllers when one of them cannot be resolved",
+		authorizationDIDDocTestCase{
+			didURL:                 fmt.Sprintf("/1.0/identifiers/%s/authorization?verificationMethod=%s&relationship=authentication", brokenControllerDid, rootDid+"%23key-1"),
+			resolutionType:         types.DIDJSONLD,
+			expectedAuthorized:     true,
+			expectedResolutionPath: []string{brokenControllerDid, rootDid},
+		},
+	),
+
+	Entry(
+		"cannot authorize a key which is not listed under the relationship",
+		authorizationDIDDocTestCase{
+			didURL:                 fmt.Sprintf("/1.0/identifiers/%s/authorization?verificationMethod=%s&relationship=assertionMethod", subjectDid, rootDid+"%23key-1"),
+			resolutionType:         types.DIDJSONLD,
+			expectedAuthorized:     false,
+			expectedResolutionPath: []string{subjectDid, controllerDid, rootDid},
+		},
+	),
+
+	Entry(
+		"cannot authorize a key of a deactivated DID",
+		authorizationDIDDocTestCase{
+			didURL:                 fmt.Sprintf("/1.0/identifiers/%s/authorization?verificationMethod=%s&relationship=authentication", deactivatedDid, rootDid+"%23key-1"),
+			resolutionType:         types.DIDJSONLD,
+			expectedAuthorized:     false,
+			expectedResolutionPath: []string{deactivatedDid},
+		},
+	),
+
+	Entry(
+		"cannot check authorization with not existent DID",
+		authorizationDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/authorization?verificationMethod=%s&relationship=authentication", testconstants.NotExistentTestnetDid, rootDid+"%23key-1"),
+			resolutionType: types.DIDJSONLD,
+			expectedError:  types.NewNotFoundError(testconstants.NotExistentTestnetDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot check authorization with unsupported relationship",
+		authorizationDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/authorization?verificationMethod=%s&relationship=service", rootDid, rootDid+"%23key-1"),
+			resolutionType: types.DIDJSONLD,
+			expectedError:  types.NewInvalidDidUrlError(rootDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot check authorization without verification method fragment",
+		authorizationDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/authorization?verificationMethod=%s&relationship=authentication", rootDid, rootDid),
+			resolutionType: types.DIDJSONLD,
+			expectedError:  types.NewInvalidDidUrlError(rootDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot check authorization with unsupported query",
+		authorizationDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/authorization?verificationMethod=%s&relationship=authentication&versionId=%s", rootDid, rootDid+"%23key-1", VersionId1),
+			resolutionType: types.DIDJSONLD,
+			expectedError:  types.NewInvalidDidUrlError(rootDid, types.DIDJSONLD, nil, true),
+		},
+	),
+)
@@ -0,0 +1,190 @@
+//go:build unit
+
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	didDocServices "github.com/cheqd/did-resolver/services/diddoc"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type diffDIDDocTestCase struct {
+	didURL          string
+	resolutionType  types.ContentType
+	expectedFrom    string
+	expectedTo      string
+	expectedSummary types.DidDocDiffSummary
+	expectedError   error
+}
+
+var (
+	diffDidDocV1 = generateDiffDidDoc("key-1", "https://example.com")
+	diffDidDocV2 = generateDiffDidDoc("key-2", "https://example.com")
+	diffDidDocV3 = generateDiffDidDoc("key-2", "https://example.org")
+
+	diffMetadataV1 = didTypes.Metadata{VersionId: VersionId1, Created: timestamppb.New(DidDocCreated), NextVersionId: VersionId2}
+	diffMetadataV2 = didTypes.Metadata{
+		VersionId: VersionId2, Created: timestamppb.New(DidDocCreated), Updated: timestamppb.New(DidDocUpdated),
+		PreviousVersionId: VersionId1, NextVersionId: testconstants.ValidVersionId,
+	}
+	diffMetadataV3 = didTypes.Metadata{
+		VersionId: testconstants.ValidVersionId, Created: timestamppb.New(DidDocCreated), Updated: timestamppb.New(DidDocAfterUpdated),
+		PreviousVersionId: VersionId2,
+	}
+
+	diffMockLedger = utils.NewMockVersionedLedgerService(
+		map[string]*didTypes.DidDoc{
+			VersionId1:                   &diffDidDocV1,
+			VersionId2:                   &diffDidDocV2,
+			testconstants.ValidVersionId: &diffDidDocV3,
+		},
+		[]*didTypes.Metadata{&diffMetadataV1, &diffMetadataV2, &diffMetadataV3},
+		[]resourceTypes.ResourceWithMetadata{},
+	)
+)
+
+func generateDiffDidDoc(keyId string, serviceEndpoint string) didTypes.DidDoc {
+	verificationMethod := didTypes.VerificationMethod{
+		Id:                     testconstants.ExistentDid + "#" + keyId,
+		VerificationMethodType: "JsonWebKey2020",
+		Controller:             testconstants.ExistentDid,
+		VerificationMaterial:   testconstants.ValidPubKeyJWK,
+	}
+	service := didTypes.Service{
+		Id:              testconstants.ExistentDid + "#" + testconstants.ValidServiceId,
+		ServiceType:     types.LinkedDomains,
+		ServiceEndpoint: []string{serviceEndpoint},
+	}
+
+	return didTypes.DidDoc{
+		Id:                 testconstants.ExistentDid,
+		Controller:         []string{testconstants.ExistentDid},
+		VerificationMethod: []*didTypes.VerificationMethod{&verificationMethod},
+		Authentication:     []string{verificationMethod.Id},
+		Service:            []*didTypes.Service{&service},
+	}
+}
+
+func emptyDiffSummary() types.DidDocDiffSummary {
+	return types.NewDidDocDiffSummary(types.DidDoc{}, types.DidDoc{})
+}
+
+var _ = DescribeTable("Test DidDocDiffEchoHandler function", func(testCase diffDIDDocTestCase) {
+	request := httptest.NewRequest(http.MethodGet, testCase.didURL, nil)
+	context, rec := utils.SetupEmptyContext(request, testCase.resolutionType, diffMockLedger)
+
+	err := didDocServices.DidDocDiffEchoHandler(context)
+	if testCase.expectedError != nil {
+		Expect(err).ToNot(BeNil())
+		Expect(testCase.expectedError.Error()).To(Equal(err.Error()))
+		return
+	}
+
+	Expect(err).To(BeNil())
+	var dereferencingResult struct {
+		DereferencingMetadata types.DereferencingMetadata `json:"dereferencingMetadata"`
+		ContentStream         types.DidDocDiff            `json:"contentStream"`
+	}
+	Expect(json.Unmarshal(rec.Body.Bytes(), &dereferencingResult)).To(BeNil())
+	Expect(dereferencingResult.ContentStream.From.VersionId).To(Equal(testCase.expectedFrom))
+	Expect(dereferencingResult.ContentStream.To.VersionId).To(Equal(testCase.expectedTo))
+	Expect(dereferencingResult.ContentStream.Summary).To(Equal(testCase.expectedSummary))
+	Expect(dereferencingResult.DereferencingMetadata.ContentType).To(Equal(testCase.resolutionType))
+},
+
+	Entry(
+		"can diff two explicit versions",
+		diffDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/diff?from=%s&to=%s", testconstants.ExistentDid, VersionId1, testconstants.ValidVersionId),
+			resolutionType: types.DIDJSONLD,
+			expectedFrom:   VersionId1,
+			expectedTo:     testconstants.ValidVersionId,
+			expectedSummary: func() types.DidDocDiffSummary {
+				summary := emptyDiffSummary()
+				summary.KeysAdded = []string{testconstants.ExistentDid + "#key-2"}
+				summary.KeysRemoved = []string{testconstants.ExistentDid + "#key-1"}
+				summary.ServicesChanged = []string{testconstants.ExistentDid + "#" + testconstants.ValidServiceId}
+				return summary
+			}(),
+		},
+	),
+
+	Entry(
+		"can diff against the previous version",
+		diffDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/diff?to=%s", testconstants.ExistentDid, VersionId2),
+			resolutionType: types.DIDJSONLD,
+			expectedFrom:   VersionId1,
+			expectedTo:     VersionId2,
+			expectedSummary: func() types.DidDocDiffSummary {
+				summary := emptyDiffSummary()
+				summary.KeysAdded = []string{testconstants.ExistentDid + "#key-2"}
+				summary.KeysRemoved = []string{testconstants.ExistentDid + "#key-1"}
+				return summary
+			}(),
+		},
+	),
+
+	Entry(
+		"can diff the latest version against the previous one",
+		diffDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/diff", testconstants.ExistentDid),
+			resolutionType: types.JSONLD,
+			expectedFrom:   VersionId2,
+			expectedTo:     testconstants.ValidVersionId,
+			expectedSummary: func() types.DidDocDiffSummary {
+				summary := emptyDiffSummary()
+				summary.ServicesChanged = []string{testconstants.ExistentDid + "#" + testconstants.ValidServiceId}
+				return summary
+			}(),
+		},
+	),
+
+	Entry(
+		"cannot diff the first version against the previous one",
+		diffDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/diff?to=%s", testconstants.ExistentDid, VersionId1),
+			resolutionType: types.DIDJSONLD,
+			expectedError:  types.NewNotFoundError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot diff with a not existent version",
+		diffDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/diff?from=%s", testconstants.ExistentDid, testconstants.NotExistentIdentifier),
+			resolutionType: types.DIDJSONLD,
+			expectedError:  types.NewNotFoundError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot diff with an invalid version",
+		diffDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/diff?from=%s", testconstants.ExistentDid, testconstants.InvalidVersionId),
+			resolutionType: types.DIDJSONLD,
+			expectedError:  types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot diff with unsupported query",
+		diffDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/diff?versionId=%s", testconstants.ExistentDid, VersionId1),
+			resolutionType: types.DIDJSONLD,
+			expectedError:  types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+)
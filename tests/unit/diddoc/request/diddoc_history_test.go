@@ -0,0 +1,165 @@
+//go:build unit
+
+package request
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	didDocServices "github.com/cheqd/did-resolver/services/diddoc"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/labstack/echo/v4"
+)
+
+type historyDIDDocTestCase struct {
+	didURL             string
+	resolutionType     types.ContentType
+	acceptHeader       string
+	expectedVersionIds []string
+	expectedContext    string
+	expectedError      error
+}
+
+var _ = DescribeTable("Test DidDocHistoryEchoHandler function", func(testCase historyDIDDocTestCase) {
+	request := httptest.NewRequest(http.MethodGet, testCase.didURL, nil)
+	context, rec := utils.SetupEmptyContext(request, testCase.resolutionType, diffMockLedger)
+	if testCase.acceptHeader != "" {
+		request.Header.Set(echo.HeaderAccept, testCase.acceptHeader)
+	}
+
+	err := didDocServices.DidDocHistoryEchoHandler(context)
+	if testCase.expectedError != nil {
+		Expect(err).ToNot(BeNil())
+		Expect(testCase.expectedError.Error()).To(Equal(err.Error()))
+		return
+	}
+
+	Expect(err).To(BeNil())
+	Expect(rec.Header().Get(echo.HeaderContentType)).To(Equal(string(types.JSONL)))
+
+	var versionIds []string
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var entry types.DidDocHistoryEntry
+		Expect(json.Unmarshal(scanner.Bytes(), &entry)).To(BeNil())
+		Expect(entry.Did.Id).To(Equal(testconstants.ExistentDid))
+		Expect(entry.Context).To(Equal(testCase.expectedContext))
+		versionIds = append(versionIds, entry.Metadata.VersionId)
+	}
+	Expect(versionIds).To(Equal(testCase.expectedVersionIds))
+},
+
+	Entry(
+		"can get DIDDoc history in chronological order",
+		historyDIDDocTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s/history", testconstants.ExistentDid),
+			resolutionType:     types.DIDJSONLD,
+			expectedVersionIds: []string{VersionId1, VersionId2, testconstants.ValidVersionId},
+			expectedContext:    types.ResolutionSchemaJSONLD,
+		},
+	),
+
+	Entry(
+		"can get DIDDoc history with JSON Lines accept header",
+		historyDIDDocTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s/history", testconstants.ExistentDid),
+			resolutionType:     types.JSONL,
+			expectedVersionIds: []string{VersionId1, VersionId2, testconstants.ValidVersionId},
+		},
+	),
+
+	Entry(
+		"can get DIDDoc history with JSON Lines preferred over JSON-LD",
+		historyDIDDocTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s/history", testconstants.ExistentDid),
+			resolutionType:     types.DIDJSONLD,
+			acceptHeader:       "application/ld+json;q=0.5, application/jsonl",
+			expectedVersionIds: []string{VersionId1, VersionId2, testconstants.ValidVersionId},
+		},
+	),
+
+	Entry(
+		"can get DIDDoc history with JSON-LD preferred over JSON Lines",
+		historyDIDDocTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s/history", testconstants.ExistentDid),
+			resolutionType:     types.DIDJSONLD,
+			acceptHeader:       "application/ld+json, application/jsonl;q=0.5",
+			expectedVersionIds: []string{VersionId1, VersionId2, testconstants.ValidVersionId},
+			expectedContext:    types.ResolutionSchemaJSONLD,
+		},
+	),
+
+	Entry(
+		"cannot get DIDDoc history with not existent DID",
+		historyDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/history", testconstants.NotExistentTestnetDid),
+			resolutionType: types.DIDJSONLD,
+			expectedError:  types.NewNotFoundError(testconstants.NotExistentTestnetDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot get DIDDoc history with queries",
+		historyDIDDocTestCase{
+			didURL:         fmt.Sprintf("/1.0/identifiers/%s/history?versionId=%s", testconstants.ExistentDid, VersionId1),
+			resolutionType: types.DIDJSONLD,
+			expectedError:  types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+)
+
+// unavailableVersionsLedgerService fails to query the given versions of DID Documents
+type unavailableVersionsLedgerService struct {
+	utils.MockVersionedLedgerService
+	unavailable []string
+}
+
+func (ls unavailableVersionsLedgerService) QueryDIDDoc(did string, version string) (*didTypes.DidDocWithMetadata, *types.IdentityError) {
+	if slices.Contains(ls.unavailable, version) {
+		return nil, types.NewInternalError(did, types.JSON, nil, false)
+	}
+	return ls.MockVersionedLedgerService.QueryDIDDoc(did, version)
+}
+
+var _ = Describe("DIDDoc history streaming", func() {
+	historyURL := fmt.Sprintf("/1.0/identifiers/%s/history", testconstants.ExistentDid)
+
+	It("returns the error with its status if the first version can't be resolved", func() {
+		ledger := unavailableVersionsLedgerService{MockVersionedLedgerService: diffMockLedger, unavailable: []string{VersionId1}}
+		context, rec := utils.SetupEmptyContext(httptest.NewRequest(http.MethodGet, historyURL, nil), types.DIDJSONLD, ledger)
+
+		err := didDocServices.DidDocHistoryEchoHandler(context)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(types.NewInternalError(testconstants.ExistentDid, types.DIDJSONLD, nil, true).Error()))
+		Expect(rec.Body.Len()).To(BeZero())
+	})
+
+	It("ends the stream with the error if a later version can't be resolved", func() {
+		ledger := unavailableVersionsLedgerService{MockVersionedLedgerService: diffMockLedger, unavailable: []string{VersionId2}}
+		context, rec := utils.SetupEmptyContext(httptest.NewRequest(http.MethodGet, historyURL, nil), types.DIDJSONLD, ledger)
+
+		Expect(didDocServices.DidDocHistoryEchoHandler(context)).To(BeNil())
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Flushed).To(BeTrue())
+
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+		var entry types.DidDocHistoryEntry
+		Expect(json.Unmarshal([]byte(lines[0]), &entry)).To(BeNil())
+		Expect(entry.Metadata.VersionId).To(Equal(VersionId1))
+		var dereferencing types.ResourceDereferencing
+		Expect(json.Unmarshal([]byte(lines[1]), &dereferencing)).To(BeNil())
+		Expect(dereferencing.DereferencingMetadata.ResolutionError).To(Equal("internalError"))
+	})
+})
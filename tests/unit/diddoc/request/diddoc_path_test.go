@@ -0,0 +1,78 @@
+//go:build unit
+
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cheqd/did-resolver/services"
+	didDocServices "github.com/cheqd/did-resolver/services/diddoc"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type pathDIDDocTestCase struct {
+	didURL                 string
+	pathServiceId          string
+	expectedLocationHeader string
+	expectedError          error
+}
+
+var _ = DescribeTable("Test DidDocPathEchoHandler function", func(testCase pathDIDDocTestCase) {
+	request := httptest.NewRequest(http.MethodGet, testCase.didURL, nil)
+	context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, utils.MockLedger)
+	resolverContext := context.(services.ResolverContext)
+	resolverContext.DidDocService.SetPathServiceId(testCase.pathServiceId)
+
+	err := didDocServices.DidDocPathEchoHandler(resolverContext)
+	if testCase.expectedError != nil {
+		Expect(err).ToNot(BeNil())
+		Expect(testCase.expectedError.Error()).To(Equal(err.Error()))
+		return
+	}
+
+	Expect(err).To(BeNil())
+	Expect(rec.Code).To(Equal(http.StatusSeeOther))
+	Expect(rec.Header().Get("Location")).To(Equal(testCase.expectedLocationHeader))
+},
+
+	Entry(
+		"can dereference path through LinkedDomains service",
+		pathDIDDocTestCase{
+			didURL:                 fmt.Sprintf("/1.0/identifiers/%s/some/path", testconstants.ValidDid),
+			expectedLocationHeader: testconstants.ValidService.ServiceEndpoint[0] + "/some/path",
+		},
+	),
+
+	Entry(
+		"can dereference path with query through configured service",
+		pathDIDDocTestCase{
+			didURL:                 fmt.Sprintf("/1.0/identifiers/%s/some:path?foo=bar", testconstants.ValidDid),
+			pathServiceId:          testconstants.ValidServiceId,
+			expectedLocationHeader: testconstants.ValidService.ServiceEndpoint[0] + "/some:path?foo=bar",
+		},
+	),
+
+	Entry(
+		"cannot dereference path if configured service is not found",
+		pathDIDDocTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/some/path", testconstants.ValidDid),
+			pathServiceId: testconstants.InvalidServiceId,
+			expectedError: types.NewNotFoundError(testconstants.ValidDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot dereference path of not existent DID",
+		pathDIDDocTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/some/path", testconstants.NotExistentTestnetDid),
+			expectedError: types.NewNotFoundError(testconstants.NotExistentTestnetDid, types.DIDJSONLD, nil, true),
+		},
+	),
+)
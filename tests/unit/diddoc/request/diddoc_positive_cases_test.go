@@ -113,9 +113,12 @@ var _ = DescribeTable("Test Query handlers with versionId and versionTime params
 					},
 				},
 				Did: &testconstants.ValidDIDDocResolution,
-				Metadata: types.NewResolutionDidDocMetadata(
-					testconstants.ValidDid, &DidDocMetadata1,
-					[]*resourceTypes.Metadata{ResourceName2.Metadata, ResourceName12.Metadata, ResourceName1.Metadata},
+				Metadata: withVersionWindow(
+					types.NewResolutionDidDocMetadata(
+						testconstants.ValidDid, &DidDocMetadata1,
+						[]*resourceTypes.Metadata{ResourceName2.Metadata, ResourceName12.Metadata, ResourceName1.Metadata},
+					),
+					&DidDocCreated, &DidDocUpdated,
 				),
 			},
 			expectedError: nil,
@@ -135,18 +138,21 @@ var _ = DescribeTable("Test Query handlers with versionId and versionTime params
 					},
 				},
 				Did: &testconstants.ValidDIDDocResolution,
-				Metadata: types.NewResolutionDidDocMetadata(
-					testconstants.ValidDid, &DidDocMetadata2,
-					[]*resourceTypes.Metadata{
-						ResourceType2.Metadata,
-						ResourceChecksum.Metadata,
-						ResourceType12.Metadata,
-						ResourceType1.Metadata,
-						ResourceType13.Metadata,
-						ResourceName2.Metadata,
-						ResourceName12.Metadata,
-						ResourceName1.Metadata,
-					},
+				Metadata: withVersionWindow(
+					types.NewResolutionDidDocMetadata(
+						testconstants.ValidDid, &DidDocMetadata2,
+						[]*resourceTypes.Metadata{
+							ResourceType2.Metadata,
+							ResourceChecksum.Metadata,
+							ResourceType12.Metadata,
+							ResourceType1.Metadata,
+							ResourceType13.Metadata,
+							ResourceName2.Metadata,
+							ResourceName12.Metadata,
+							ResourceName1.Metadata,
+						},
+					),
+					&DidDocUpdated, nil,
 				),
 			},
 			expectedError: nil,
@@ -166,9 +172,12 @@ var _ = DescribeTable("Test Query handlers with versionId and versionTime params
 					},
 				},
 				Did: &testconstants.ValidDIDDocResolution,
-				Metadata: types.NewResolutionDidDocMetadata(
-					testconstants.ValidDid, &DidDocMetadata1,
-					[]*resourceTypes.Metadata{ResourceName2.Metadata, ResourceName12.Metadata, ResourceName1.Metadata},
+				Metadata: withVersionWindow(
+					types.NewResolutionDidDocMetadata(
+						testconstants.ValidDid, &DidDocMetadata1,
+						[]*resourceTypes.Metadata{ResourceName2.Metadata, ResourceName12.Metadata, ResourceName1.Metadata},
+					),
+					&DidDocCreated, &DidDocUpdated,
 				),
 			},
 			expectedError: nil,
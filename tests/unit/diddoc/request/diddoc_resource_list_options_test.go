@@ -0,0 +1,191 @@
+//go:build unit
+
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"time"
+
+	didDocService "github.com/cheqd/did-resolver/services/diddoc"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Linked resources pagination, sorting and field selection", func() {
+	resolveMetadata := func(query string) (*types.DidResolution, error) {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/1.0/identifiers/%s/metadata?%s", testconstants.ValidDid, query), nil)
+		context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, MockLedger)
+
+		if err := didDocService.DidDocMetadataEchoHandler(context); err != nil {
+			return nil, err
+		}
+		var resolution types.DidResolution
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resolution)).To(BeNil())
+		return &resolution, nil
+	}
+
+	It("can go through all pages of resources", func() {
+		var resourceIds []string
+		query := "resourceLimit=3"
+		for pages := 1; ; pages++ {
+			resolution, err := resolveMetadata(query)
+			Expect(err).To(BeNil())
+			// The ledger counts resources only for the first page
+			if pages == 1 {
+				Expect(resolution.Metadata.ResourcePagination.Total).To(Equal(len(MockLedger.Resources)))
+			} else {
+				Expect(resolution.Metadata.ResourcePagination.Total).To(BeZero())
+			}
+			for _, resource := range resolution.Metadata.Resources {
+				resourceIds = append(resourceIds, resource.ResourceId)
+			}
+
+			if resolution.Metadata.ResourcePagination.NextCursor == "" {
+				Expect(pages).To(Equal(3))
+				break
+			}
+			query = "resourceLimit=3&resourceCursor=" + resolution.Metadata.ResourcePagination.NextCursor
+		}
+
+		// Pages keep the order of the ledger
+		var ledgerResourceIds []string
+		for i := range MockLedger.Resources {
+			ledgerResourceIds = append(ledgerResourceIds, MockLedger.Resources[i].Metadata.Id)
+		}
+		Expect(resourceIds).To(Equal(ledgerResourceIds))
+	})
+
+	It("can sort resources by version", func() {
+		resolution, err := resolveMetadata("resourceSort=version&resourceLimit=2")
+		Expect(err).To(BeNil())
+		Expect(resolution.Metadata.Resources).To(HaveLen(2))
+		Expect(resolution.Metadata.Resources[0].Version).To(Equal("1"))
+		Expect(resolution.Metadata.Resources[1].Version).To(Equal("12"))
+	})
+
+	It("sorts the whole collection before it's paginated", func() {
+		// Ids of resources are unique on the ledger, so ResourceChecksum which reuses the id of ResourceType13 is left out
+		resources := MockLedger.Resources[:len(MockLedger.Resources)-1]
+		ledger := utils.NewMockLedgerService(&testconstants.ValidDIDDoc, MockLedger.Metadata, resources)
+
+		var created []time.Time
+		query := "resourceSort=-created&resourceLimit=2"
+		for {
+			request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/1.0/identifiers/%s/metadata?%s", testconstants.ValidDid, query), nil)
+			context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, ledger)
+			Expect(didDocService.DidDocMetadataEchoHandler(context)).To(BeNil())
+			var resolution types.DidResolution
+			Expect(json.Unmarshal(rec.Body.Bytes(), &resolution)).To(BeNil())
+
+			Expect(len(resolution.Metadata.Resources)).To(BeNumerically("<=", 2))
+			for _, resource := range resolution.Metadata.Resources {
+				created = append(created, *resource.Created)
+			}
+			if resolution.Metadata.ResourcePagination.NextCursor == "" {
+				break
+			}
+			query = "resourceSort=-created&resourceLimit=2&resourceCursor=" + resolution.Metadata.ResourcePagination.NextCursor
+		}
+
+		var ledgerCreated []time.Time
+		for i := range resources {
+			ledgerCreated = append(ledgerCreated, resources[i].Metadata.Created.AsTime())
+		}
+		sort.Slice(ledgerCreated, func(i, j int) bool { return ledgerCreated[i].After(ledgerCreated[j]) })
+		Expect(created).To(HaveLen(len(ledgerCreated)))
+		for i := range created {
+			Expect(created[i]).To(BeTemporally("==", ledgerCreated[i]))
+		}
+	})
+
+	It("can select fields of resources", func() {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/1.0/identifiers/%s/metadata?resourceFields=resourceId,resourceType", testconstants.ValidDid), nil)
+		context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, MockLedger)
+		Expect(didDocService.DidDocMetadataEchoHandler(context)).To(BeNil())
+
+		var resolution struct {
+			Metadata struct {
+				Resources []map[string]any `json:"linkedResourceMetadata"`
+			} `json:"didDocumentMetadata"`
+		}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resolution)).To(BeNil())
+		Expect(resolution.Metadata.Resources).To(HaveLen(len(MockLedger.Resources)))
+		for _, resource := range resolution.Metadata.Resources {
+			Expect(resource).To(HaveLen(2))
+			Expect(resource).To(HaveKey("resourceId"))
+			Expect(resource).To(HaveKey("resourceType"))
+		}
+	})
+
+	It("can return summary of resources instead of the list", func() {
+		resolution, err := resolveMetadata("resourceMetadata=summary")
+		Expect(err).To(BeNil())
+		Expect(resolution.Metadata.Resources).To(BeNil())
+		Expect(resolution.Metadata.ResourceSummary.Total).To(Equal(len(MockLedger.Resources)))
+		Expect(resolution.Metadata.ResourceSummary.Resources[0]).To(Equal(types.ResourceSummaryEntry{
+			ResourceType: "Type1", Name: "Name", Count: 1, LatestCreated: &Resource3Created,
+		}))
+	})
+
+	It("can return summary of resources in DID resolution", func() {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/1.0/identifiers/%s?resourceMetadata=summary", testconstants.ValidDid), nil)
+		context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, MockLedger)
+		Expect(didDocService.DidDocEchoHandler(context)).To(BeNil())
+
+		var resolution types.DidResolution
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resolution)).To(BeNil())
+		Expect(resolution.Metadata.Resources).To(BeNil())
+		Expect(resolution.Metadata.ResourceSummary.Total).To(Equal(len(MockLedger.Resources)))
+	})
+
+	It("can return summary of filtered resources", func() {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/1.0/identifiers/%s?resourceType=Type2&resourceMetadata=summary", testconstants.ValidDid), nil)
+		context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, MockLedger)
+		Expect(didDocService.DidDocEchoHandler(context)).To(BeNil())
+
+		var dereferencing types.ResourceDereferencing
+		Expect(json.Unmarshal(rec.Body.Bytes(), &dereferencing)).To(BeNil())
+		Expect(dereferencing.Metadata.Resources).To(BeNil())
+		Expect(dereferencing.Metadata.ResourceSummary).To(Equal(&types.ResourceSummary{
+			Total: 2,
+			Resources: []types.ResourceSummaryEntry{
+				{ResourceType: "Type2", Name: "Name2", Count: 2, LatestCreated: &Resource4Created},
+			},
+		}))
+	})
+
+	It("can return summary of resources for every version", func() {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/1.0/identifiers/%s/versions?resourceMetadata=summary", testconstants.ValidDid), nil)
+		context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, MockLedger)
+		Expect(didDocService.DidDocAllVersionMetadataEchoHandler(context)).To(BeNil())
+
+		var dereferencing struct {
+			ContentStream types.DereferencedDidVersionsList `json:"contentStream"`
+		}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &dereferencing)).To(BeNil())
+		Expect(dereferencing.ContentStream.Versions).To(HaveLen(2))
+		for _, version := range dereferencing.ContentStream.Versions {
+			Expect(version.Resources).To(BeNil())
+			Expect(version.ResourceSummary).ToNot(BeNil())
+		}
+	})
+
+	DescribeTable("rejects invalid resource list queries", func(query string) {
+		_, err := resolveMetadata(query)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(Equal(types.NewInvalidDidUrlError(testconstants.ValidDid, types.DIDJSONLD, nil, false).Error()))
+	},
+		Entry("not supported query", "versionId=1"),
+		Entry("resourceMetadata other than summary", "resourceMetadata=true"),
+		Entry("invalid limit", "resourceLimit=-1"),
+		Entry("not supported sort", "resourceSort=size"),
+		Entry("cursor of not existent resource", "resourceCursor=ZmZmZmZmZmYtMzI5Yi00NjE0LWEzZjItZmZmZmZmZmZmZmZm"),
+	)
+})
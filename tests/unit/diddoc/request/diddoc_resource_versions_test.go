@@ -0,0 +1,85 @@
+//go:build unit
+
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	didDocService "github.com/cheqd/did-resolver/services/diddoc"
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resource versions", func() {
+	dereferenceMetadata := func(didURL string, handler echo.HandlerFunc) (*types.ResourceDereferencing, error) {
+		request := httptest.NewRequest(http.MethodGet, didURL, nil)
+		context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, MockLedger)
+
+		if err := handler(context); err != nil {
+			return nil, err
+		}
+		var dereferencing types.ResourceDereferencing
+		Expect(json.Unmarshal(rec.Body.Bytes(), &dereferencing)).To(BeNil())
+		return &dereferencing, nil
+	}
+
+	resourceIds := func(dereferencing *types.ResourceDereferencing) []string {
+		var ids []string
+		for _, resource := range *dereferencing.Metadata.Resources {
+			ids = append(ids, resource.ResourceId)
+		}
+		return ids
+	}
+
+	DescribeTable("can dereference special versions of the resource", func(query string, expectedResourceIds ...string) {
+		dereferencing, err := dereferenceMetadata(
+			fmt.Sprintf("/1.0/identifiers/%s?%s&resourceMetadata=true", testconstants.ValidDid, query),
+			didDocService.DidDocEchoHandler,
+		)
+		Expect(err).To(BeNil())
+		Expect(resourceIds(dereferencing)).To(ConsistOf(expectedResourceIds))
+	},
+		Entry("latest version", "resourceName=Name1&resourceType=string&resourceVersion=latest", ResourceIdName12),
+		Entry("first version", "resourceName=Name1&resourceType=string&resourceVersion=first", ResourceIdName1),
+		Entry("latest versions of every name and type", "resourceName=Name2&resourceVersion=latest", ResourceIdName2, ResourceIdType12, ResourceIdType2),
+		Entry("versions created before the current time", "resourceName=Name1&resourceType=string&resourceVersionTime=now", ResourceIdName1, ResourceIdName12),
+	)
+
+	It("links the resource to its other versions", func() {
+		dereferencing, err := dereferenceMetadata(
+			fmt.Sprintf("/1.0/identifiers/%s?resourceId=%s&resourceMetadata=true", testconstants.ValidDid, ResourceIdName1),
+			didDocService.DidDocEchoHandler,
+		)
+		Expect(err).To(BeNil())
+		Expect(*dereferencing.Metadata.Resources).To(HaveLen(1))
+		Expect((*dereferencing.Metadata.Resources)[0].Links).To(Equal(&types.ResourceVersionLinks{
+			Latest: testconstants.ValidDid + "?resourceName=Name1&resourceType=string&resourceVersion=latest",
+			First:  testconstants.ValidDid + "?resourceName=Name1&resourceType=string&resourceVersion=first",
+		}))
+	})
+
+	It("can fetch all versions of the resource from the oldest to the newest", func() {
+		dereferencing, err := dereferenceMetadata(
+			fmt.Sprintf("/1.0/identifiers/%s/resources/%s/versions", testconstants.ValidDid, ResourceIdName12),
+			resourceServices.ResourceVersionsEchoHandler,
+		)
+		Expect(err).To(BeNil())
+		Expect(resourceIds(dereferencing)).To(Equal([]string{ResourceIdName1, ResourceIdName12}))
+	})
+
+	It("can't fetch versions of not existent resource", func() {
+		_, err := dereferenceMetadata(
+			fmt.Sprintf("/1.0/identifiers/%s/resources/%s/versions", testconstants.ValidDid, testconstants.NotExistentIdentifier),
+			resourceServices.ResourceVersionsEchoHandler,
+		)
+		Expect(err.Error()).To(Equal(types.NewNotFoundError(testconstants.ValidDid, types.DIDJSONLD, nil, true).Error()))
+	})
+})
@@ -45,7 +45,7 @@ var _ = DescribeTable("Test Query handlers with service and relativeRef params",
 		QueriesDIDDocTestCase{
 			didURL:             fmt.Sprintf("/1.0/identifiers/%s?service=%s&relativeRef=foo", testconstants.ValidDid, testconstants.ValidServiceId),
 			resolutionType:     types.DIDJSONLD,
-			expectedResolution: types.NewServiceResult(testconstants.ValidService.ServiceEndpoint[0] + "foo"),
+			expectedResolution: types.NewServiceResult(testconstants.ValidService.ServiceEndpoint[0] + "/foo"),
 			expectedError:      nil,
 		},
 	),
@@ -63,7 +63,7 @@ var _ = DescribeTable("Test Query handlers with service and relativeRef params",
 		QueriesDIDDocTestCase{
 			didURL:             fmt.Sprintf("/1.0/identifiers/%s?versionId=%s&service=%s&relativeRef=foo", testconstants.ValidDid, testconstants.ValidVersionId, testconstants.ValidServiceId),
 			resolutionType:     types.DIDJSONLD,
-			expectedResolution: types.NewServiceResult(testconstants.ValidService.ServiceEndpoint[0] + "foo"),
+			expectedResolution: types.NewServiceResult(testconstants.ValidService.ServiceEndpoint[0] + "/foo"),
 			expectedError:      nil,
 		},
 	),
@@ -81,12 +81,48 @@ var _ = DescribeTable("Test Query handlers with service and relativeRef params",
 		QueriesDIDDocTestCase{
 			didURL:             fmt.Sprintf("/1.0/identifiers/%s?versionTime=%s&service=%s&relativeRef=foo", testconstants.ValidDid, testconstants.CreatedAfter.Format(time.RFC3339), testconstants.ValidServiceId),
 			resolutionType:     types.DIDJSONLD,
-			expectedResolution: types.NewServiceResult(testconstants.ValidService.ServiceEndpoint[0] + "foo"),
+			expectedResolution: types.NewServiceResult(testconstants.ValidService.ServiceEndpoint[0] + "/foo"),
+			expectedError:      nil,
+		},
+	),
+	Entry(
+		"Positive. ServiceType case",
+		QueriesDIDDocTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s?serviceType=%s", testconstants.ValidDid, types.LinkedDomains),
+			resolutionType:     types.DIDJSONLD,
+			expectedResolution: types.NewServiceResult(testconstants.ValidService.ServiceEndpoint[0]),
+			expectedError:      nil,
+		},
+	),
+	Entry(
+		"Positive. ServiceType + relativeRef with path and query case",
+		QueriesDIDDocTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s?serviceType=%s&relativeRef=%s", testconstants.ValidDid, types.LinkedDomains, "%2Fpath%2F..%2Fabout%3Fq%3D1"),
+			resolutionType:     types.DIDJSONLD,
+			expectedResolution: types.NewServiceResult(testconstants.ValidService.ServiceEndpoint[0] + "/about?q=1"),
 			expectedError:      nil,
 		},
 	),
 
 	// Negative Cases
+	Entry(
+		"Negative. ServiceType not found",
+		QueriesDIDDocTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s?serviceType=%s", testconstants.ValidDid, "DIDCommMessaging"),
+			resolutionType:     types.DIDJSONLD,
+			expectedResolution: nil,
+			expectedError:      types.NewNotFoundError(testconstants.ValidDid, types.DIDJSONLD, nil, true),
+		},
+	),
+	Entry(
+		"Negative. ServiceType with resource query",
+		QueriesDIDDocTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s?serviceType=%s&resourceType=%s", testconstants.ValidDid, types.LinkedDomains, "string"),
+			resolutionType:     types.DIDJSONLD,
+			expectedResolution: nil,
+			expectedError:      types.NewRepresentationNotSupportedError(testconstants.ValidDid, types.DIDJSONLD, nil, true),
+		},
+	),
 	Entry(
 		"Negative. Service not found",
 		QueriesDIDDocTestCase{
@@ -0,0 +1,103 @@
+//go:build unit
+
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	didDocService "github.com/cheqd/did-resolver/services/diddoc"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type deactivatedVersionTimeTestCase struct {
+	versionTime         time.Time
+	expectedVersionId   string
+	expectedDeactivated bool
+	expectedValidFrom   *time.Time
+	expectedValidUntil  *time.Time
+}
+
+var deactivatedMockLedger = func() utils.MockLedgerService {
+	// The second version deactivates the DID, but the ledger marks both versions as deactivated
+	metadata1 := generateMetadata(VersionId1, timestamppb.New(DidDocCreated), nil)
+	metadata1.Deactivated = true
+	metadata1.NextVersionId = VersionId2
+	metadata2 := generateMetadata(VersionId2, timestamppb.New(DidDocCreated), timestamppb.New(DidDocUpdated))
+	metadata2.Deactivated = true
+	metadata2.PreviousVersionId = VersionId1
+
+	return utils.NewMockLedgerService(
+		&testconstants.ValidDIDDoc,
+		[]*didTypes.Metadata{&metadata1, &metadata2},
+		[]resourceTypes.ResourceWithMetadata{},
+	)
+}()
+
+var _ = DescribeTable("Test versionTime query for deactivated DID", func(testCase deactivatedVersionTimeTestCase) {
+	didURL := fmt.Sprintf("/1.0/identifiers/%s?versionTime=%s", testconstants.ValidDid, testCase.versionTime.Format(time.RFC3339))
+	request := httptest.NewRequest(http.MethodGet, didURL, nil)
+	context, rec := utils.SetupEmptyContext(request, types.JSON, deactivatedMockLedger)
+
+	err := didDocService.DidDocEchoHandler(context)
+	Expect(err).To(BeNil())
+
+	var resolutionResult types.DidResolution
+	Expect(json.Unmarshal(rec.Body.Bytes(), &resolutionResult)).To(BeNil())
+	Expect(resolutionResult.Metadata.VersionId).To(Equal(testCase.expectedVersionId))
+	Expect(resolutionResult.Metadata.Deactivated).To(Equal(testCase.expectedDeactivated))
+	Expect(resolutionResult.Metadata.ValidFrom).To(Equal(testCase.expectedValidFrom))
+	Expect(resolutionResult.Metadata.ValidUntil).To(Equal(testCase.expectedValidUntil))
+},
+
+	Entry(
+		"is not deactivated before deactivation",
+		deactivatedVersionTimeTestCase{
+			versionTime:         DidDocAfterCreated,
+			expectedVersionId:   VersionId1,
+			expectedDeactivated: false,
+			expectedValidFrom:   &DidDocCreated,
+			expectedValidUntil:  &DidDocUpdated,
+		},
+	),
+
+	Entry(
+		"is deactivated after deactivation",
+		deactivatedVersionTimeTestCase{
+			versionTime:         DidDocAfterUpdated,
+			expectedVersionId:   VersionId2,
+			expectedDeactivated: true,
+			expectedValidFrom:   &DidDocUpdated,
+			expectedValidUntil:  nil,
+		},
+	),
+)
+
+var _ = Describe("Test versionId query for deactivated DID", func() {
+	It("keeps the deactivated flag of the ledger, like the version route", func() {
+		didURL := fmt.Sprintf("/1.0/identifiers/%s?versionId=%s", testconstants.ValidDid, VersionId1)
+		request := httptest.NewRequest(http.MethodGet, didURL, nil)
+		context, rec := utils.SetupEmptyContext(request, types.JSON, deactivatedMockLedger)
+
+		err := didDocService.DidDocEchoHandler(context)
+		Expect(err).To(BeNil())
+
+		var resolutionResult types.DidResolution
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resolutionResult)).To(BeNil())
+		Expect(resolutionResult.Metadata.VersionId).To(Equal(VersionId1))
+		Expect(resolutionResult.Metadata.Deactivated).To(BeTrue())
+		Expect(resolutionResult.Metadata.ValidFrom).To(BeNil())
+		Expect(resolutionResult.Metadata.ValidUntil).To(BeNil())
+	})
+})
@@ -33,9 +33,9 @@ type ResourceTestCase struct {
 
 var (
 	Data                   = []byte("{\"attr\":[\"name\",\"age\"]}")
-	Checksum               = sha256.New().Sum(Data)
+	Checksum               = sha256.Sum256(Data)
 	Data1                  = []byte("{\"attr\":[\"name\",\"age\",\"address\"]}")
-	Checksum1              = sha256.New().Sum(Data1)
+	Checksum1              = sha256.Sum256(Data1)
 	VersionId1             = uuid.New().String()
 	VersionId2             = uuid.New().String()
 	ResourceIdName1        = uuid.New().String()
@@ -141,3 +141,9 @@ func generateMetadata(versionId string, created, updated *timestamppb.Timestamp)
 		Updated:     updated,
 	}
 }
+
+func withVersionWindow(metadata *types.ResolutionDidDocMetadata, validFrom, validUntil *time.Time) *types.ResolutionDidDocMetadata {
+	metadata.ValidFrom = validFrom
+	metadata.ValidUntil = validUntil
+	return metadata
+}
@@ -0,0 +1,190 @@
+//go:build unit
+
+package fakenode_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"github.com/cheqd/did-resolver/services"
+	didDocServices "github.com/cheqd/did-resolver/services/diddoc"
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	"github.com/cheqd/did-resolver/tests/fakenode"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	fixtureDid        = "did:cheqd:testnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0"
+	fixtureVersionId2 = "cbcd7b9b-bc3e-4ccc-a7c4-1d8c4d7a2a5c"
+	fixtureNoteId     = "5e16a3f9-7c6e-40b4-89cc-f0a8c7b3e2d1"
+)
+
+func testnetEndpoint(node *fakenode.Node, role types.EndpointRole) types.Endpoint {
+	return types.Endpoint{URL: node.Address(), Timeout: 5 * time.Second, Role: role}
+}
+
+func newLedgerService(endpoints ...types.Endpoint) (services.LedgerService, *services.EndpointManager) {
+	network := types.Network{Namespace: "testnet", Endpoints: endpoints, Timeout: 5 * time.Second}
+	endpointManager := services.NewEndpointManager(types.Config{
+		EnableFallbackEndpoints: len(endpoints) > 1,
+		Networks:                []types.Network{network},
+	})
+	ledgerService := services.NewLedgerService(endpointManager)
+	Expect(ledgerService.RegisterLedger(types.DID_METHOD, network)).To(Succeed())
+	return ledgerService, endpointManager
+}
+
+var _ = Describe("LedgerService with fake cheqd node", func() {
+	var node *fakenode.Node
+
+	BeforeEach(func() {
+		var err error
+		node, err = fakenode.Start(fakenode.DefaultFixtures())
+		Expect(err).To(BeNil())
+		DeferCleanup(node.Stop)
+	})
+
+	It("queries the latest version of DID Document", func() {
+		ledgerService, _ := newLedgerService(testnetEndpoint(node, types.EndpointRolePrimary))
+
+		didDoc, err := ledgerService.QueryDIDDoc(fixtureDid, "")
+		Expect(err).To(BeNil())
+		Expect(didDoc.Metadata.VersionId).To(Equal(fixtureVersionId2))
+		Expect(didDoc.DidDoc.Service).To(HaveLen(1))
+	})
+
+	It("maps not found DID Document to notFound error", func() {
+		ledgerService, _ := newLedgerService(testnetEndpoint(node, types.EndpointRolePrimary))
+
+		_, err := ledgerService.QueryDIDDoc("did:cheqd:testnet:b9d1e8f2-6a1c-4c1e-9a3b-2f6d8c4e7a10", "")
+		Expect(err).ToNot(BeNil())
+		Expect(err.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("reads the collection of resources page by page", func() {
+		fixtures := fakenode.DefaultFixtures()
+		for i := range 250 {
+			data := []byte(fmt.Sprintf("resource %d", i))
+			fixtures.Resources = append(fixtures.Resources, &resourceTypes.ResourceWithMetadata{
+				Resource: &resourceTypes.Resource{Data: data},
+				Metadata: &resourceTypes.Metadata{
+					CollectionId: "c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0",
+					Id:           fmt.Sprintf("00000000-0000-4000-8000-%012d", i),
+					Name:         fmt.Sprintf("Resource %d", i),
+					ResourceType: "String",
+					MediaType:    "text/plain",
+					Created:      timestamppb.New(time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC)),
+				},
+			})
+		}
+		bigNode, err := fakenode.Start(fixtures)
+		Expect(err).To(BeNil())
+		DeferCleanup(bigNode.Stop)
+		ledgerService, _ := newLedgerService(testnetEndpoint(bigNode, types.EndpointRolePrimary))
+
+		requests := bigNode.Requests()
+		resources, iErr := ledgerService.QueryCollectionResources(fixtureDid)
+		Expect(iErr).To(BeNil())
+		Expect(resources).To(HaveLen(253))
+		Expect(bigNode.Requests() - requests).To(Equal(int64(3)))
+	})
+
+	It("resolves DID Document end to end through the HTTP handler", func() {
+		ledgerService, _ := newLedgerService(testnetEndpoint(node, types.EndpointRolePrimary))
+		request := httptest.NewRequest(http.MethodGet, "/1.0/identifiers/"+fixtureDid, nil)
+		context, rec := utils.SetupEmptyContext(request, types.JSON, ledgerService)
+
+		Expect(didDocServices.DidDocEchoHandler(context)).To(Succeed())
+		var resolution types.DidResolution
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resolution)).To(Succeed())
+		Expect(resolution.Did.Id).To(Equal(fixtureDid))
+		Expect(resolution.Metadata.VersionId).To(Equal(fixtureVersionId2))
+		Expect(resolution.Metadata.Resources).To(HaveLen(3))
+	})
+
+	It("dereferences resource data end to end through the HTTP handler", func() {
+		ledgerService, _ := newLedgerService(testnetEndpoint(node, types.EndpointRolePrimary))
+		request := httptest.NewRequest(http.MethodGet, "/1.0/identifiers/"+fixtureDid+"/resources/"+fixtureNoteId, nil)
+		context, rec := utils.SetupEmptyContext(request, "*/*", ledgerService)
+
+		Expect(resourceServices.ResourceDataEchoHandler(context)).To(Succeed())
+		Expect(rec.Body.String()).To(Equal("Hello, cheqd!"))
+		Expect(rec.Header().Get("Content-Type")).To(Equal("text/plain"))
+	})
+
+	It("passes health check if the node responds", func() {
+		_, endpointManager := newLedgerService(testnetEndpoint(node, types.EndpointRolePrimary))
+
+		network, err := endpointManager.GetHealthyEndpoint("testnet")
+		Expect(err).To(BeNil())
+		Expect(network.Endpoints[0].URL).To(Equal(node.Address()))
+	})
+
+	It("reports health of each endpoint on one-off check", func() {
+		stopped, err := fakenode.Start(fakenode.DefaultFixtures())
+		Expect(err).To(BeNil())
+		stopped.Stop()
+
+		checks := services.CheckEndpoints(types.Config{Networks: []types.Network{{
+			Namespace: "testnet",
+			Endpoints: []types.Endpoint{testnetEndpoint(node, types.EndpointRolePrimary), testnetEndpoint(stopped, types.EndpointRoleFallback)},
+		}}})
+		Expect(checks).To(HaveLen(2))
+		Expect(checks[0].Healthy).To(BeTrue())
+		Expect(checks[1].Healthy).To(BeFalse())
+		Expect(checks[1].Endpoint.Role).To(Equal(types.EndpointRoleFallback))
+	})
+
+	Context("with fallback node", func() {
+		var fallback *fakenode.Node
+
+		BeforeEach(func() {
+			var err error
+			fallback, err = fakenode.Start(fakenode.DefaultFixtures())
+			Expect(err).To(BeNil())
+			DeferCleanup(fallback.Stop)
+		})
+
+		It("skips primary node which fails health check on startup", func() {
+			node.FailWith(status.Error(codes.Unavailable, "node is syncing"))
+			ledgerService, endpointManager := newLedgerService(
+				testnetEndpoint(node, types.EndpointRolePrimary), testnetEndpoint(fallback, types.EndpointRoleFallback),
+			)
+
+			network, err := endpointManager.GetHealthyEndpoint("testnet")
+			Expect(err).To(BeNil())
+			Expect(network.Endpoints[0].URL).To(Equal(fallback.Address()))
+
+			requests := fallback.Requests()
+			_, iErr := ledgerService.QueryDIDDoc(fixtureDid, "")
+			Expect(iErr).To(BeNil())
+			Expect(fallback.Requests() - requests).To(Equal(int64(1)))
+		})
+
+		It("fails over to fallback node when primary node is marked unhealthy", func() {
+			ledgerService, endpointManager := newLedgerService(
+				testnetEndpoint(node, types.EndpointRolePrimary), testnetEndpoint(fallback, types.EndpointRoleFallback),
+			)
+			node.Stop()
+			primary, err := endpointManager.GetHealthyEndpoint("testnet")
+			Expect(err).To(BeNil())
+			endpointManager.MarkEndpointUnhealthy(*primary)
+
+			requests := fallback.Requests()
+			resource, iErr := ledgerService.QueryResource(fixtureDid, fixtureNoteId)
+			Expect(iErr).To(BeNil())
+			Expect(string(resource.Resource.Data)).To(Equal("Hello, cheqd!"))
+			Expect(fallback.Requests() - requests).To(Equal(int64(1)))
+		})
+	})
+})
@@ -0,0 +1,152 @@
+//go:build unit
+
+package fakenode_test
+
+import (
+	"net/http"
+
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/tests/fakenode"
+	"github.com/cheqd/did-resolver/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const devnetDid = "did:cheqd:devnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0"
+
+func startNode() *fakenode.Node {
+	node, err := fakenode.Start(fakenode.DefaultFixtures())
+	Expect(err).To(BeNil())
+	DeferCleanup(node.Stop)
+	return node
+}
+
+func networksConfig(networks ...types.Network) types.Config {
+	return types.Config{Networks: networks}
+}
+
+func network(namespace string, endpoints ...types.Endpoint) types.Network {
+	return types.Network{Namespace: namespace, Endpoints: endpoints, Timeout: endpoints[0].Timeout}
+}
+
+var _ = Describe("LedgerService reconfiguration", func() {
+	var node *fakenode.Node
+
+	BeforeEach(func() {
+		node = startNode()
+	})
+
+	It("moves queries to the new endpoint", func() {
+		ledgerService, endpointManager := newLedgerService(testnetEndpoint(node, types.EndpointRolePrimary))
+		newNode := startNode()
+
+		Expect(ledgerService.Reconfigure(types.DID_METHOD, networksConfig(
+			network("testnet", testnetEndpoint(newNode, types.EndpointRolePrimary)),
+		))).To(Succeed())
+
+		requests := node.Requests()
+		_, err := ledgerService.QueryDIDDoc(fixtureDid, "")
+		Expect(err).To(BeNil())
+		Expect(node.Requests()).To(Equal(requests))
+		Expect(endpointManager.ListEndpoints()).To(HaveLen(1))
+		Expect(endpointManager.ListEndpoints()[0].Endpoint.URL).To(Equal(newNode.Address()))
+	})
+
+	It("registers new namespaces and unregisters removed ones", func() {
+		ledgerService, _ := newLedgerService(testnetEndpoint(node, types.EndpointRolePrimary))
+
+		Expect(ledgerService.Reconfigure(types.DID_METHOD, networksConfig(
+			network("devnet", testnetEndpoint(node, types.EndpointRolePrimary)),
+		))).To(Succeed())
+
+		Expect(ledgerService.GetNamespaces()).To(ConsistOf("devnet"))
+		_, err := ledgerService.QueryDIDDoc(fixtureDid, "")
+		Expect(err.Code).To(Equal(http.StatusBadRequest))
+		// The fake node serves only testnet DIDs, so the DID is looked up but not found
+		_, err = ledgerService.QueryDIDDoc(devnetDid, "")
+		Expect(err.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("keeps health of the unchanged endpoints", func() {
+		ledgerService, endpointManager := newLedgerService(testnetEndpoint(node, types.EndpointRolePrimary))
+		_, err := endpointManager.ForceEndpointHealth("testnet", node.Address(), false)
+		Expect(err).To(BeNil())
+		fallbackNode := startNode()
+
+		Expect(ledgerService.Reconfigure(types.DID_METHOD, networksConfig(
+			network("testnet", testnetEndpoint(node, types.EndpointRolePrimary), testnetEndpoint(fallbackNode, types.EndpointRoleFallback)),
+		))).To(Succeed())
+
+		statuses := endpointManager.ListEndpoints()
+		Expect(statuses).To(HaveLen(2))
+		Expect(statuses[0].Endpoint.Role).To(Equal(types.EndpointRoleFallback))
+		Expect(statuses[0].Healthy).To(BeTrue())
+		Expect(statuses[1].Endpoint.Role).To(Equal(types.EndpointRolePrimary))
+		Expect(statuses[1].Drained).To(BeTrue())
+
+		requests := fallbackNode.Requests()
+		_, queryErr := ledgerService.QueryDIDDoc(fixtureDid, "")
+		Expect(queryErr).To(BeNil())
+		Expect(fallbackNode.Requests()).To(BeNumerically(">", requests))
+	})
+
+	It("keeps the current endpoints if none of the new ones is healthy", func() {
+		ledgerService, endpointManager := newLedgerService(testnetEndpoint(node, types.EndpointRolePrimary))
+		unhealthyNode := startNode()
+		unhealthyNode.FailWith(status.Error(codes.Unavailable, "node is syncing"))
+
+		err := ledgerService.Reconfigure(types.DID_METHOD, networksConfig(
+			network("testnet", testnetEndpoint(unhealthyNode, types.EndpointRolePrimary)),
+		))
+		Expect(err).To(MatchError(services.ErrNoHealthyEndpoints))
+
+		Expect(endpointManager.ListEndpoints()[0].Endpoint.URL).To(Equal(node.Address()))
+		_, queryErr := ledgerService.QueryDIDDoc(fixtureDid, "")
+		Expect(queryErr).To(BeNil())
+	})
+
+	It("rejects the change while endpoints added by the admin API are in use", func() {
+		ledgerService, endpointManager := newLedgerService(testnetEndpoint(node, types.EndpointRolePrimary))
+		fallbackNode := startNode()
+		_, err := endpointManager.AddEndpoint("testnet", testnetEndpoint(fallbackNode, types.EndpointRoleFallback))
+		Expect(err).To(BeNil())
+		newNode := startNode()
+
+		Expect(ledgerService.Reconfigure(types.DID_METHOD, networksConfig(
+			network("testnet", testnetEndpoint(newNode, types.EndpointRolePrimary)),
+		))).To(MatchError(services.ErrEndpointsChangedByAdmin))
+		Expect(endpointManager.ListEndpoints()).To(HaveLen(2))
+
+		// Once the change is reverted, the config is applied
+		_, err = endpointManager.RemoveEndpoint("testnet", fallbackNode.Address())
+		Expect(err).To(BeNil())
+		Expect(ledgerService.Reconfigure(types.DID_METHOD, networksConfig(
+			network("testnet", testnetEndpoint(newNode, types.EndpointRolePrimary)),
+		))).To(Succeed())
+		Expect(endpointManager.ListEndpoints()).To(HaveLen(1))
+		Expect(endpointManager.ListEndpoints()[0].Endpoint.URL).To(Equal(newNode.Address()))
+	})
+
+	It("rejects the change while endpoints removed by the admin API are missing", func() {
+		fallbackNode := startNode()
+		ledgerService, endpointManager := newLedgerService(
+			testnetEndpoint(node, types.EndpointRolePrimary), testnetEndpoint(fallbackNode, types.EndpointRoleFallback),
+		)
+		_, err := endpointManager.RemoveEndpoint("testnet", fallbackNode.Address())
+		Expect(err).To(BeNil())
+
+		Expect(ledgerService.Reconfigure(types.DID_METHOD, networksConfig(
+			network("testnet", testnetEndpoint(node, types.EndpointRolePrimary)),
+		))).To(MatchError(services.ErrEndpointsChangedByAdmin))
+	})
+
+	It("rejects networks without endpoints", func() {
+		ledgerService, _ := newLedgerService(testnetEndpoint(node, types.EndpointRolePrimary))
+
+		err := ledgerService.Reconfigure(types.DID_METHOD, networksConfig(types.Network{Namespace: "testnet"}))
+		Expect(err).To(HaveOccurred())
+		Expect(ledgerService.GetNamespaces()).To(ConsistOf("testnet"))
+	})
+})
@@ -0,0 +1,15 @@
+//go:build unit
+
+package fakenode_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFakeNode(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "[Unit Test]: Ledger Service with fake cheqd node")
+}
@@ -0,0 +1,120 @@
+//go:build unit
+
+package fuzz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+	"github.com/labstack/echo/v4"
+)
+
+var didUrlSeeds = []string{
+	"did:cheqd:testnet:fafdsffq11213343/path-to-s/ome-external-resource?query#key1???",
+	"did:cheqd:mainnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0/resources/5e16a3f9-7c6e-40b4-89cc-f0a8c7b3e2d1",
+	"did:cheqd:testnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0?resourceName=PersonSchema&resourceType=JSONSchema2020",
+	"did:cheqd:testnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0#key-1",
+	"did:cheqd:zF7rhDBfUt9d1gJPjx7s1J",
+	"did:cheqd:testnet:x#a#b",
+	"x#y#did:cheqd:testnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0",
+	"did:cheqd:testnet:abc?",
+	"",
+}
+
+func FuzzTrySplitDIDUrl(f *testing.F) {
+	for _, seed := range didUrlSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, didUrl string) {
+		did, path, query, fragment, err := utils.TrySplitDIDUrl(didUrl)
+		if err != nil {
+			return
+		}
+		// All parts come from the DID URL in order, nothing is skipped
+		if !strings.HasPrefix(didUrl, did+path) {
+			t.Fatalf("%q is split into DID %q and path %q, which are not its prefix", didUrl, did, path)
+		}
+		// Joining drops only the empty query, so splitting it again gives the same parts
+		joined := utils.JoinDIDUrl(did, path, query, fragment)
+		did2, path2, query2, fragment2, err := utils.TrySplitDIDUrl(joined)
+		if err != nil || did2 != did || path2 != path || query2 != query || fragment2 != fragment {
+			t.Fatalf("%q is joined back as %q, which is split differently", didUrl, joined)
+		}
+		_ = utils.ValidateDIDUrl(didUrl, types.DID_METHOD, []string{"mainnet", "testnet"})
+	})
+}
+
+func FuzzTrySplitDID(f *testing.F) {
+	for _, seed := range didUrlSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, did string) {
+		method, namespace, id, err := utils.TrySplitDID(did)
+		if err != nil {
+			return
+		}
+		if joined := utils.JoinDID(method, namespace, id); joined != did {
+			t.Fatalf("%q is joined back as %q", did, joined)
+		}
+		_ = utils.ValidateDID(did, types.DID_METHOD, []string{"mainnet", "testnet"})
+	})
+}
+
+func FuzzParseResourceDidUrl(f *testing.F) {
+	for _, seed := range didUrlSeeds {
+		f.Add(seed)
+	}
+	f.Add("https://resolver.cheqd.net/1.0/identifiers/did:cheqd:testnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0/resources/5e16a3f9-7c6e-40b4-89cc-f0a8c7b3e2d1")
+	f.Add("https://resolver.cheqd.net/1.0/identifiers/%zz")
+	f.Fuzz(func(t *testing.T, didUrl string) {
+		_, _ = types.ParseResourceDidUrl(didUrl)
+	})
+}
+
+func FuzzPrepareQueries(f *testing.F) {
+	f.Add("resourceName=PersonSchema&resourceType=JSONSchema2020")
+	f.Add("service=website%23key-1")
+	f.Add("a=b%23c&d=e")
+	f.Add("%23")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, rawQuery string) {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.URL.RawQuery = rawQuery
+		context := echo.New().NewContext(request, httptest.NewRecorder())
+
+		query, flag := services.PrepareQueries(context)
+		if flag == nil {
+			if query != rawQuery {
+				t.Fatalf("%q is prepared as %q without fragment", rawQuery, query)
+			}
+			return
+		}
+		// The fragment is the escaped tail of the query after the last %23
+		if query+*flag != rawQuery || !strings.HasPrefix(*flag, "%23") || strings.Contains(*flag, "&") {
+			t.Fatalf("%q is prepared as query %q and fragment %q", rawQuery, query, *flag)
+		}
+	})
+}
+
+func FuzzGetPriorityContentType(f *testing.F) {
+	f.Add("application/did+ld+json", false)
+	f.Add(`application/ld+json;profile="https://w3id.org/did-resolution", */*;q=0.1`, false)
+	f.Add(`*/*;profile="https://w3id.org/did-url-dereferencing"`, true)
+	f.Add("text/html;q=0.9,application/json;q=0.8", true)
+	f.Add(";;,,q=", false)
+	f.Add("", true)
+	f.Fuzz(func(t *testing.T, acceptHeader string, resource bool) {
+		contentType, _ := services.GetPriorityContentType(acceptHeader, resource)
+		if !resource && contentType.IsSupported() {
+			return
+		}
+		if contentType == "" && strings.TrimSpace(acceptHeader) == "" {
+			t.Fatalf("empty Accept header has no default content type")
+		}
+	})
+}
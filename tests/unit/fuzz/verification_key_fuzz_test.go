@@ -0,0 +1,145 @@
+//go:build unit
+
+package fuzz
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"testing"
+
+	"github.com/cheqd/did-resolver/utils"
+	"github.com/mr-tron/base58"
+)
+
+// jwkObject converts the generated JWK to a JSON object, the way it's stored in DID Documents
+func jwkObject(t *testing.T, key interface{}) interface{} {
+	data, err := json.Marshal(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var object interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		t.Fatal(err)
+	}
+	return object
+}
+
+// FuzzVerificationKeyRoundTrip checks that 2018 -> 2020 -> JWK -> 2018 and the opposite way are the identity
+func FuzzVerificationKeyRoundTrip(f *testing.F) {
+	f.Add(bytes.Repeat([]byte{0}, ed25519.PublicKeySize))
+	f.Add(bytes.Repeat([]byte{0xff}, ed25519.PublicKeySize))
+	f.Add([]byte("0123456789abcdef0123456789abcdef"))
+	f.Fuzz(func(t *testing.T, seed []byte) {
+		if len(seed) < ed25519.PublicKeySize {
+			return
+		}
+		publicKeyBase58 := base58.Encode(seed[:ed25519.PublicKeySize])
+
+		publicKeyMultibase, err := utils.Ed25519VerificationKey2018ToEd25519VerificationKey2020(publicKeyBase58)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKeyJwk, err := utils.Ed25519VerificationKey2020ToJSONWebKey2020(publicKeyMultibase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roundTrip, err := utils.JSONWebKey2020ToEd25519VerificationKey2018(jwkObject(t, publicKeyJwk))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if roundTrip != publicKeyBase58 {
+			t.Fatalf("2018 -> 2020 -> JWK -> 2018: %s became %s", publicKeyBase58, roundTrip)
+		}
+
+		publicKeyJwk, err = utils.Ed25519VerificationKey2018ToJSONWebKey2020(publicKeyBase58)
+		if err != nil {
+			t.Fatal(err)
+		}
+		multibaseRoundTrip, err := utils.JSONWebKey2020ToEd25519VerificationKey2020(jwkObject(t, publicKeyJwk))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if multibaseRoundTrip != publicKeyMultibase {
+			t.Fatalf("2018 -> JWK -> 2020: expected %s, got %s", publicKeyMultibase, multibaseRoundTrip)
+		}
+		roundTrip, err = utils.Ed25519VerificationKey2020ToEd25519VerificationKey2018(multibaseRoundTrip)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if roundTrip != publicKeyBase58 {
+			t.Fatalf("2020 -> 2018: %s became %s", publicKeyBase58, roundTrip)
+		}
+	})
+}
+
+func FuzzEd25519VerificationKey2018(f *testing.F) {
+	f.Add("6fYkiuzNvu5THPLV5PKc1b7NyCWQ9bJa2rnLhfRxiYUK")
+	f.Add("")
+	f.Add("0OIl")
+	f.Fuzz(func(t *testing.T, publicKeyBase58 string) {
+		publicKeyMultibase, err := utils.Ed25519VerificationKey2018ToEd25519VerificationKey2020(publicKeyBase58)
+		_, jwkErr := utils.Ed25519VerificationKey2018ToJSONWebKey2020(publicKeyBase58)
+		if (err == nil) != (jwkErr == nil) {
+			t.Fatalf("%q is converted inconsistently: %v, %v", publicKeyBase58, err, jwkErr)
+		}
+		if err != nil {
+			return
+		}
+		roundTrip, err := utils.Ed25519VerificationKey2020ToEd25519VerificationKey2018(publicKeyMultibase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if roundTrip != publicKeyBase58 {
+			t.Fatalf("2018 -> 2020 -> 2018: %s became %s", publicKeyBase58, roundTrip)
+		}
+	})
+}
+
+func FuzzEd25519VerificationKey2020(f *testing.F) {
+	f.Add("z6Mkk7ooKAEpGSZvPtBBkxHSrgfNnmnFZUYvishGXwPydmFh")
+	f.Add("z")
+	f.Add("f00")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, publicKeyMultibase string) {
+		publicKeyBase58, err := utils.Ed25519VerificationKey2020ToEd25519VerificationKey2018(publicKeyMultibase)
+		_, jwkErr := utils.Ed25519VerificationKey2020ToJSONWebKey2020(publicKeyMultibase)
+		if (err == nil) != (jwkErr == nil) {
+			t.Fatalf("%q is converted inconsistently: %v, %v", publicKeyMultibase, err, jwkErr)
+		}
+		if err != nil {
+			return
+		}
+		roundTrip, err := utils.Ed25519VerificationKey2018ToEd25519VerificationKey2020(publicKeyBase58)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if roundTrip != publicKeyMultibase {
+			t.Fatalf("2020 -> 2018 -> 2020: %s became %s", publicKeyMultibase, roundTrip)
+		}
+	})
+}
+
+func FuzzJSONWebKey2020(f *testing.F) {
+	f.Add(`{"crv":"Ed25519","kid":"_Qq0UL2Fq651Q0Fjd6TvnYE-faHiOpRlPVQcY_-tA4A","kty":"OKP","x":"VCpo2LMLhn6iWku8MKvSLg2ZAoC-nlOyPVQaO3FxVeQ"}`)
+	f.Add(`{"x":42}`)
+	f.Add(`[]`)
+	f.Add(`"x"`)
+	f.Fuzz(func(t *testing.T, data string) {
+		var publicKeyJwk interface{}
+		if err := json.Unmarshal([]byte(data), &publicKeyJwk); err != nil {
+			return
+		}
+		publicKeyBase58, err := utils.JSONWebKey2020ToEd25519VerificationKey2018(publicKeyJwk)
+		_, multibaseErr := utils.JSONWebKey2020ToEd25519VerificationKey2020(publicKeyJwk)
+		if (err == nil) != (multibaseErr == nil) {
+			t.Fatalf("%s is converted inconsistently: %v, %v", data, err, multibaseErr)
+		}
+		if err != nil {
+			return
+		}
+		if publicKey, err := base58.Decode(publicKeyBase58); err != nil || len(publicKey) != ed25519.PublicKeySize {
+			t.Fatalf("%s is converted to a key of wrong size", data)
+		}
+	})
+}
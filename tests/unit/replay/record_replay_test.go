@@ -0,0 +1,147 @@
+//go:build unit
+
+package replay_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	"github.com/cheqd/did-resolver/services"
+	"github.com/cheqd/did-resolver/tests/fakenode"
+	"github.com/cheqd/did-resolver/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	fixtureDid        = "did:cheqd:testnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0"
+	fixtureVersionId  = "0ce23d04-5b67-4ea6-a315-788588e53f4e"
+	fixtureNoteId     = "5e16a3f9-7c6e-40b4-89cc-f0a8c7b3e2d1"
+	missingDid        = "did:cheqd:testnet:b9d1e8f2-6a1c-4c1e-9a3b-2f6d8c4e7a10"
+	missingResourceId = "3a8c8e62-1f3b-4a5e-9c1d-7f2e6b4a9d01"
+)
+
+var didUrls = []string{
+	fixtureDid,
+	fixtureDid + "?versionId=" + fixtureVersionId,
+	fixtureDid + "#key-1",
+	fixtureDid + "?resourceName=PersonSchema&resourceType=JSONSchema2020",
+	fixtureDid + types.RESOURCE_PATH + fixtureNoteId,
+	fixtureDid + types.RESOURCE_PATH + missingResourceId,
+	fixtureDid + "/metadata?resourceLimit=1",
+	fixtureDid + "/metadata?resourceLimit=1&resourceCursor=" + base64.RawURLEncoding.EncodeToString([]byte("1")),
+	missingDid,
+}
+
+func startLedgerService() services.LedgerService {
+	node, err := fakenode.Start(fakenode.DefaultFixtures())
+	Expect(err).To(BeNil())
+	DeferCleanup(node.Stop)
+
+	network := types.Network{
+		Namespace: "testnet",
+		Endpoints: []types.Endpoint{{URL: node.Address(), Timeout: 5 * time.Second, Role: types.EndpointRolePrimary}},
+	}
+	ledgerService := services.NewLedgerService(services.NewEndpointManager(types.Config{Networks: []types.Network{network}}))
+	Expect(ledgerService.RegisterLedger(types.DID_METHOD, network)).To(Succeed())
+	return ledgerService
+}
+
+func record(path string) {
+	recordingLedgerService, err := services.NewRecordingLedgerService(startLedgerService(), path)
+	Expect(err).To(BeNil())
+	defer recordingLedgerService.Close()
+
+	server := newServer(recordingLedgerService)
+	for _, didUrl := range didUrls {
+		serve(server, didUrl, "")
+	}
+}
+
+func mustMarshal(didDoc *didTypes.DidDocWithMetadata) []byte {
+	data, err := protojson.Marshal(didDoc)
+	Expect(err).To(BeNil())
+	return data
+}
+
+var _ = Describe("Record and replay", func() {
+	var recordingPath string
+
+	BeforeEach(func() {
+		recordingPath = filepath.Join(GinkgoT().TempDir(), "ledger.jsonl")
+	})
+
+	It("replays the same responses as the recorded ledger", func() {
+		live := newServer(startLedgerService())
+		record(recordingPath)
+
+		recording, err := services.ReadLedgerRecording(recordingPath)
+		Expect(err).To(BeNil())
+		Expect(recording.Namespaces).To(Equal([]string{"testnet"}))
+		replayed := newServer(services.NewReplayLedgerService(recording))
+
+		for _, didUrl := range didUrls {
+			expected := serve(live, didUrl, "")
+			actual := serve(replayed, didUrl, "")
+			Expect(actual.Code).To(Equal(expected.Code), didUrl)
+			Expect(actual.Header().Get("Content-Type")).To(Equal(expected.Header().Get("Content-Type")), didUrl)
+			Expect(withoutRetrieved(actual.Body.Bytes())).To(Equal(withoutRetrieved(expected.Body.Bytes())), didUrl)
+		}
+	})
+
+	It("records errors with gRPC status of the ledger", func() {
+		record(recordingPath)
+		recording, err := services.ReadLedgerRecording(recordingPath)
+		Expect(err).To(BeNil())
+
+		replay := services.NewReplayLedgerService(recording)
+		_, identityErr := replay.QueryDIDDoc(missingDid, "")
+		Expect(identityErr).NotTo(BeNil())
+		Expect(identityErr.Code).To(Equal(types.NotFoundHttpCode))
+		Expect(identityErr.Message).To(Equal("notFound"))
+		Expect(status.Code(identityErr.Internal)).To(Equal(codes.NotFound))
+
+		_, identityErr = replay.QueryResource(fixtureDid, missingResourceId)
+		Expect(identityErr).NotTo(BeNil())
+		Expect(identityErr.IsDereferencing).To(BeTrue())
+		Expect(status.Code(identityErr.Internal)).To(Equal(codes.NotFound))
+	})
+
+	It("fails queries which weren't recorded", func() {
+		replay := services.NewReplayLedgerService(&services.LedgerRecording{Namespaces: []string{"testnet"}})
+
+		_, err := replay.QueryDIDDoc(fixtureDid, "")
+		Expect(err).NotTo(BeNil())
+		Expect(err.Message).To(Equal("internalError"))
+		Expect(serve(newServer(replay), fixtureDid, "").Code).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("serves repeated queries in the recorded order and repeats the last response", func() {
+		before := &didTypes.DidDocWithMetadata{DidDoc: &didTypes.DidDoc{Id: fixtureDid}, Metadata: &didTypes.Metadata{VersionId: "1"}}
+		after := &didTypes.DidDocWithMetadata{DidDoc: &didTypes.DidDoc{Id: fixtureDid}, Metadata: &didTypes.Metadata{VersionId: "2"}}
+		replay := services.NewReplayLedgerService(&services.LedgerRecording{Records: []services.LedgerRecord{
+			{Query: services.QueryDIDDocRecord, Did: fixtureDid, Response: mustMarshal(before)},
+			{Query: services.QueryDIDDocRecord, Did: fixtureDid, Response: mustMarshal(after)},
+		}})
+
+		for _, versionId := range []string{"1", "2", "2"} {
+			didDoc, err := replay.QueryDIDDoc(fixtureDid, "")
+			Expect(err).To(BeNil())
+			Expect(didDoc.Metadata.VersionId).To(Equal(versionId))
+		}
+	})
+
+	It("rejects recordings of unknown version", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "future.jsonl")
+		Expect(writeFile(path, `{"version":2,"namespaces":["testnet"]}`)).To(Succeed())
+
+		_, err := services.ReadLedgerRecording(path)
+		Expect(err).To(MatchError(ContainSubstring("unsupported recording version 2")))
+	})
+})
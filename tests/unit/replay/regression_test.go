@@ -0,0 +1,114 @@
+//go:build unit
+
+package replay_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+
+	"github.com/cheqd/did-resolver/services"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// RegressionCase is a request replayed against a ledger recording from testdata, e.g. from an incident
+type RegressionCase struct {
+	recording   string
+	didUrl      string
+	accept      string
+	status      int
+	contentType string
+	// Error of resolution or dereferencing metadata, if any
+	error string
+	body  string
+}
+
+func resolutionError(body []byte) string {
+	var result map[string]map[string]any
+	if json.Unmarshal(body, &result) != nil {
+		return ""
+	}
+	for _, key := range []string{"didResolutionMetadata", "dereferencingMetadata"} {
+		if err, ok := result[key]["error"].(string); ok {
+			return err
+		}
+	}
+	return ""
+}
+
+var _ = DescribeTable("Replayed ledger recordings", func(testCase RegressionCase) {
+	recording, err := services.ReadLedgerRecording(filepath.Join("testdata", testCase.recording))
+	Expect(err).To(BeNil())
+
+	recorder := serve(newServer(services.NewReplayLedgerService(recording)), testCase.didUrl, testCase.accept)
+	Expect(recorder.Code).To(Equal(testCase.status), recorder.Body.String())
+	if testCase.contentType != "" {
+		Expect(recorder.Header().Get("Content-Type")).To(Equal(testCase.contentType))
+	}
+	Expect(resolutionError(recorder.Body.Bytes())).To(Equal(testCase.error))
+	if testCase.body != "" {
+		Expect(recorder.Body.String()).To(Equal(testCase.body))
+	}
+},
+
+	Entry(
+		"resolves the latest DID Document",
+		RegressionCase{
+			recording:   "ledger.jsonl",
+			didUrl:      fixtureDid,
+			status:      http.StatusOK,
+			contentType: `application/ld+json;profile="https://w3id.org/did-resolution"`,
+		},
+	),
+
+	Entry(
+		"resolves the version of DID Document",
+		RegressionCase{
+			recording: "ledger.jsonl",
+			didUrl:    fixtureDid + "?versionId=" + fixtureVersionId,
+			status:    http.StatusOK,
+		},
+	),
+
+	Entry(
+		"dereferences the latest resource by name and type",
+		RegressionCase{
+			recording:   "ledger.jsonl",
+			didUrl:      fixtureDid + "?resourceName=PersonSchema&resourceType=JSONSchema2020",
+			status:      http.StatusOK,
+			contentType: "application/json",
+		},
+	),
+
+	Entry(
+		"dereferences resource data",
+		RegressionCase{
+			recording:   "ledger.jsonl",
+			didUrl:      fixtureDid + "/resources/" + fixtureNoteId,
+			status:      http.StatusOK,
+			contentType: "text/plain",
+			body:        "Hello, cheqd!",
+		},
+	),
+
+	Entry(
+		"maps NotFound status of missing resource to notFound",
+		RegressionCase{
+			recording: "ledger.jsonl",
+			didUrl:    fixtureDid + "/resources/" + missingResourceId,
+			status:    http.StatusNotFound,
+			error:     "notFound",
+		},
+	),
+
+	Entry(
+		"maps NotFound status of missing DID to notFound",
+		RegressionCase{
+			recording: "ledger.jsonl",
+			didUrl:    missingDid,
+			status:    http.StatusNotFound,
+			error:     "notFound",
+		},
+	),
+)
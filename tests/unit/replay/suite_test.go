@@ -0,0 +1,79 @@
+//go:build unit
+
+package replay_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cheqd/did-resolver/services"
+	didDocServices "github.com/cheqd/did-resolver/services/diddoc"
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	"github.com/cheqd/did-resolver/types"
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestReplay(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "[Unit Test]: Record and Replay Ledger Services")
+}
+
+// newServer routes requests the same way as the resolver, with the given ledger service
+func newServer(ledgerService services.LedgerServiceI) *echo.Echo {
+	didService := services.NewDIDDocService(types.DID_METHOD, ledgerService)
+	resourceService := services.NewResourceService(types.DID_METHOD, ledgerService)
+
+	e := echo.New()
+	e.HTTPErrorHandler = services.CustomHTTPErrorHandler
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return next(services.ResolverContext{
+				Context:         c,
+				LedgerService:   ledgerService,
+				DidDocService:   didService,
+				ResourceService: resourceService,
+			})
+		}
+	})
+	didDocServices.SetRoutes(e)
+	resourceServices.SetRoutes(e)
+	return e
+}
+
+func serve(e *echo.Echo, didUrl string, accept string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, types.RESOLVER_PATH+strings.ReplaceAll(didUrl, "#", "%23"), nil)
+	if accept != "" {
+		request.Header.Set(echo.HeaderAccept, accept)
+	}
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// withoutRetrieved removes the time of resolution from JSON responses, which differs between runs
+func withoutRetrieved(body []byte) string {
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+	for _, key := range []string{"didResolutionMetadata", "dereferencingMetadata"} {
+		if object, ok := value.(map[string]any); ok {
+			if metadata, ok := object[key].(map[string]any); ok {
+				delete(metadata, "retrieved")
+			}
+		}
+	}
+	data, err := json.Marshal(value)
+	Expect(err).To(BeNil())
+	return string(data)
+}
+
+func writeFile(path string, content string) error {
+	return os.WriteFile(path, []byte(content), 0o644)
+}
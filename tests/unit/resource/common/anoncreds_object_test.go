@@ -0,0 +1,117 @@
+//go:build unit
+
+package common
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type anonCredsObjectTestCase struct {
+	resourceType   string
+	data           string
+	expectedObject types.AnonCredsObjectI
+	expectedError  bool
+}
+
+var anonCredsCreated = time.Date(2023, 1, 25, 11, 58, 10, 0, time.UTC)
+
+var _ = DescribeTable("Test NewAnonCredsObject function", func(testCase anonCredsObjectTestCase) {
+	resource := &resourceTypes.ResourceWithMetadata{
+		Resource: &resourceTypes.Resource{Data: []byte(testCase.data)},
+		Metadata: &resourceTypes.Metadata{
+			ResourceType: testCase.resourceType,
+			Created:      timestamppb.New(anonCredsCreated),
+		},
+	}
+
+	object, err := types.NewAnonCredsObject(testconstants.ExistentDid, resource)
+	if testCase.expectedError {
+		Expect(err).ToNot(BeNil())
+		return
+	}
+	Expect(err).To(BeNil())
+	Expect(object).To(Equal(testCase.expectedObject))
+},
+
+	Entry(
+		"can build schema",
+		anonCredsObjectTestCase{
+			resourceType: types.AnonCredsSchemaResourceType,
+			data:         `{"name":"Example schema","version":"1.0","attrNames":["name","age"]}`,
+			expectedObject: &types.AnonCredsSchema{
+				IssuerId:  testconstants.ExistentDid,
+				Name:      "Example schema",
+				Version:   "1.0",
+				AttrNames: []string{"name", "age"},
+			},
+		},
+	),
+
+	Entry(
+		"can build status list with timestamp and replaced issuerId",
+		anonCredsObjectTestCase{
+			resourceType: types.AnonCredsRevocationStatusListResourceType,
+			data:         `{"issuerId":"did:cheqd:testnet:other","revRegDefId":"rev-reg-def","revocationList":[0,1],"currentAccumulator":"21 124C"}`,
+			expectedObject: &types.AnonCredsRevocationStatusList{
+				IssuerId:           testconstants.ExistentDid,
+				RevRegDefId:        "rev-reg-def",
+				RevocationList:     []int{0, 1},
+				CurrentAccumulator: "21 124C",
+				Timestamp:          anonCredsCreated.Unix(),
+			},
+		},
+	),
+
+	Entry(
+		"cannot build schema without attrNames",
+		anonCredsObjectTestCase{
+			resourceType:  types.AnonCredsSchemaResourceType,
+			data:          `{"name":"Example schema","version":"1.0","attrNames":[]}`,
+			expectedError: true,
+		},
+	),
+
+	Entry(
+		"cannot build credential definition of not CL type",
+		anonCredsObjectTestCase{
+			resourceType:  types.AnonCredsCredentialDefinitionResourceType,
+			data:          `{"schemaId":"schema","type":"BBS","tag":"default","value":{"primary":{"n":"1"}}}`,
+			expectedError: true,
+		},
+	),
+
+	Entry(
+		"cannot build revocation registry definition without tails",
+		anonCredsObjectTestCase{
+			resourceType:  types.AnonCredsRevocationRegistryDefinitionResourceType,
+			data:          `{"revocDefType":"CL_ACCUM","credDefId":"cred-def","tag":"default","value":{"publicKeys":{"accumKey":{"z":"1 0BB"}},"maxCredNum":666}}`,
+			expectedError: true,
+		},
+	),
+
+	Entry(
+		"cannot build status list with invalid statuses",
+		anonCredsObjectTestCase{
+			resourceType:  types.AnonCredsRevocationStatusListResourceType,
+			data:          `{"revRegDefId":"rev-reg-def","revocationList":[0,2],"currentAccumulator":"21 124C"}`,
+			expectedError: true,
+		},
+	),
+
+	Entry(
+		"cannot build object from not AnonCreds resource",
+		anonCredsObjectTestCase{
+			resourceType:  "String",
+			data:          `{"name":"Example schema","version":"1.0","attrNames":["name"]}`,
+			expectedError: true,
+		},
+	),
+)
@@ -0,0 +1,103 @@
+//go:build unit
+
+package common
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"math"
+
+	"github.com/cheqd/did-resolver/types"
+	resolverUtils "github.com/cheqd/did-resolver/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func encodeStatusList(bitstring []byte) string {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, _ = writer.Write(bitstring)
+	_ = writer.Close()
+	return base64.RawURLEncoding.EncodeToString(compressed.Bytes())
+}
+
+var _ = Describe("Credential status", func() {
+	bitstring := []byte{0b10100000, 0b00000011}
+
+	Context("DecodeStatusList", func() {
+		It("can decode StatusList2021 encoded list", func() {
+			Expect(resolverUtils.DecodeStatusList(encodeStatusList(bitstring))).To(Equal(bitstring))
+		})
+
+		It("can decode Bitstring Status List encoded list with multibase prefix", func() {
+			Expect(resolverUtils.DecodeStatusList("u" + encodeStatusList(bitstring))).To(Equal(bitstring))
+		})
+
+		It("cannot decode the list larger than the limit", func() {
+			_, err := resolverUtils.DecodeStatusList(encodeStatusList(make([]byte, resolverUtils.MaxStatusListSize+1)))
+			Expect(err).To(MatchError(ContainSubstring("larger than")))
+		})
+
+		It("cannot decode not compressed list", func() {
+			_, err := resolverUtils.DecodeStatusList(base64.RawURLEncoding.EncodeToString(bitstring))
+			Expect(err).ToNot(BeNil())
+		})
+	})
+
+	DescribeTable("GetStatusFromBitstring", func(index int, statusSize int, expectedStatus int, expectError bool) {
+		status, err := resolverUtils.GetStatusFromBitstring(bitstring, index, statusSize)
+		if expectError {
+			Expect(err).ToNot(BeNil())
+			return
+		}
+		Expect(err).To(BeNil())
+		Expect(status).To(Equal(expectedStatus))
+	},
+		Entry("the first bit is set", 0, 1, 1, false),
+		Entry("the second bit is not set", 1, 1, 0, false),
+		Entry("the last bit is set", 15, 1, 1, false),
+		Entry("status of several bits", 0, 2, 2, false),
+		Entry("status of several bits at the end", 7, 2, 3, false),
+		Entry("index is out of the list", 16, 1, 0, true),
+		Entry("status is out of the list", 8, 2, 0, true),
+		Entry("index overflowing with status of several bits", 1<<62, 32, 0, true),
+		Entry("the largest index", math.MaxInt, 1, 0, true),
+	)
+
+	Context("ParseStatusListCredential", func() {
+		credential := []byte(`{
+			"type": ["VerifiableCredential", "BitstringStatusListCredential"],
+			"credentialSubject": {"type": "BitstringStatusList", "statusPurpose": ["revocation", "suspension"], "encodedList": "` + encodeStatusList(bitstring) + `"}
+		}`)
+
+		It("can parse JSON credential", func() {
+			statusList, err := types.ParseStatusListCredential(credential)
+			Expect(err).To(BeNil())
+			Expect(statusList.DecodeBitstring()).To(Equal(bitstring))
+		})
+
+		It("can parse VC-JWT credential", func() {
+			payload := base64.RawURLEncoding.EncodeToString([]byte(`{"vc":` + string(credential) + `}`))
+			statusList, err := types.ParseStatusListCredential([]byte("eyJhbGciOiJFUzI1NiJ9." + payload + ".c2lnbmF0dXJl"))
+			Expect(err).To(BeNil())
+			Expect(statusList.DecodeBitstring()).To(Equal(bitstring))
+		})
+
+		It("cannot parse credential without encodedList", func() {
+			_, err := types.ParseStatusListCredential([]byte(`{"credentialSubject": {"statusPurpose": "revocation"}}`))
+			Expect(err).ToNot(BeNil())
+		})
+
+		It("requires statusPurpose for the list with several purposes", func() {
+			statusList, err := types.ParseStatusListCredential(credential)
+			Expect(err).To(BeNil())
+
+			_, err = statusList.GetStatusPurpose("")
+			Expect(err).ToNot(BeNil())
+			Expect(statusList.GetStatusPurpose("suspension")).To(Equal("suspension"))
+			_, err = statusList.GetStatusPurpose("message")
+			Expect(err).ToNot(BeNil())
+		})
+	})
+})
@@ -3,11 +3,17 @@
 package common
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
 	"github.com/cheqd/did-resolver/services"
 	testconstants "github.com/cheqd/did-resolver/tests/constants"
 	utils "github.com/cheqd/did-resolver/tests/unit"
@@ -36,6 +42,7 @@ var _ = DescribeTable("Test DereferenceResourceData method", func(testCase deref
 		Expect(expectedContentType).To(Equal(dereferencingResult.DereferencingMetadata.ContentType))
 		Expect(testCase.expectedResourceDereferencing.DereferencingMetadata.DidProperties).To(Equal(dereferencingResult.DereferencingMetadata.DidProperties))
 		Expect(dereferencingResult.DereferencingMetadata.ResolutionError).To(BeEmpty())
+		Expect(dereferencingResult.DereferencingMetadata.ChecksumVerified).To(BeTrue())
 	}
 },
 
@@ -136,3 +143,55 @@ var _ = DescribeTable("Test DereferenceResourceData method", func(testCase deref
 		},
 	),
 )
+
+var _ = Describe("Test DereferenceResourceData method with tampered resource data", func() {
+	tamperedLedger := utils.NewMockLedgerService(
+		&testconstants.ValidDIDDoc,
+		[]*didTypes.Metadata{&testconstants.ValidMetadata},
+		[]resourceTypes.ResourceWithMetadata{
+			{
+				Resource: &resourceTypes.Resource{Data: []byte("tampered data")},
+				Metadata: &resourceTypes.Metadata{
+					CollectionId: testconstants.ValidIdentifier,
+					Id:           testconstants.ExistentResourceId,
+					MediaType:    "application/json",
+					Checksum:     testconstants.ValidResourceMetadata.Checksum,
+				},
+			},
+		},
+	)
+	resourceService := services.NewResourceService(testconstants.ValidMethod, tamperedLedger)
+
+	It("returns checksumMismatch error and counts it", func() {
+		mismatchCount := services.ChecksumMismatchCount()
+
+		_, err := resourceService.DereferenceResourceData(testconstants.ExistentDid, testconstants.ExistentResourceId, types.DIDJSON)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Message).To(Equal("checksumMismatch"))
+		Expect(err.Code).To(Equal(types.InternalErrorHttpCode))
+
+		_, err = resourceService.DereferenceResourceDataWithMetadata(testconstants.ExistentDid, testconstants.ExistentResourceId, types.DIDJSON)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Message).To(Equal("checksumMismatch"))
+
+		Expect(services.ChecksumMismatchCount()).To(Equal(mismatchCount + 2))
+	})
+
+	It("logs the number of checksum mismatches", func() {
+		var output bytes.Buffer
+		logger := log.Logger
+		log.Logger = zerolog.New(&output)
+		defer func() { log.Logger = logger }()
+
+		_, err := resourceService.DereferenceResourceData(testconstants.ExistentDid, testconstants.ExistentResourceId, types.DIDJSON)
+		Expect(err).ToNot(BeNil())
+
+		var entry struct {
+			Message               string `json:"message"`
+			ChecksumMismatchCount uint64 `json:"checksumMismatchCount"`
+		}
+		Expect(json.Unmarshal(output.Bytes(), &entry)).To(Succeed())
+		Expect(entry.Message).To(Equal("Resource checksum mismatch"))
+		Expect(entry.ChecksumMismatchCount).To(Equal(services.ChecksumMismatchCount()))
+	})
+})
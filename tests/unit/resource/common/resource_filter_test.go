@@ -0,0 +1,60 @@
+//go:build unit
+
+package common
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+)
+
+var _ = Describe("ResourceFilter", func() {
+	var (
+		t1 = utils.MustParseDate("2021-08-23T09:00:00Z")
+		t2 = utils.MustParseDate("2021-08-23T09:40:00Z")
+		t3 = utils.MustParseDate("2021-08-23T09:50:00Z")
+
+		resources = types.DereferencedResourceList{
+			{ResourceId: "r1", Name: "name1", ResourceType: "type1", MediaType: "application/json", Version: "v1", Created: &t1},
+			{ResourceId: "r2", Name: "name2", ResourceType: "type2", MediaType: "image/png", Version: "v1", Created: &t2},
+			{ResourceId: "r3", Name: "name2", ResourceType: "type2", MediaType: "image/png", Version: "v2", Created: &t3},
+			{ResourceId: "r4", Name: "name3", ResourceType: "type2", MediaType: "application/json", Version: "v1", Created: &t2},
+		}
+	)
+
+	resourceIds := func(resources types.DereferencedResourceList) []string {
+		ids := []string{}
+		for _, r := range resources {
+			ids = append(ids, r.ResourceId)
+		}
+		return ids
+	}
+
+	DescribeTable("filters resources and sorts them from the newest to the oldest", func(query string, expectedResourceIds ...string) {
+		queries, err := url.ParseQuery(query)
+		Expect(err).To(BeNil())
+		filter, err := types.NewResourceFilter(queries)
+		Expect(err).To(BeNil())
+		Expect(resourceIds(filter.Apply(resources))).To(Equal(expectedResourceIds))
+	},
+		Entry("without filters", "", "r3", "r2", "r4", "r1"),
+		Entry("by type", "resourceType=type2", "r3", "r2", "r4"),
+		Entry("by name", "resourceName=name2", "r3", "r2"),
+		Entry("by media type", "mediaType=application/json", "r4", "r1"),
+		Entry("by version", "resourceVersion=v1", "r2", "r4", "r1"),
+		Entry("by latest version", "resourceVersion=latest", "r3", "r4", "r1"),
+		Entry("created after", "createdAfter=2021-08-23T09:00:00Z", "r3", "r2", "r4"),
+		Entry("created before", "createdBefore=2021-08-23T09:50:00Z", "r2", "r4", "r1"),
+		Entry("with several filters", "resourceType=type2&createdBefore=2021-08-23T09:50:00Z&mediaType=image/png", "r2"),
+		Entry("without matching resources", "resourceName=name4"),
+	)
+
+	It("fails for invalid time", func() {
+		_, err := types.NewResourceFilter(url.Values{types.CreatedAfterQ: []string{"yesterday"}})
+		Expect(err).ToNot(BeNil())
+	})
+})
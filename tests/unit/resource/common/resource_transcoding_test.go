@@ -0,0 +1,102 @@
+//go:build unit
+
+package common
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+var (
+	pngData    = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	jsonData   = []byte(`{"name":"Alice","age":30,"id":12345678901234567890123}`)
+	jsonLDData = []byte(`{"@context":{"@vocab":"https://schema.org/","id":"@id","knows":{"@type":"@id"}},"id":"https://example.com/alice","name":"Alice","knows":"https://example.com/bob"}`)
+)
+
+var _ = Describe("ResolveMediaType", func() {
+	DescribeTable("detects the media type of resource data", func(stored string, data []byte, expected string) {
+		Expect(utils.ResolveMediaType(stored, data)).To(Equal(expected))
+	},
+		Entry("missing media type of image", "", pngData, "image/png"),
+		Entry("missing media type of JSON-LD", "", jsonLDData, "application/ld+json"),
+		Entry("JSON media type of image", "application/json", pngData, "image/png"),
+		Entry("another image media type", "image/jpeg", pngData, "image/png"),
+		Entry("correct JSON media type", "application/json", jsonData, "application/json"),
+		Entry("text media type of JSON", "text/plain", jsonData, "text/plain"),
+		Entry("specific media type of zip container", "application/vnd.oasis.opendocument.text", []byte("PK\x03\x04"), "application/vnd.oasis.opendocument.text"),
+	)
+})
+
+var _ = Describe("NegotiateResourceData", func() {
+	It("returns data as is if its media type is accepted", func() {
+		contentType, data, err := types.NegotiateResourceData("text/html,image/*;q=0.9", "image/png", pngData)
+		Expect(err).To(BeNil())
+		Expect(contentType).To(Equal(types.ContentType("image/png")))
+		Expect(data).To(Equal(pngData))
+	})
+
+	It("transcodes JSON to CBOR", func() {
+		contentType, data, err := types.NegotiateResourceData("application/cbor", types.JSON, []byte(`{"name":"Alice","age":30,"score":0.1}`))
+		Expect(err).To(BeNil())
+		Expect(contentType).To(Equal(types.CBOR))
+
+		var decoded map[string]interface{}
+		Expect(cbor.Unmarshal(data, &decoded)).To(BeNil())
+		Expect(decoded).To(Equal(map[string]interface{}{"name": "Alice", "age": uint64(30), "score": 0.1}))
+	})
+
+	It("transcodes JSON to YAML keeping the order of keys", func() {
+		contentType, data, err := types.NegotiateResourceData("application/yaml", types.JSON, jsonData)
+		Expect(err).To(BeNil())
+		Expect(contentType).To(Equal(types.YAML))
+		Expect(string(data)).To(Equal("name: Alice\nage: 30\nid: !!int 12345678901234567890123\n"))
+	})
+
+	DescribeTable("returns data of any media type as is for the DID resolution media types", func(accept string) {
+		contentType, data, err := types.NegotiateResourceData(accept, "image/png", pngData)
+		Expect(err).To(BeNil())
+		Expect(contentType).To(Equal(types.ContentType("image/png")))
+		Expect(data).To(Equal(pngData))
+	},
+		Entry("DID JSON", string(types.DIDJSON)),
+		Entry("DID JSON-LD", string(types.DIDJSONLD)),
+	)
+
+	It("returns JSON resource as is for JSON", func() {
+		contentType, data, err := types.NegotiateResourceData(string(types.JSON), types.JSONLD, jsonLDData)
+		Expect(err).To(BeNil())
+		Expect(contentType).To(Equal(types.JSONLD))
+		Expect(data).To(Equal(jsonLDData))
+	})
+
+	It("returns JSON-LD resource as JSON-LD", func() {
+		contentType, data, err := types.NegotiateResourceData(string(types.JSONLD), types.JSON, jsonLDData)
+		Expect(err).To(BeNil())
+		Expect(contentType).To(Equal(types.JSONLD))
+		Expect(data).To(Equal(jsonLDData))
+	})
+
+	It("picks the next accepted media type if the conversion is not lossless", func() {
+		contentType, _, err := types.NegotiateResourceData("application/cbor,application/yaml;q=0.5", types.JSON, []byte(`{"value":1.00000000000000000001}`))
+		Expect(err).To(BeNil())
+		Expect(contentType).To(Equal(types.YAML))
+	})
+
+	DescribeTable("can't represent resource data in not accepted media type", func(accept string, mediaType types.ContentType, data []byte) {
+		_, _, err := types.NegotiateResourceData(accept, mediaType, data)
+		Expect(err).ToNot(BeNil())
+	},
+		Entry("image as CBOR", "application/cbor", types.ContentType("image/png"), pngData),
+		Entry("JSON as image", "image/png", types.JSON, jsonData),
+		Entry("image as JSON", string(types.JSON), types.ContentType("image/png"), pngData),
+		Entry("image as JSON-LD", string(types.JSONLD), types.ContentType("image/png"), pngData),
+		Entry("big float as CBOR", "application/cbor", types.JSON, []byte(`{"value":1.00000000000000000001}`)),
+		Entry("JSON-LD in the expanded form", `application/ld+json;profile="http://www.w3.org/ns/json-ld#expanded"`, types.JSONLD, jsonLDData),
+		Entry("JSON-LD in the compacted form", `application/ld+json;profile="http://www.w3.org/ns/json-ld#compacted"`, types.JSONLD, jsonLDData),
+		Entry("refused media type", "image/png,*/*;q=0", types.JSON, jsonData),
+	)
+})
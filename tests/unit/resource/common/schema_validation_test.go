@@ -0,0 +1,100 @@
+//go:build unit
+
+package common
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cheqd/did-resolver/types"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+var (
+	personSchemaUrl = "did:cheqd:testnet:zF7rhDBfUt9d1gJPjx7s1J/resources/a7e7d4e6-5b3c-4f3e-9d2a-0e4f1c2b3a4d"
+	ageSchemaUrl    = "did:cheqd:testnet:zF7rhDBfUt9d1gJPjx7s1J/resources/b8f8e5f7-6c4d-4a4f-8e3b-1f5a2d3c4b5e"
+	personSchema    = []byte(`{"type":"object","properties":{"name":{"type":"string"},"age":{"$ref":"` + ageSchemaUrl + `"}},"required":["name"]}`)
+	ageSchema       = []byte(`{"type":"integer","minimum":0}`)
+)
+
+func loadAgeSchema(url string) ([]byte, error) {
+	if url != ageSchemaUrl {
+		return nil, errors.New("not found")
+	}
+	return ageSchema, nil
+}
+
+var _ = Describe("ValidateJSONSchema", func() {
+	It("validates data against schema with references to other schemas", func() {
+		violations, err := utils.ValidateJSONSchema(personSchemaUrl, personSchema, []byte(`{"name":"Alice","age":30}`), loadAgeSchema)
+		Expect(err).To(BeNil())
+		Expect(violations).To(BeEmpty())
+	})
+
+	It("returns violations of the schema", func() {
+		violations, err := utils.ValidateJSONSchema(personSchemaUrl, personSchema, []byte(`{"age":-1}`), loadAgeSchema)
+		Expect(err).To(BeNil())
+		Expect(violations).To(ConsistOf(
+			"at '/': missing property 'name'",
+			"at '/age': minimum: got -1, want 0",
+		))
+	})
+
+	It("returns violation if data is not JSON", func() {
+		violations, err := utils.ValidateJSONSchema(personSchemaUrl, personSchema, []byte("\x89PNG"), loadAgeSchema)
+		Expect(err).To(BeNil())
+		Expect(violations).To(HaveLen(1))
+	})
+
+	It("uses draft 2020-12 by default", func() {
+		schema := []byte(`{"prefixItems":[{"type":"string"}],"items":false}`)
+		violations, err := utils.ValidateJSONSchema(personSchemaUrl, schema, []byte(`["a",1]`), loadAgeSchema)
+		Expect(err).To(BeNil())
+		Expect(violations).To(HaveLen(1))
+	})
+
+	DescribeTable("can't compile invalid schema", func(schema []byte) {
+		_, err := utils.ValidateJSONSchema(personSchemaUrl, schema, []byte(`{}`), loadAgeSchema)
+		Expect(err).ToNot(BeNil())
+	},
+		Entry("not JSON", []byte("schema")),
+		Entry("invalid against metaschema", []byte(`{"type":5}`)),
+		Entry("reference which can't be loaded", []byte(`{"$ref":"https://example.com/schema"}`)),
+	)
+})
+
+var _ = Describe("GetSchemaDidUrl", func() {
+	DescribeTable("returns $schema only if it's a DID URL", func(data string, expected string) {
+		Expect(types.GetSchemaDidUrl([]byte(data))).To(Equal(expected))
+	},
+		Entry("DID URL", `{"$schema":"`+personSchemaUrl+`"}`, personSchemaUrl),
+		Entry("metaschema", `{"$schema":"https://json-schema.org/draft/2020-12/schema"}`, ""),
+		Entry("missing $schema", `{"name":"Alice"}`, ""),
+		Entry("not JSON", "\x89PNG", ""),
+	)
+})
+
+var _ = Describe("ParseResourceDidUrl", func() {
+	DescribeTable("parses DID URL of a resource", func(didUrl string, expected types.ResourceDidUrl) {
+		resourceDidUrl, err := types.ParseResourceDidUrl(didUrl)
+		Expect(err).To(BeNil())
+		Expect(resourceDidUrl).To(Equal(expected))
+	},
+		Entry("path", personSchemaUrl, types.ResourceDidUrl{
+			Did: "did:cheqd:testnet:zF7rhDBfUt9d1gJPjx7s1J", ResourceId: "a7e7d4e6-5b3c-4f3e-9d2a-0e4f1c2b3a4d",
+		}),
+		Entry("query", "did:cheqd:testnet:zF7rhDBfUt9d1gJPjx7s1J?resourceName=person&resourceType=JSONSchema2020", types.ResourceDidUrl{
+			Did: "did:cheqd:testnet:zF7rhDBfUt9d1gJPjx7s1J", ResourceName: "person", ResourceType: "JSONSchema2020",
+		}),
+		Entry("resolver URL", "https://resolver.cheqd.net/1.0/identifiers/"+personSchemaUrl, types.ResourceDidUrl{
+			Did: "did:cheqd:testnet:zF7rhDBfUt9d1gJPjx7s1J", ResourceId: "a7e7d4e6-5b3c-4f3e-9d2a-0e4f1c2b3a4d",
+		}),
+	)
+
+	It("fails for DID URL which doesn't point to a resource", func() {
+		_, err := types.ParseResourceDidUrl("did:cheqd:testnet:zF7rhDBfUt9d1gJPjx7s1J/service")
+		Expect(err).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,201 @@
+//go:build unit
+
+package request
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type credentialStatusTestCase struct {
+	query              url.Values
+	expectedResourceId string
+	expectedStatus     types.CredentialStatus
+	expectedError      error
+}
+
+var (
+	statusListName        = "revocation-list"
+	statusListType        = "BitstringStatusListCredential"
+	bitstringStatusListId = "5d3c6b2a-1f0e-4d9c-8b7a-6e5f4d3c2b1a"
+	statusList2021Id      = "6e4d7c3b-2a1f-4e0d-9c8b-7f6e5d4c3b2a"
+	notStatusListId       = "7f5e8d4c-3b2a-4f1e-8d9c-0a7f6e5d4c3b"
+
+	credentialStatusMockLedger = utils.NewMockLedgerService(
+		&testconstants.ValidDIDDoc,
+		[]*didTypes.Metadata{&testconstants.ValidMetadata},
+		[]resourceTypes.ResourceWithMetadata{
+			generateAnonCredsResource(statusList2021Id, statusListName, statusListType, generateStatusListCredential(
+				"StatusList2021", `"revocation"`, []byte{0b01000000},
+			), time.Date(2023, 1, 25, 10, 0, 0, 0, time.UTC)),
+			generateAnonCredsResource(bitstringStatusListId, statusListName, statusListType, generateStatusListCredential(
+				"BitstringStatusList", `["revocation","suspension"]`, []byte{0b10000000},
+			), time.Date(2023, 1, 25, 12, 0, 0, 0, time.UTC)),
+			generateAnonCredsResource(notStatusListId, "schema", "JSONSchema2020", []byte(`{"type":"object"}`), time.Date(2023, 1, 25, 12, 0, 0, 0, time.UTC)),
+		},
+	)
+)
+
+func generateStatusListCredential(subjectType string, statusPurpose string, bitstring []byte) []byte {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	_, _ = writer.Write(bitstring)
+	_ = writer.Close()
+
+	return []byte(fmt.Sprintf(
+		`{"type":["VerifiableCredential","%sCredential"],"credentialSubject":{"type":"%s","statusPurpose":%s,"encodedList":"%s"}}`,
+		subjectType, subjectType, statusPurpose, base64.RawURLEncoding.EncodeToString(compressed.Bytes()),
+	))
+}
+
+var _ = DescribeTable("Test ResourceCredentialStatusEchoHandler function", func(testCase credentialStatusTestCase) {
+	request := httptest.NewRequest(http.MethodGet, "/1.0/identifiers/credentialStatus?"+testCase.query.Encode(), nil)
+	context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, credentialStatusMockLedger)
+
+	err := resourceServices.ResourceCredentialStatusEchoHandler(context)
+	if testCase.expectedError != nil {
+		Expect(err).ToNot(BeNil())
+		Expect(testCase.expectedError.Error()).To(Equal(err.Error()))
+		return
+	}
+
+	Expect(err).To(BeNil())
+	var dereferencingResult struct {
+		DereferencingMetadata types.DereferencingMetadata `json:"dereferencingMetadata"`
+		ContentStream         types.CredentialStatus      `json:"contentStream"`
+		Metadata              types.DereferencedResource  `json:"contentMetadata"`
+	}
+	Expect(json.Unmarshal(rec.Body.Bytes(), &dereferencingResult)).To(BeNil())
+	Expect(dereferencingResult.Metadata.ResourceId).To(Equal(testCase.expectedResourceId))
+	Expect(dereferencingResult.ContentStream).To(Equal(testCase.expectedStatus))
+	Expect(dereferencingResult.DereferencingMetadata.ChecksumVerified).To(BeTrue())
+},
+
+	Entry(
+		"can check status in StatusList2021 by DID URL of the resource",
+		credentialStatusTestCase{
+			query: url.Values{
+				types.StatusListCredentialQ: {testconstants.ExistentDid + types.RESOURCE_PATH + statusList2021Id},
+				types.StatusListIndexQ:      {"1"},
+			},
+			expectedResourceId: statusList2021Id,
+			expectedStatus: types.CredentialStatus{
+				StatusListCredential: testconstants.ExistentDid + types.RESOURCE_PATH + statusList2021Id,
+				StatusListIndex:      1,
+				StatusPurpose:        "revocation",
+				StatusSize:           1,
+				Status:               1,
+			},
+		},
+	),
+
+	Entry(
+		"can check status in the latest Bitstring Status List by resolver URL",
+		credentialStatusTestCase{
+			query: url.Values{
+				types.StatusListCredentialQ: {fmt.Sprintf("https://resolver.cheqd.net/1.0/identifiers/%s?resourceName=%s&resourceType=%s", testconstants.ExistentDid, statusListName, statusListType)},
+				types.StatusListIndexQ:      {"1"},
+				types.StatusPurposeQ:        {"suspension"},
+			},
+			expectedResourceId: bitstringStatusListId,
+			expectedStatus: types.CredentialStatus{
+				StatusListCredential: fmt.Sprintf("https://resolver.cheqd.net/1.0/identifiers/%s?resourceName=%s&resourceType=%s", testconstants.ExistentDid, statusListName, statusListType),
+				StatusListIndex:      1,
+				StatusPurpose:        "suspension",
+				StatusSize:           1,
+				Status:               0,
+			},
+		},
+	),
+
+	Entry(
+		"can check status of several bits",
+		credentialStatusTestCase{
+			query: url.Values{
+				types.StatusListCredentialQ: {testconstants.ExistentDid + types.RESOURCE_PATH + bitstringStatusListId},
+				types.StatusListIndexQ:      {"0"},
+				types.StatusPurposeQ:        {"revocation"},
+				types.StatusSizeQ:           {"2"},
+			},
+			expectedResourceId: bitstringStatusListId,
+			expectedStatus: types.CredentialStatus{
+				StatusListCredential: testconstants.ExistentDid + types.RESOURCE_PATH + bitstringStatusListId,
+				StatusListIndex:      0,
+				StatusPurpose:        "revocation",
+				StatusSize:           2,
+				Status:               2,
+			},
+		},
+	),
+
+	Entry(
+		"cannot check status without statusPurpose in the list with several purposes",
+		credentialStatusTestCase{
+			query: url.Values{
+				types.StatusListCredentialQ: {testconstants.ExistentDid + types.RESOURCE_PATH + bitstringStatusListId},
+				types.StatusListIndexQ:      {"0"},
+			},
+			expectedError: types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot check status out of the list",
+		credentialStatusTestCase{
+			query: url.Values{
+				types.StatusListCredentialQ: {testconstants.ExistentDid + types.RESOURCE_PATH + statusList2021Id},
+				types.StatusListIndexQ:      {"8"},
+			},
+			expectedError: types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot check status without statusListIndex",
+		credentialStatusTestCase{
+			query: url.Values{
+				types.StatusListCredentialQ: {testconstants.ExistentDid + types.RESOURCE_PATH + statusList2021Id},
+			},
+			expectedError: types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot check status in not existent status list",
+		credentialStatusTestCase{
+			query: url.Values{
+				types.StatusListCredentialQ: {testconstants.ExistentDid + types.RESOURCE_PATH + testconstants.NotExistentIdentifier},
+				types.StatusListIndexQ:      {"0"},
+			},
+			expectedError: types.NewNotFoundError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot check status in not a status list",
+		credentialStatusTestCase{
+			query: url.Values{
+				types.StatusListCredentialQ: {testconstants.ExistentDid + types.RESOURCE_PATH + notStatusListId},
+				types.StatusListIndexQ:      {"0"},
+			},
+			expectedError: types.NewRepresentationNotSupportedError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+)
@@ -0,0 +1,138 @@
+//go:build unit
+
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type anonCredsStatusListTestCase struct {
+	query              string
+	expectedResourceId string
+	expectedRevoked    []int
+	expectedNextUpdate *time.Time
+	expectedError      error
+}
+
+var (
+	revRegDefName      = "revocation-registry"
+	revRegDefId        = "af20b1f0-5c4d-4037-9669-eaedddb9c2df"
+	statusListId1      = "1a7b0c3e-4f62-4e2a-9d0d-6b3a9f0c1e21"
+	statusListId2      = "2b8c1d4f-5a73-4f3b-8e1e-7c4b0a1d2f32"
+	statusListCreated1 = time.Date(2023, 1, 25, 10, 0, 0, 0, time.UTC)
+	statusListCreated2 = time.Date(2023, 1, 25, 12, 0, 0, 0, time.UTC)
+
+	statusListMockLedger = utils.NewMockLedgerService(
+		&testconstants.ValidDIDDoc,
+		[]*didTypes.Metadata{&testconstants.ValidMetadata},
+		[]resourceTypes.ResourceWithMetadata{
+			generateAnonCredsResource(revRegDefId, revRegDefName, types.AnonCredsRevocationRegistryDefinitionResourceType, []byte(
+				`{"revocDefType":"CL_ACCUM","credDefId":"cred-def","tag":"default","value":{"publicKeys":{"accumKey":{"z":"1 0BB"}},"maxCredNum":666,"tailsLocation":"https://tails.example.com","tailsHash":"91zvq2"}}`,
+			), statusListCreated1),
+			generateAnonCredsResource(statusListId1, revRegDefName, types.AnonCredsRevocationStatusListResourceType, []byte(
+				`{"revRegDefId":"rev-reg-def","revocationList":[0,0],"currentAccumulator":"21 124C"}`,
+			), statusListCreated1),
+			generateAnonCredsResource(statusListId2, revRegDefName, types.AnonCredsRevocationStatusListResourceType, []byte(
+				`{"revRegDefId":"rev-reg-def","revocationList":[0,1],"currentAccumulator":"21 125D"}`,
+			), statusListCreated2),
+		},
+	)
+)
+
+var _ = DescribeTable("Test ResourceAnonCredsStatusListEchoHandler function", func(testCase anonCredsStatusListTestCase) {
+	didURL := fmt.Sprintf("/1.0/identifiers/%s/anoncreds/statusList?%s", testconstants.ExistentDid, testCase.query)
+	request := httptest.NewRequest(http.MethodGet, didURL, nil)
+	context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, statusListMockLedger)
+
+	err := resourceServices.ResourceAnonCredsStatusListEchoHandler(context)
+	if testCase.expectedError != nil {
+		Expect(err).ToNot(BeNil())
+		Expect(testCase.expectedError.Error()).To(Equal(err.Error()))
+		return
+	}
+
+	Expect(err).To(BeNil())
+	var dereferencingResult types.AnonCredsStatusListDereferencing
+	Expect(json.Unmarshal(rec.Body.Bytes(), &dereferencingResult)).To(BeNil())
+	Expect(dereferencingResult.Metadata.ResourceId).To(Equal(testCase.expectedResourceId))
+	Expect(dereferencingResult.Metadata.NextUpdate).To(Equal(testCase.expectedNextUpdate))
+	Expect(dereferencingResult.ContentStream.RevocationList).To(Equal(testCase.expectedRevoked))
+	Expect(dereferencingResult.ContentStream.IssuerId).To(Equal(testconstants.ExistentDid))
+},
+
+	Entry(
+		"can get status list by revRegDefId at Unix timestamp",
+		anonCredsStatusListTestCase{
+			query:              fmt.Sprintf("revRegDefId=%s&timestamp=%d", revRegDefId, statusListCreated1.Add(time.Hour).Unix()),
+			expectedResourceId: statusListId1,
+			expectedRevoked:    []int{0, 0},
+			expectedNextUpdate: &statusListCreated2,
+		},
+	),
+
+	Entry(
+		"can get status list by resourceName at time string",
+		anonCredsStatusListTestCase{
+			query:              fmt.Sprintf("resourceName=%s&timestamp=%s", revRegDefName, statusListCreated2.Format(time.RFC3339)),
+			expectedResourceId: statusListId2,
+			expectedRevoked:    []int{0, 1},
+			expectedNextUpdate: nil,
+		},
+	),
+
+	Entry(
+		"can get the latest status list without timestamp",
+		anonCredsStatusListTestCase{
+			query:              fmt.Sprintf("revRegDefId=%s", revRegDefId),
+			expectedResourceId: statusListId2,
+			expectedRevoked:    []int{0, 1},
+			expectedNextUpdate: nil,
+		},
+	),
+
+	Entry(
+		"cannot get status list before the first one is created",
+		anonCredsStatusListTestCase{
+			query:         fmt.Sprintf("revRegDefId=%s&timestamp=%d", revRegDefId, statusListCreated1.Add(-time.Second).Unix()),
+			expectedError: types.NewNotFoundError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot get status list by id of not revocation registry definition",
+		anonCredsStatusListTestCase{
+			query:         fmt.Sprintf("revRegDefId=%s", statusListId1),
+			expectedError: types.NewNotFoundError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot get status list with both revRegDefId and resourceName",
+		anonCredsStatusListTestCase{
+			query:         fmt.Sprintf("revRegDefId=%s&resourceName=%s", revRegDefId, revRegDefName),
+			expectedError: types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot get status list with invalid timestamp",
+		anonCredsStatusListTestCase{
+			query:         fmt.Sprintf("revRegDefId=%s&timestamp=yesterday", revRegDefId),
+			expectedError: types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+)
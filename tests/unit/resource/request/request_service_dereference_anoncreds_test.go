@@ -0,0 +1,130 @@
+//go:build unit
+
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+	resolverUtils "github.com/cheqd/did-resolver/utils"
+)
+
+type anonCredsTestCase struct {
+	didURL         string
+	expectedSchema *types.AnonCredsSchema
+	expectedError  error
+}
+
+var (
+	anonCredsSchemaId = "44547089-170b-4f5a-bcbc-06e46e0089e4"
+	anonCredsSchema   = []byte(`{"name":"Example schema","version":"1.0","attrNames":["name","age"]}`)
+	// Schema without attrNames
+	invalidAnonCredsSchemaId = "0b3a6c1e-6f4e-4c1b-9d0a-3e5f7a9b1c2d"
+
+	anonCredsMockLedger = utils.NewMockLedgerService(
+		&testconstants.ValidDIDDoc,
+		[]*didTypes.Metadata{&testconstants.ValidMetadata},
+		[]resourceTypes.ResourceWithMetadata{
+			generateAnonCredsResource(anonCredsSchemaId, "Example schema", types.AnonCredsSchemaResourceType, anonCredsSchema, testconstants.ValidCreated),
+			generateAnonCredsResource(testconstants.ExistentResourceId, "Example schema", "String", []byte("test data"), testconstants.ValidCreated),
+			generateAnonCredsResource(invalidAnonCredsSchemaId, "Invalid schema", types.AnonCredsSchemaResourceType, []byte(`{"name":"Invalid schema","version":"1.0"}`), testconstants.ValidCreated),
+		},
+	)
+)
+
+func generateAnonCredsResource(resourceId string, name string, resourceType string, data []byte, created time.Time) resourceTypes.ResourceWithMetadata {
+	return resourceTypes.ResourceWithMetadata{
+		Resource: &resourceTypes.Resource{Data: data},
+		Metadata: &resourceTypes.Metadata{
+			CollectionId: testconstants.ValidIdentifier,
+			Id:           resourceId,
+			Name:         name,
+			ResourceType: resourceType,
+			MediaType:    "application/json",
+			Created:      timestamppb.New(created),
+			Checksum:     resolverUtils.Sha256Checksum(data),
+		},
+	}
+}
+
+var _ = DescribeTable("Test ResourceAnonCredsEchoHandler function", func(testCase anonCredsTestCase) {
+	request := httptest.NewRequest(http.MethodGet, testCase.didURL, nil)
+	context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, anonCredsMockLedger)
+
+	err := resourceServices.ResourceAnonCredsEchoHandler(context)
+	if testCase.expectedError != nil {
+		Expect(err).ToNot(BeNil())
+		Expect(testCase.expectedError.Error()).To(Equal(err.Error()))
+		return
+	}
+
+	Expect(err).To(BeNil())
+	var dereferencingResult struct {
+		DereferencingMetadata types.DereferencingMetadata `json:"dereferencingMetadata"`
+		ContentStream         types.AnonCredsSchema       `json:"contentStream"`
+		Metadata              types.DereferencedResource  `json:"contentMetadata"`
+	}
+	Expect(json.Unmarshal(rec.Body.Bytes(), &dereferencingResult)).To(BeNil())
+	Expect(dereferencingResult.ContentStream).To(Equal(*testCase.expectedSchema))
+	Expect(dereferencingResult.Metadata.ResourceId).To(Equal(anonCredsSchemaId))
+	Expect(dereferencingResult.DereferencingMetadata.ChecksumVerified).To(BeTrue())
+},
+
+	Entry(
+		"can get AnonCreds schema with issuerId",
+		anonCredsTestCase{
+			didURL: fmt.Sprintf("/1.0/identifiers/%s/resources/%s/anoncreds", testconstants.ExistentDid, anonCredsSchemaId),
+			expectedSchema: &types.AnonCredsSchema{
+				IssuerId:  testconstants.ExistentDid,
+				Name:      "Example schema",
+				Version:   "1.0",
+				AttrNames: []string{"name", "age"},
+			},
+		},
+	),
+
+	Entry(
+		"cannot get AnonCreds object from not AnonCreds resource",
+		anonCredsTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/resources/%s/anoncreds", testconstants.ExistentDid, testconstants.ExistentResourceId),
+			expectedError: types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot get AnonCreds object with invalid data",
+		anonCredsTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/resources/%s/anoncreds", testconstants.ExistentDid, invalidAnonCredsSchemaId),
+			expectedError: types.NewInternalError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot get AnonCreds object with not existent resourceId",
+		anonCredsTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/resources/%s/anoncreds", testconstants.ExistentDid, testconstants.NotExistentIdentifier),
+			expectedError: types.NewNotFoundError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot get AnonCreds object with query",
+		anonCredsTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/resources/%s/anoncreds?resourceType=%s", testconstants.ExistentDid, anonCredsSchemaId, types.AnonCredsSchemaResourceType),
+			expectedError: types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+)
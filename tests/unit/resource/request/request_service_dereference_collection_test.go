@@ -0,0 +1,122 @@
+//go:build unit
+
+package request
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type collectionTestCase struct {
+	didURL              string
+	expectedResourceIds []string
+	expectedError       error
+}
+
+var _ = DescribeTable("Test ResourceCollectionEchoHandler function", func(testCase collectionTestCase) {
+	request := httptest.NewRequest(http.MethodGet, testCase.didURL, nil)
+	context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, validateMockLedger)
+
+	err := resourceServices.ResourceCollectionEchoHandler(context)
+	if testCase.expectedError != nil {
+		Expect(err).ToNot(BeNil())
+		Expect(testCase.expectedError.Error()).To(Equal(err.Error()))
+		return
+	}
+
+	Expect(err).To(BeNil())
+	var dereferencingResult types.ResourceDereferencing
+	Expect(json.Unmarshal(rec.Body.Bytes(), &dereferencingResult)).To(BeNil())
+	resourceIds := []string{}
+	for _, resource := range *dereferencingResult.Metadata.Resources {
+		resourceIds = append(resourceIds, resource.ResourceId)
+	}
+	Expect(resourceIds).To(Equal(testCase.expectedResourceIds))
+},
+
+	Entry(
+		"can get all resources of the collection from the newest to the oldest",
+		collectionTestCase{
+			didURL:              fmt.Sprintf("/1.0/identifiers/%s/resources", testconstants.ExistentDid),
+			expectedResourceIds: []string{validPersonResourceId, invalidPersonResourceId, personSchemaResourceId},
+		},
+	),
+
+	Entry(
+		"can paginate resources of the collection in the same order",
+		collectionTestCase{
+			didURL:              fmt.Sprintf("/1.0/identifiers/%s/resources?resourceLimit=2", testconstants.ExistentDid),
+			expectedResourceIds: []string{validPersonResourceId, invalidPersonResourceId},
+		},
+	),
+
+	Entry(
+		"can get the next page of resources of the collection",
+		collectionTestCase{
+			didURL: fmt.Sprintf(
+				"/1.0/identifiers/%s/resources?resourceLimit=2&resourceCursor=%s",
+				testconstants.ExistentDid, base64.RawURLEncoding.EncodeToString([]byte(invalidPersonResourceId)),
+			),
+			expectedResourceIds: []string{personSchemaResourceId},
+		},
+	),
+
+	Entry(
+		"can filter resources by type and creation time",
+		collectionTestCase{
+			didURL:              fmt.Sprintf("/1.0/identifiers/%s/resources?resourceType=Person&createdAfter=2023-01-25T12:00:00Z", testconstants.ExistentDid),
+			expectedResourceIds: []string{validPersonResourceId, invalidPersonResourceId},
+		},
+	),
+
+	Entry(
+		"can sort and paginate filtered resources",
+		collectionTestCase{
+			didURL:              fmt.Sprintf("/1.0/identifiers/%s/resources?resourceType=Person&resourceSort=-name&resourceLimit=1", testconstants.ExistentDid),
+			expectedResourceIds: []string{invalidPersonResourceId},
+		},
+	),
+
+	Entry(
+		"cannot sort resources by unknown field",
+		collectionTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/resources?resourceSort=resourceName", testconstants.ExistentDid),
+			expectedError: types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"can get empty list if no resource matches",
+		collectionTestCase{
+			didURL:              fmt.Sprintf("/1.0/identifiers/%s/resources?mediaType=image/png", testconstants.ExistentDid),
+			expectedResourceIds: []string{},
+		},
+	),
+
+	Entry(
+		"cannot get resources with not supported query",
+		collectionTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/resources?resourceId=%s", testconstants.ExistentDid, validPersonResourceId),
+			expectedError: types.NewInvalidDidUrlError(testconstants.ExistentDid, types.DIDJSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot get resources of not existent DID",
+		collectionTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/resources", testconstants.NotExistentTestnetDid),
+			expectedError: types.NewNotFoundError(testconstants.NotExistentTestnetDid, types.DIDJSONLD, nil, true),
+		},
+	),
+)
@@ -15,6 +15,7 @@ import (
 	testconstants "github.com/cheqd/did-resolver/tests/constants"
 	utils "github.com/cheqd/did-resolver/tests/unit"
 	"github.com/cheqd/did-resolver/types"
+	resolverUtils "github.com/cheqd/did-resolver/utils"
 )
 
 type resourceDataTestCase struct {
@@ -38,6 +39,8 @@ var _ = DescribeTable("Test ResourceDataEchoHandler function", func(testCase res
 		Expect(err).To(BeNil())
 		Expect(testCase.expectedResource.GetBytes(), rec.Body.Bytes())
 		Expect(expectedContentType).To(Equal(types.ContentType(rec.Header().Get("Content-Type"))))
+		Expect(rec.Header().Get(types.CONTENT_DIGEST_HEADER)).To(Equal(resolverUtils.ContentDigest(testconstants.ValidResource[0].Resource.Data)))
+		Expect(rec.Header().Get(types.REPR_DIGEST_HEADER)).To(Equal(rec.Header().Get(types.CONTENT_DIGEST_HEADER)))
 	}
 },
 
@@ -176,3 +179,15 @@ var _ = DescribeTable("Test redirect DID", func(testCase utils.RedirectDIDTestCa
 		},
 	),
 )
+
+var _ = Describe("Digest of gzipped resource data", func() {
+	It("sends only Repr-Digest, as Content-Digest would have to cover the compressed bytes", func() {
+		request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/1.0/identifiers/%s/resources/%s", testconstants.ExistentDid, testconstants.ExistentResourceId), nil)
+		request.Header.Set(echo.HeaderAcceptEncoding, "gzip")
+		context, rec := utils.SetupEmptyContext(request, types.DIDJSONLD, utils.MockLedger)
+
+		Expect(resourceServices.ResourceDataEchoHandler(context)).To(BeNil())
+		Expect(rec.Header().Get(types.REPR_DIGEST_HEADER)).To(Equal(resolverUtils.ContentDigest(testconstants.ValidResource[0].Resource.Data)))
+		Expect(rec.Header().Get(types.CONTENT_DIGEST_HEADER)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,140 @@
+//go:build unit
+
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type negotiateResourceDataTestCase struct {
+	resourceId          string
+	accept              string
+	expectedContentType string
+	expectedData        string
+	expectedError       error
+}
+
+var (
+	jsonLDResourceId       = "8c6bb1a4-4f57-4b0c-9a4e-55d1d2d6f7a1"
+	untypedImageResourceId = "9d7cc2b5-5a68-4c1d-8b5f-66e2e3e7a8b2"
+	jsonLDResourceData     = `{"@context":{"@vocab":"https://schema.org/"},"name":"Alice"}`
+	imageResourceData      = "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+	negotiateMockLedger = newNegotiateMockLedger()
+)
+
+func newNegotiateMockLedger() utils.MockLedgerService {
+	resources := []resourceTypes.ResourceWithMetadata{
+		generateAnonCredsResource(jsonLDResourceId, "person", "Person", []byte(jsonLDResourceData), time.Date(2023, 1, 25, 12, 0, 0, 0, time.UTC)),
+		generateAnonCredsResource(untypedImageResourceId, "logo", "Image", []byte(imageResourceData), time.Date(2023, 1, 25, 12, 0, 0, 0, time.UTC)),
+	}
+	resources[1].Metadata.MediaType = ""
+	return utils.NewMockLedgerService(&testconstants.ValidDIDDoc, []*didTypes.Metadata{&testconstants.ValidMetadata}, resources)
+}
+
+var _ = DescribeTable("Test content negotiation of resource data", func(testCase negotiateResourceDataTestCase) {
+	request := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/1.0/identifiers/%s/resources/%s", testconstants.ExistentDid, testCase.resourceId), nil)
+	context, rec := utils.SetupEmptyContext(request, types.ContentType(testCase.accept), negotiateMockLedger)
+
+	err := resourceServices.ResourceDataEchoHandler(context)
+	if testCase.expectedError != nil {
+		Expect(err).ToNot(BeNil())
+		Expect(testCase.expectedError.Error()).To(Equal(err.Error()))
+		return
+	}
+
+	Expect(err).To(BeNil())
+	Expect(rec.Header().Get("Content-Type")).To(Equal(testCase.expectedContentType))
+	Expect(rec.Header().Get("Vary")).To(Equal("Accept"))
+	Expect(rec.Body.String()).To(Equal(testCase.expectedData))
+},
+
+	Entry(
+		"can get JSON-LD resource in its media type",
+		negotiateResourceDataTestCase{
+			resourceId:          jsonLDResourceId,
+			accept:              "*/*",
+			expectedContentType: string(types.JSON),
+			expectedData:        jsonLDResourceData,
+		},
+	),
+
+	Entry(
+		"can get JSON-LD resource in YAML",
+		negotiateResourceDataTestCase{
+			resourceId:          jsonLDResourceId,
+			accept:              "text/html,application/yaml;q=0.9",
+			expectedContentType: string(types.YAML),
+			expectedData:        "'@context':\n    '@vocab': https://schema.org/\nname: Alice\n",
+		},
+	),
+
+	Entry(
+		"can get image resource with the resolution media type",
+		negotiateResourceDataTestCase{
+			resourceId:          untypedImageResourceId,
+			accept:              string(types.DIDJSONLD),
+			expectedContentType: "image/png",
+			expectedData:        imageResourceData,
+		},
+	),
+
+	Entry(
+		"cannot get image resource as JSON",
+		negotiateResourceDataTestCase{
+			resourceId:    untypedImageResourceId,
+			accept:        string(types.JSON),
+			expectedError: types.NewRepresentationNotSupportedError(testconstants.ExistentDid, types.JSON, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot get JSON-LD resource in the expanded form",
+		negotiateResourceDataTestCase{
+			resourceId:    jsonLDResourceId,
+			accept:        `application/ld+json;profile="http://www.w3.org/ns/json-ld#expanded"`,
+			expectedError: types.NewRepresentationNotSupportedError(testconstants.ExistentDid, types.JSONLD, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot get not existent resource, which is reported in the requested media type",
+		negotiateResourceDataTestCase{
+			resourceId:    testconstants.NotExistentIdentifier,
+			accept:        string(types.JSON),
+			expectedError: types.NewNotFoundError(testconstants.ExistentDid, types.JSON, nil, true),
+		},
+	),
+
+	Entry(
+		"can get resource without media type in the sniffed one",
+		negotiateResourceDataTestCase{
+			resourceId:          untypedImageResourceId,
+			accept:              "image/*",
+			expectedContentType: "image/png",
+			expectedData:        imageResourceData,
+		},
+	),
+
+	Entry(
+		"cannot get image resource in CBOR",
+		negotiateResourceDataTestCase{
+			resourceId:    untypedImageResourceId,
+			accept:        string(types.CBOR),
+			expectedError: types.NewRepresentationNotSupportedError(testconstants.ExistentDid, types.JSONLD, nil, true),
+		},
+	),
+)
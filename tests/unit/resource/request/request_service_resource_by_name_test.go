@@ -0,0 +1,101 @@
+//go:build unit
+
+package request
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cheqd/did-resolver/services"
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type resourceByNameTestCase struct {
+	didURL             string
+	redirect           bool
+	expectedStatusCode int
+	expectedLocation   string
+	expectedData       string
+	expectedError      error
+}
+
+var _ = DescribeTable("Test ResourceByNameEchoHandler function", func(testCase resourceByNameTestCase) {
+	request := httptest.NewRequest(http.MethodGet, testCase.didURL, nil)
+	context, rec := utils.SetupEmptyContext(request, types.JSON, validateMockLedger)
+	resolverContext := context.(services.ResolverContext)
+	resolverContext.ResourceService.SetByNameRedirect(testCase.redirect)
+
+	err := resourceServices.ResourceByNameEchoHandler(resolverContext)
+	if testCase.expectedError != nil {
+		Expect(err).ToNot(BeNil())
+		Expect(testCase.expectedError.Error()).To(Equal(err.Error()))
+		return
+	}
+
+	Expect(err).To(BeNil())
+	Expect(rec.Code).To(Equal(testCase.expectedStatusCode))
+	Expect(rec.Header().Get("Location")).To(Equal(testCase.expectedLocation))
+	Expect(rec.Body.String()).To(ContainSubstring(testCase.expectedData))
+},
+
+	Entry(
+		"can redirect to the canonical URL of the resource",
+		resourceByNameTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s/resources/by-name/Person/alice", testconstants.ExistentDid),
+			redirect:           true,
+			expectedStatusCode: http.StatusSeeOther,
+			expectedLocation:   fmt.Sprintf("/1.0/identifiers/%s/resources/%s", testconstants.ExistentDid, validPersonResourceId),
+		},
+	),
+
+	Entry(
+		"can redirect to the latest version of the resource",
+		resourceByNameTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s/resources/by-name/JSONSchema2020/person/latest", testconstants.ExistentDid),
+			redirect:           true,
+			expectedStatusCode: http.StatusSeeOther,
+			expectedLocation:   fmt.Sprintf("/1.0/identifiers/%s/resources/%s", testconstants.ExistentDid, personSchemaResourceId),
+		},
+	),
+
+	Entry(
+		"can serve the resource directly",
+		resourceByNameTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s/resources/by-name/Person/bob", testconstants.ExistentDid),
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `{"name":42}`,
+		},
+	),
+
+	Entry(
+		"cannot get resource with another type",
+		resourceByNameTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/resources/by-name/Image/alice", testconstants.ExistentDid),
+			redirect:      true,
+			expectedError: types.NewNotFoundError(testconstants.ExistentDid, types.JSON, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot get resource with not existent version",
+		resourceByNameTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/resources/by-name/Person/alice/2", testconstants.ExistentDid),
+			expectedError: types.NewNotFoundError(testconstants.ExistentDid, types.JSON, nil, true),
+		},
+	),
+
+	Entry(
+		"cannot mix path with queries",
+		resourceByNameTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/resources/by-name/Person/alice?resourceVersion=1", testconstants.ExistentDid),
+			expectedError: types.NewInvalidDidUrlError(testconstants.ExistentDid, types.JSON, nil, true),
+		},
+	),
+)
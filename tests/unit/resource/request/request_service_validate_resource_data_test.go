@@ -0,0 +1,135 @@
+//go:build unit
+
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	resourceServices "github.com/cheqd/did-resolver/services/resource"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+)
+
+type validateResourceDataTestCase struct {
+	didURL             string
+	accept             types.ContentType
+	expectedValidation *types.SchemaValidation
+	expectedError      error
+}
+
+var (
+	personSchemaResourceId  = "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+	validPersonResourceId   = "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e"
+	invalidPersonResourceId = "3c4d5e6f-7a8b-4c9d-8e1f-2a3b4c5d6e7f"
+	personSchemaDidUrl      = testconstants.ExistentDid + types.RESOURCE_PATH + personSchemaResourceId
+
+	validateMockLedger = utils.NewMockLedgerService(
+		&testconstants.ValidDIDDoc,
+		[]*didTypes.Metadata{&testconstants.ValidMetadata},
+		[]resourceTypes.ResourceWithMetadata{
+			generateAnonCredsResource(
+				personSchemaResourceId, "person", "JSONSchema2020",
+				[]byte(`{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{"name":{"type":"string"}},"required":["name"]}`),
+				time.Date(2023, 1, 25, 12, 0, 0, 0, time.UTC),
+			),
+			generateAnonCredsResource(
+				validPersonResourceId, "alice", "Person",
+				[]byte(`{"$schema":"`+personSchemaDidUrl+`","name":"Alice"}`),
+				time.Date(2023, 1, 26, 12, 0, 0, 0, time.UTC),
+			),
+			generateAnonCredsResource(
+				invalidPersonResourceId, "bob", "Person",
+				[]byte(`{"name":42}`),
+				time.Date(2023, 1, 26, 12, 0, 0, 0, time.UTC),
+			),
+		},
+	)
+)
+
+var _ = DescribeTable("Test validation of resource data against JSON Schema", func(testCase validateResourceDataTestCase) {
+	request := httptest.NewRequest(http.MethodGet, testCase.didURL, nil)
+	context, rec := utils.SetupEmptyContext(request, testCase.accept, validateMockLedger)
+
+	err := resourceServices.ResourceDataEchoHandler(context)
+	if testCase.expectedError != nil {
+		Expect(err).ToNot(BeNil())
+		Expect(testCase.expectedError.Error()).To(Equal(err.Error()))
+		return
+	}
+
+	Expect(err).To(BeNil())
+	var dereferencingResult struct {
+		DereferencingMetadata types.DereferencingMetadata `json:"dereferencingMetadata"`
+	}
+	Expect(json.Unmarshal(rec.Body.Bytes(), &dereferencingResult)).To(BeNil())
+	Expect(dereferencingResult.DereferencingMetadata.SchemaValidation).To(Equal(testCase.expectedValidation))
+},
+
+	Entry(
+		"can validate resource data against the schema from validateAgainst",
+		validateResourceDataTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s/resources/%s?validateAgainst=%s", testconstants.ExistentDid, validPersonResourceId, personSchemaDidUrl),
+			accept:             types.JSONLD,
+			expectedValidation: &types.SchemaValidation{Schema: personSchemaDidUrl, Valid: true},
+		},
+	),
+
+	Entry(
+		"can find the latest schema by name and type",
+		validateResourceDataTestCase{
+			didURL: fmt.Sprintf(
+				"/1.0/identifiers/%s/resources/%s?validateAgainst=%s%%3FresourceName%%3Dperson%%26resourceType%%3DJSONSchema2020",
+				testconstants.ExistentDid, invalidPersonResourceId, testconstants.ExistentDid,
+			),
+			accept: types.JSONLD,
+			expectedValidation: &types.SchemaValidation{
+				Schema: testconstants.ExistentDid + "?resourceName=person&resourceType=JSONSchema2020",
+				Errors: []string{"at '/name': got number, want string"},
+			},
+		},
+	),
+
+	Entry(
+		"can validate resource data against the schema declared in $schema",
+		validateResourceDataTestCase{
+			didURL:             fmt.Sprintf("/1.0/identifiers/%s/resources/%s?validateAgainst=%%24schema", testconstants.ExistentDid, validPersonResourceId),
+			accept:             types.JSONLD + ";profile=" + types.ContentType(types.W3IDDIDURL),
+			expectedValidation: &types.SchemaValidation{Schema: personSchemaDidUrl, Valid: true},
+		},
+	),
+
+	Entry(
+		"doesn't validate resource data against $schema unless asked",
+		validateResourceDataTestCase{
+			didURL: fmt.Sprintf("/1.0/identifiers/%s/resources/%s", testconstants.ExistentDid, validPersonResourceId),
+			accept: types.JSONLD + ";profile=" + types.ContentType(types.W3IDDIDURL),
+		},
+	),
+
+	Entry(
+		"doesn't validate resource data without $schema",
+		validateResourceDataTestCase{
+			didURL: fmt.Sprintf("/1.0/identifiers/%s/resources/%s?validateAgainst=%%24schema", testconstants.ExistentDid, invalidPersonResourceId),
+			accept: types.JSONLD + ";profile=" + types.ContentType(types.W3IDDIDURL),
+		},
+	),
+
+	Entry(
+		"cannot validate resource data against not existent schema",
+		validateResourceDataTestCase{
+			didURL:        fmt.Sprintf("/1.0/identifiers/%s/resources/%s?validateAgainst=%s", testconstants.ExistentDid, validPersonResourceId, testconstants.ExistentDid+types.RESOURCE_PATH+"4d5e6f7a-8b9c-4d0e-9f2a-3b4c5d6e7f8a"),
+			accept:        types.JSONLD,
+			expectedError: types.NewNotFoundError(testconstants.ExistentDid, types.JSONLD, nil, true),
+		},
+	),
+)
@@ -0,0 +1,145 @@
+//go:build unit
+
+package snapshot_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cheqd/did-resolver/services"
+	testconstants "github.com/cheqd/did-resolver/tests/constants"
+	"github.com/cheqd/did-resolver/tests/fakenode"
+	utils "github.com/cheqd/did-resolver/tests/unit"
+	"github.com/cheqd/did-resolver/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	fixtureDid       = "did:cheqd:testnet:c1685ca0-1f5b-439c-8eb8-5c0e85ab7cd0"
+	fixtureVersionId = "0ce23d04-5b67-4ea6-a315-788588e53f4e"
+	fixtureNoteId    = "5e16a3f9-7c6e-40b4-89cc-f0a8c7b3e2d1"
+)
+
+func exportFixtures() *services.Snapshot {
+	node, err := fakenode.Start(fakenode.DefaultFixtures())
+	Expect(err).To(BeNil())
+	DeferCleanup(node.Stop)
+
+	network := types.Network{
+		Namespace: "testnet",
+		Endpoints: []types.Endpoint{{URL: node.Address(), Timeout: 5 * time.Second, Role: types.EndpointRolePrimary}},
+	}
+	ledgerService := services.NewLedgerService(services.NewEndpointManager(types.Config{Networks: []types.Network{network}}))
+	Expect(ledgerService.RegisterLedger(types.DID_METHOD, network)).To(Succeed())
+
+	snapshot, err := services.ExportSnapshot(ledgerService, []string{fixtureDid})
+	Expect(err).To(BeNil())
+	return snapshot
+}
+
+var _ = Describe("Snapshot", func() {
+	var snapshot *services.Snapshot
+
+	BeforeEach(func() {
+		snapshot = exportFixtures()
+	})
+
+	It("exports all versions and resources of the DID", func() {
+		Expect(snapshot.Namespaces).To(Equal([]string{"testnet"}))
+		Expect(snapshot.Dids).To(HaveKey(fixtureDid))
+		Expect(snapshot.Dids[fixtureDid].Versions).To(HaveLen(2))
+		Expect(snapshot.Dids[fixtureDid].Resources).To(HaveLen(3))
+	})
+
+	DescribeTable("reads the written snapshot", func(name string) {
+		snapshotPath := filepath.Join(GinkgoT().TempDir(), name)
+		Expect(services.WriteSnapshot(snapshot, snapshotPath)).To(Succeed())
+
+		read, err := services.ReadSnapshot(snapshotPath)
+		Expect(err).To(BeNil())
+		Expect(read.Namespaces).To(Equal(snapshot.Namespaces))
+		Expect(read.CreatedAt.Equal(snapshot.CreatedAt)).To(BeTrue())
+		Expect(read.Dids).To(HaveLen(1))
+		Expect(read.Dids[fixtureDid].Versions).To(HaveLen(2))
+		Expect(read.Dids[fixtureDid].Resources).To(HaveLen(3))
+	},
+		Entry("from directory", "snapshot"),
+		Entry("from archive", "snapshot.tar.gz"),
+	)
+
+	It("rejects resource data which doesn't match checksum", func() {
+		dir := GinkgoT().TempDir()
+		snapshot.Dids[fixtureDid].Resources[0].Resource.Data = []byte("tampered")
+		Expect(services.WriteSnapshot(snapshot, dir)).To(Succeed())
+
+		_, err := services.ReadSnapshot(dir)
+		Expect(err).To(MatchError(ContainSubstring("checksum mismatch")))
+	})
+
+	It("rejects unsupported format version", func() {
+		dir := GinkgoT().TempDir()
+		Expect(services.WriteSnapshot(snapshot, dir)).To(Succeed())
+		manifest := `{"version": 2, "namespaces": ["testnet"], "dids": []}`
+		Expect(os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(manifest), 0o644)).To(Succeed())
+
+		_, err := services.ReadSnapshot(dir)
+		Expect(err).To(MatchError("unsupported snapshot format version 2, expected 1"))
+	})
+
+	Context("SnapshotLedgerService", func() {
+		var ledgerService services.SnapshotLedgerService
+
+		BeforeEach(func() {
+			ledgerService = services.NewSnapshotLedgerService(snapshot, utils.MockLedger)
+		})
+
+		It("returns the latest and the requested version of DID Document", func() {
+			latest, err := ledgerService.QueryDIDDoc(fixtureDid, "")
+			Expect(err).To(BeNil())
+			Expect(latest.Metadata.NextVersionId).To(BeEmpty())
+
+			version, err := ledgerService.QueryDIDDoc(fixtureDid, fixtureVersionId)
+			Expect(err).To(BeNil())
+			Expect(version.Metadata.VersionId).To(Equal(fixtureVersionId))
+
+			versions, err := ledgerService.QueryAllDidDocVersionsMetadata(fixtureDid)
+			Expect(err).To(BeNil())
+			Expect(versions).To(HaveLen(2))
+		})
+
+		It("returns resources of the collection", func() {
+			resource, err := ledgerService.QueryResource(fixtureDid, fixtureNoteId)
+			Expect(err).To(BeNil())
+			Expect(string(resource.Resource.Data)).To(Equal("Hello, cheqd!"))
+
+			collection, err := ledgerService.QueryCollectionResources(fixtureDid)
+			Expect(err).To(BeNil())
+			Expect(collection).To(HaveLen(3))
+		})
+
+		It("returns notFound error for DID which is not in the snapshot", func() {
+			_, err := ledgerService.QueryDIDDoc(testconstants.NotExistentTestnetDid, "")
+			Expect(err).ToNot(BeNil())
+			Expect(err.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("passes other namespaces to the next ledger service", func() {
+			didDoc, err := ledgerService.QueryDIDDoc(testconstants.ExistentDid, "")
+			Expect(err).To(BeNil())
+			Expect(didDoc.DidDoc.Id).To(Equal(testconstants.ExistentDid))
+			Expect(ledgerService.GetNamespaces()).To(ContainElements("testnet", "mainnet"))
+		})
+
+		It("returns invalidDid error for other namespaces without the next ledger service", func() {
+			ledgerService = services.NewSnapshotLedgerService(snapshot, nil)
+
+			_, err := ledgerService.QueryDIDDoc(testconstants.ExistentDid, "")
+			Expect(err).ToNot(BeNil())
+			Expect(err.Code).To(Equal(types.InvalidDidHttpCode))
+			Expect(ledgerService.GetNamespaces()).To(Equal([]string{"testnet"}))
+		})
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
 	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
 	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
 	"github.com/cheqd/did-resolver/services"
@@ -139,10 +140,54 @@ func (ls MockLedgerService) QueryCollectionResources(did string) ([]*resourceTyp
 	return metadataList, nil
 }
 
+func (ls MockLedgerService) QueryCollectionResourcesPage(did string, page *query.PageRequest) ([]*resourceTypes.Metadata, *query.PageResponse, *types.IdentityError) {
+	collection, err := ls.QueryCollectionResources(did)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pageCollection(did, collection, page)
+}
+
 func (ls MockLedgerService) GetNamespaces() []string {
 	return []string{"testnet", "mainnet"}
 }
 
+// MockVersionedLedgerService serves a separate DID Document for each version
+type MockVersionedLedgerService struct {
+	MockLedgerService
+	DidDocs map[string]*didTypes.DidDoc // versionId -> DidDoc
+}
+
+func NewMockVersionedLedgerService(didDocs map[string]*didTypes.DidDoc, metadata []*didTypes.Metadata, resources []resourceTypes.ResourceWithMetadata) MockVersionedLedgerService {
+	// Every version shares the same DID, so any of them can be used as a base
+	var did *didTypes.DidDoc
+	for _, didDoc := range didDocs {
+		did = didDoc
+		break
+	}
+
+	return MockVersionedLedgerService{
+		MockLedgerService: NewMockLedgerService(did, metadata, resources),
+		DidDocs:           didDocs,
+	}
+}
+
+func (ls MockVersionedLedgerService) QueryDIDDoc(did string, version string) (*didTypes.DidDocWithMetadata, *types.IdentityError) {
+	if ls.Did.Id == did {
+		if version == "" {
+			metadata := ls.Metadata[len(ls.Metadata)-1]
+			return &didTypes.DidDocWithMetadata{DidDoc: ls.DidDocs[metadata.VersionId], Metadata: metadata}, nil
+		}
+		for _, metadata := range ls.Metadata {
+			if metadata.VersionId == version {
+				return &didTypes.DidDocWithMetadata{DidDoc: ls.DidDocs[version], Metadata: metadata}, nil
+			}
+		}
+	}
+
+	return nil, types.NewNotFoundError(did, types.JSON, nil, true)
+}
+
 func MustParseDate(sdate string) time.Time {
 	date, err := time.Parse(time.RFC3339, sdate)
 	if err != nil {
@@ -151,3 +196,64 @@ func MustParseDate(sdate string) time.Time {
 
 	return date
 }
+
+// MockMultipleDidsLedgerService serves the latest version of several DID Documents without resources
+type MockMultipleDidsLedgerService struct {
+	DidDocs map[string]*didTypes.DidDocWithMetadata // did -> DidDoc
+}
+
+func NewMockMultipleDidsLedgerService(didDocs ...*didTypes.DidDocWithMetadata) MockMultipleDidsLedgerService {
+	ls := MockMultipleDidsLedgerService{DidDocs: map[string]*didTypes.DidDocWithMetadata{}}
+	for _, didDoc := range didDocs {
+		ls.DidDocs[didDoc.DidDoc.Id] = didDoc
+	}
+	return ls
+}
+
+func (ls MockMultipleDidsLedgerService) QueryDIDDoc(did string, version string) (*didTypes.DidDocWithMetadata, *types.IdentityError) {
+	didDoc, ok := ls.DidDocs[did]
+	if !ok || (version != "" && didDoc.Metadata.VersionId != version) {
+		return nil, types.NewNotFoundError(did, types.JSON, nil, true)
+	}
+	return didDoc, nil
+}
+
+func (ls MockMultipleDidsLedgerService) QueryAllDidDocVersionsMetadata(did string) ([]*didTypes.Metadata, *types.IdentityError) {
+	didDoc, ok := ls.DidDocs[did]
+	if !ok {
+		return nil, types.NewNotFoundError(did, types.JSON, nil, true)
+	}
+	return []*didTypes.Metadata{didDoc.Metadata}, nil
+}
+
+func (ls MockMultipleDidsLedgerService) QueryResource(did string, resourceId string) (*resourceTypes.ResourceWithMetadata, *types.IdentityError) {
+	return nil, types.NewNotFoundError(did, types.JSON, nil, true)
+}
+
+func (ls MockMultipleDidsLedgerService) QueryCollectionResources(did string) ([]*resourceTypes.Metadata, *types.IdentityError) {
+	if _, ok := ls.DidDocs[did]; !ok {
+		return []*resourceTypes.Metadata{}, types.NewNotFoundError(did, types.JSON, nil, true)
+	}
+	return []*resourceTypes.Metadata{}, nil
+}
+
+func (ls MockMultipleDidsLedgerService) QueryCollectionResourcesPage(did string, page *query.PageRequest) ([]*resourceTypes.Metadata, *query.PageResponse, *types.IdentityError) {
+	collection, err := ls.QueryCollectionResources(did)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pageCollection(did, collection, page)
+}
+
+// pageCollection pages the collection like the ledger does, with resource ids as keys
+func pageCollection(did string, collection []*resourceTypes.Metadata, page *query.PageRequest) ([]*resourceTypes.Metadata, *query.PageResponse, *types.IdentityError) {
+	resources, pagination, err := services.PageCollectionResources(collection, page)
+	if err != nil {
+		return nil, nil, types.NewInvalidDidUrlError(did, types.JSON, err, false)
+	}
+	return resources, pagination, nil
+}
+
+func (ls MockMultipleDidsLedgerService) GetNamespaces() []string {
+	return []string{"testnet", "mainnet"}
+}
@@ -0,0 +1,231 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	resourceTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/resource/v2"
+	"github.com/rs/zerolog/log"
+)
+
+// Resource types used by cheqd AnonCreds Object Method
+const (
+	AnonCredsSchemaResourceType                       = "anonCredsSchema"
+	AnonCredsCredentialDefinitionResourceType         = "anonCredsCredDef"
+	AnonCredsRevocationRegistryDefinitionResourceType = "anonCredsRevocRegDef"
+	AnonCredsRevocationStatusListResourceType         = "anonCredsStatusList"
+)
+
+const (
+	anonCredsCredentialDefinitionType         = "CL"
+	anonCredsRevocationRegistryDefinitionType = "CL_ACCUM"
+	// Maximum number of attributes in AnonCreds schema
+	anonCredsSchemaAttrNamesLimit = 125
+)
+
+// ErrNotAnonCredsObject is returned for resources of other types, other errors mean that the data of the AnonCreds resource is invalid
+var ErrNotAnonCredsObject = errors.New("resource is not an AnonCreds object")
+
+type AnonCredsObjectI interface {
+	ContentStreamI
+	Validate() error
+}
+
+type AnonCredsSchema struct {
+	IssuerId  string   `json:"issuerId" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47"`
+	Name      string   `json:"name" example:"Example schema"`
+	Version   string   `json:"version" example:"1.0"`
+	AttrNames []string `json:"attrNames" example:"name,age"`
+}
+
+type AnonCredsCredentialDefinition struct {
+	IssuerId string                             `json:"issuerId" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47"`
+	SchemaId string                             `json:"schemaId" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/44547089-170b-4f5a-bcbc-06e46e0089e4"`
+	Type     string                             `json:"type" example:"CL"`
+	Tag      string                             `json:"tag" example:"default"`
+	Value    AnonCredsCredentialDefinitionValue `json:"value"`
+}
+
+type AnonCredsCredentialDefinitionValue struct {
+	Primary    json.RawMessage `json:"primary" swaggertype:"object"`
+	Revocation json.RawMessage `json:"revocation,omitempty" swaggertype:"object"`
+}
+
+type AnonCredsRevocationRegistryDefinition struct {
+	IssuerId     string                                     `json:"issuerId" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47"`
+	RevocDefType string                                     `json:"revocDefType" example:"CL_ACCUM"`
+	CredDefId    string                                     `json:"credDefId" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/77465164-5646-42d9-9a0a-f7b2dcb855c0"`
+	Tag          string                                     `json:"tag" example:"default"`
+	Value        AnonCredsRevocationRegistryDefinitionValue `json:"value"`
+}
+
+type AnonCredsRevocationRegistryDefinitionValue struct {
+	PublicKeys    AnonCredsRevocationRegistryPublicKeys `json:"publicKeys"`
+	MaxCredNum    uint32                                `json:"maxCredNum" example:"666"`
+	TailsLocation string                                `json:"tailsLocation" example:"https://my.revocations.tails/tailsfile.txt"`
+	TailsHash     string                                `json:"tailsHash" example:"91zvq2cFmBZmHCcLqFyzv7bfehHH5rMhdAG5wTjqy2PE"`
+}
+
+type AnonCredsRevocationRegistryPublicKeys struct {
+	AccumKey AnonCredsAccumulatorKey `json:"accumKey"`
+}
+
+type AnonCredsAccumulatorKey struct {
+	Z string `json:"z" example:"1 0BB...386"`
+}
+
+type AnonCredsRevocationStatusList struct {
+	IssuerId           string `json:"issuerId" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47"`
+	RevRegDefId        string `json:"revRegDefId" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/af20b1f0-5c4d-4037-9669-eaedddb9c2df"`
+	RevocationList     []int  `json:"revocationList" example:"0,1,0"`
+	CurrentAccumulator string `json:"currentAccumulator" example:"21 124C594B6B20E41B681E92B2C43FD165EA9E68BC3C9D63A82C8893124983CAE94 21 124C5341937827427B0A3A32113BD5E64FB7AB39BD3E5ABDD7970874501CA4897 6 5438CB6F442E2F807812FD9DC0C39AFF4A86B1E6766DBB5359E86A4D70401B0F 4 39D1CA5C4716FFC4FE0853C4FF7F081DFD8DF8D2C2CA79705211680AC77BF3A1 6 70504A5493F89C97C225B68310811A41AD9CD889301F238E93C95AD085E84191 4 39582252194D756D5D86D0EED02BF1B95CE12AED2FA5CD3C53260747D891993C"`
+	// Creation time of the status list resource as Unix timestamp
+	Timestamp int64 `json:"timestamp" example:"1669640864"`
+}
+
+// NewAnonCredsObject builds AnonCreds object from DID-Linked Resource and adds issuerId (and timestamp for status lists)
+func NewAnonCredsObject(issuerId string, resource *resourceTypes.ResourceWithMetadata) (AnonCredsObjectI, error) {
+	var object AnonCredsObjectI
+	switch resource.Metadata.ResourceType {
+	case AnonCredsSchemaResourceType:
+		object = &AnonCredsSchema{}
+	case AnonCredsCredentialDefinitionResourceType:
+		object = &AnonCredsCredentialDefinition{}
+	case AnonCredsRevocationRegistryDefinitionResourceType:
+		object = &AnonCredsRevocationRegistryDefinition{}
+	case AnonCredsRevocationStatusListResourceType:
+		object = &AnonCredsRevocationStatusList{}
+	default:
+		return nil, fmt.Errorf("%w: resource type %s", ErrNotAnonCredsObject, resource.Metadata.ResourceType)
+	}
+
+	if err := json.Unmarshal(resource.Resource.Data, object); err != nil {
+		return nil, err
+	}
+
+	// issuerId is always the DID which the resource is linked to
+	switch o := object.(type) {
+	case *AnonCredsSchema:
+		o.IssuerId = issuerId
+	case *AnonCredsCredentialDefinition:
+		o.IssuerId = issuerId
+	case *AnonCredsRevocationRegistryDefinition:
+		o.IssuerId = issuerId
+	case *AnonCredsRevocationStatusList:
+		o.IssuerId = issuerId
+		o.Timestamp = resource.Metadata.Created.AsTime().Unix()
+	}
+
+	if err := object.Validate(); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+func (e *AnonCredsSchema) Validate() error {
+	if e.Name == "" || e.Version == "" {
+		return errors.New("schema name and version are required")
+	}
+	if len(e.AttrNames) == 0 || len(e.AttrNames) > anonCredsSchemaAttrNamesLimit {
+		return fmt.Errorf("schema should have from 1 to %d attrNames", anonCredsSchemaAttrNamesLimit)
+	}
+	return nil
+}
+
+func (e *AnonCredsCredentialDefinition) Validate() error {
+	if e.SchemaId == "" || e.Tag == "" {
+		return errors.New("credential definition schemaId and tag are required")
+	}
+	if e.Type != anonCredsCredentialDefinitionType {
+		return fmt.Errorf("credential definition type should be %s", anonCredsCredentialDefinitionType)
+	}
+	if len(e.Value.Primary) == 0 {
+		return errors.New("credential definition primary value is required")
+	}
+	return nil
+}
+
+func (e *AnonCredsRevocationRegistryDefinition) Validate() error {
+	if e.CredDefId == "" || e.Tag == "" {
+		return errors.New("revocation registry definition credDefId and tag are required")
+	}
+	if e.RevocDefType != anonCredsRevocationRegistryDefinitionType {
+		return fmt.Errorf("revocation registry definition type should be %s", anonCredsRevocationRegistryDefinitionType)
+	}
+	if e.Value.PublicKeys.AccumKey.Z == "" || e.Value.MaxCredNum == 0 || e.Value.TailsLocation == "" || e.Value.TailsHash == "" {
+		return errors.New("revocation registry definition value is incomplete")
+	}
+	return nil
+}
+
+func (e *AnonCredsRevocationStatusList) Validate() error {
+	if e.RevRegDefId == "" || e.CurrentAccumulator == "" {
+		return errors.New("status list revRegDefId and currentAccumulator are required")
+	}
+	for _, status := range e.RevocationList {
+		if status != 0 && status != 1 {
+			return errors.New("status list revocationList should contain only 0 and 1")
+		}
+	}
+	return nil
+}
+
+func (e *AnonCredsSchema) AddContext(newProtocol string) {}
+func (e *AnonCredsSchema) RemoveContext()                {}
+func (e *AnonCredsSchema) GetBytes() []byte              { return marshalAnonCredsObject(e) }
+
+func (e *AnonCredsCredentialDefinition) AddContext(newProtocol string) {}
+func (e *AnonCredsCredentialDefinition) RemoveContext()                {}
+func (e *AnonCredsCredentialDefinition) GetBytes() []byte              { return marshalAnonCredsObject(e) }
+
+func (e *AnonCredsRevocationRegistryDefinition) AddContext(newProtocol string) {}
+func (e *AnonCredsRevocationRegistryDefinition) RemoveContext()                {}
+func (e *AnonCredsRevocationRegistryDefinition) GetBytes() []byte              { return marshalAnonCredsObject(e) }
+
+func (e *AnonCredsRevocationStatusList) AddContext(newProtocol string) {}
+func (e *AnonCredsRevocationStatusList) RemoveContext()                {}
+func (e *AnonCredsRevocationStatusList) GetBytes() []byte              { return marshalAnonCredsObject(e) }
+
+func marshalAnonCredsObject(object AnonCredsObjectI) []byte {
+	bytes, err := json.Marshal(object)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal AnonCreds object")
+		return []byte{}
+	}
+	return bytes
+}
+
+// AnonCredsStatusListDereferencing is the revocation status list which was current at the requested time
+type AnonCredsStatusListDereferencing struct {
+	Context               string                         `json:"@context,omitempty" example:"https://w3id.org/did-resolution/v1"`
+	DereferencingMetadata DereferencingMetadata          `json:"dereferencingMetadata"`
+	ContentStream         *AnonCredsRevocationStatusList `json:"contentStream"`
+	Metadata              *AnonCredsStatusListMetadata   `json:"contentMetadata"`
+}
+
+type AnonCredsStatusListMetadata struct {
+	DereferencedResource
+	// Creation time of the next status list of the registry. Empty if the status list is the latest one
+	NextUpdate *time.Time `json:"nextUpdate,omitempty" example:"2021-09-01T12:00:00Z"`
+}
+
+// Interface implementation
+
+func (d AnonCredsStatusListDereferencing) GetContentType() string {
+	return string(d.DereferencingMetadata.ContentType)
+}
+
+func (d AnonCredsStatusListDereferencing) GetBytes() []byte {
+	if d.ContentStream == nil {
+		return []byte{}
+	}
+	return d.ContentStream.GetBytes()
+}
+
+func (d AnonCredsStatusListDereferencing) IsRedirect() bool {
+	return false
+}
+
+// end of Interface implementation
@@ -37,6 +37,15 @@ type RawConfig struct {
 	EnableFallbackEndpoints bool   `mapstructure:"ENABLE_FALLBACK_ENDPOINTS"`
 	ResolverListener        string `mapstructure:"RESOLVER_LISTENER"`
 	LogLevel                string `mapstructure:"LOG_LEVEL"`
+	DidUrlPathService       string `mapstructure:"DID_URL_PATH_SERVICE"`
+	ResourceByNameRedirect  bool   `mapstructure:"RESOURCE_BY_NAME_REDIRECT"`
+	SnapshotPath            string `mapstructure:"SNAPSHOT_PATH"`
+	CacheDir                string `mapstructure:"CACHE_DIR"`
+	CacheMaxSizeMB          int64  `mapstructure:"CACHE_MAX_SIZE_MB"`
+	LedgerRecordPath        string `mapstructure:"LEDGER_RECORD_PATH"`
+	LedgerReplayPath        string `mapstructure:"LEDGER_REPLAY_PATH"`
+	AdminListener           string `mapstructure:"ADMIN_LISTENER"`
+	AdminToken              string `mapstructure:"ADMIN_TOKEN"`
 }
 
 type Config struct {
@@ -44,6 +53,16 @@ type Config struct {
 	EnableFallbackEndpoints bool
 	ResolverListener        string
 	LogLevel                string
+	DidUrlPathService       string
+	ResourceByNameRedirect  bool
+	SnapshotPath            string
+	CacheDir                string
+	CacheMaxSizeMB          int64
+	LedgerRecordPath        string
+	LedgerReplayPath        string
+	AdminListener           string
+	// Not printed with the configuration
+	AdminToken string `json:"-"`
 }
 
 func (c *Config) MarshalJson() (string, error) {
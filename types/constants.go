@@ -10,7 +10,10 @@ const (
 	DIDRES     ContentType = "application/did"
 	W3IDDIDRES string      = "https://w3id.org/did-resolution"
 	TEXT       ContentType = "text/plain"
+	JSONL      ContentType = "application/jsonl"
 	W3IDDIDURL string      = "https://w3id.org/did-url-dereferencing"
+	CBOR       ContentType = "application/cbor"
+	YAML       ContentType = "application/yaml"
 )
 
 func (cType ContentType) IsSupported() bool {
@@ -24,6 +27,18 @@ func (cType ContentType) IsSupported() bool {
 	return supportedTypes[cType]
 }
 
+// Special values of resourceVersion and resourceVersionTime queries.
+// latest and first select versions in the order of creation, and take precedence over
+// resources whose resourceVersion is literally latest or first.
+const (
+	ResourceVersionLatest  = "latest"
+	ResourceVersionFirst   = "first"
+	ResourceVersionTimeNow = "now"
+)
+
+// Value of validateAgainst query to validate resource data against the schema declared in its $schema
+const ValidateAgainstDeclared = "$schema"
+
 type TransformKeysType string
 
 const (
@@ -41,6 +56,27 @@ func (tKType TransformKeysType) IsSupported() bool {
 	return supportedTypes[tKType]
 }
 
+type VerificationRelationship string
+
+const (
+	AuthenticationRelationship       VerificationRelationship = "authentication"
+	AssertionMethodRelationship      VerificationRelationship = "assertionMethod"
+	CapabilityInvocationRelationship VerificationRelationship = "capabilityInvocation"
+	CapabilityDelegationRelationship VerificationRelationship = "capabilityDelegation"
+	KeyAgreementRelationship         VerificationRelationship = "keyAgreement"
+)
+
+func (relationship VerificationRelationship) IsSupported() bool {
+	supportedRelationships := map[VerificationRelationship]bool{
+		AuthenticationRelationship:       true,
+		AssertionMethodRelationship:      true,
+		CapabilityInvocationRelationship: true,
+		CapabilityDelegationRelationship: true,
+		KeyAgreementRelationship:         true,
+	}
+	return supportedRelationships[relationship]
+}
+
 const (
 	DIDSchemaJSONLD                  = "https://www.w3.org/ns/did/v1"
 	LinkedDomainsJSONLD              = "https://identity.foundation/.well-known/did-configuration/v1"
@@ -56,25 +92,57 @@ const (
 	DID_VERSION_PATH        = "/version/"
 	DID_VERSIONS_PATH       = "/versions"
 	DID_METADATA            = "/metadata"
+	DID_DIFF_PATH           = "/diff"
+	DID_HISTORY_PATH        = "/history"
+	DID_AUTHORIZATION_PATH  = "/authorization"
+	DID_URL_PATH            = "/*"
 	RESOURCE_PATH           = "/resources/"
+	RESOURCE_VERSIONS_PATH  = "/versions"
+	RESOURCES_PATH          = "/resources"
+	RESOURCE_BY_NAME_PATH   = "by-name/"
+	ANONCREDS_PATH          = "/anoncreds"
+	STATUS_LIST_PATH        = "/statusList"
+	CREDENTIAL_STATUS_PATH  = "credentialStatus"
 	SWAGGER_PATH            = "/swagger/*"
+	HEALTH_PATH             = "/health"
 	DEFAULT_RESOLUTION_TYPE = "*/*"
+	CONTENT_DIGEST_HEADER   = "Content-Digest"
+	REPR_DIGEST_HEADER      = "Repr-Digest"
 )
 
 const (
-	VersionId            string = "versionId"
-	VersionTime          string = "versionTime"
-	TransformKeys        string = "transformKeys"
-	LinkedDomains        string = "LinkedDomains"
-	Metadata             string = "metadata"
-	ServiceQ             string = "service"
-	RelativeRef          string = "relativeRef"
-	ResourceId           string = "resourceId"
-	ResourceName         string = "resourceName"
-	ResourceType         string = "resourceType"
-	ResourceVersionTime  string = "resourceVersionTime"
-	ResourceMetadata     string = "resourceMetadata"
-	ResourceCollectionId string = "resourceCollectionId"
-	ResourceVersion      string = "resourceVersion"
-	ResourceChecksum     string = "checksum"
+	VersionId             string = "versionId"
+	VersionTime           string = "versionTime"
+	TransformKeys         string = "transformKeys"
+	LinkedDomains         string = "LinkedDomains"
+	Metadata              string = "metadata"
+	ServiceQ              string = "service"
+	ServiceTypeQ          string = "serviceType"
+	RelativeRef           string = "relativeRef"
+	ResourceId            string = "resourceId"
+	ResourceName          string = "resourceName"
+	ResourceType          string = "resourceType"
+	ResourceVersionTime   string = "resourceVersionTime"
+	ResourceMetadata      string = "resourceMetadata"
+	ResourceCollectionId  string = "resourceCollectionId"
+	ResourceVersion       string = "resourceVersion"
+	ResourceChecksum      string = "checksum"
+	DiffFrom              string = "from"
+	DiffTo                string = "to"
+	VerificationMethodQ   string = "verificationMethod"
+	RelationshipQ         string = "relationship"
+	RevRegDefIdQ          string = "revRegDefId"
+	TimestampQ            string = "timestamp"
+	StatusListCredentialQ string = "statusListCredential"
+	StatusListIndexQ      string = "statusListIndex"
+	StatusPurposeQ        string = "statusPurpose"
+	StatusSizeQ           string = "statusSize"
+	ResourceLimitQ        string = "resourceLimit"
+	ResourceCursorQ       string = "resourceCursor"
+	ResourceSortQ         string = "resourceSort"
+	ResourceFieldsQ       string = "resourceFields"
+	ValidateAgainstQ      string = "validateAgainst"
+	MediaTypeQ            string = "mediaType"
+	CreatedAfterQ         string = "createdAfter"
+	CreatedBeforeQ        string = "createdBefore"
 )
@@ -0,0 +1,27 @@
+package types
+
+// ControllerAuthorization is the answer whether a verification method is authorised
+// to act for a DID, either directly or through the chain of its controllers.
+type ControllerAuthorization struct {
+	Did                string                   `json:"did" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47"`
+	VerificationMethod string                   `json:"verificationMethod" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47#key-1"`
+	Relationship       VerificationRelationship `json:"relationship" example:"authentication"`
+	Authorized         bool                     `json:"authorized" example:"true"`
+	// If authorized, the chain of DIDs from the requested one to the DID which lists the verification method.
+	// Otherwise all the DIDs which were resolved while walking the controllers.
+	ResolutionPath []string `json:"resolutionPath" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47"`
+}
+
+func NewControllerAuthorization(did string, verificationMethod string, relationship VerificationRelationship, authorized bool, resolutionPath []string) *ControllerAuthorization {
+	return &ControllerAuthorization{
+		Did:                did,
+		VerificationMethod: verificationMethod,
+		Relationship:       relationship,
+		Authorized:         authorized,
+		ResolutionPath:     resolutionPath,
+	}
+}
+
+func (e *ControllerAuthorization) AddContext(newProtocol string) {}
+func (e *ControllerAuthorization) RemoveContext()                {}
+func (e *ControllerAuthorization) GetBytes() []byte              { return []byte{} }
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cheqd/did-resolver/utils"
+)
+
+type StatusListCredential struct {
+	Type              StringOrStringArray         `json:"type"`
+	CredentialSubject StatusListCredentialSubject `json:"credentialSubject"`
+}
+
+type StatusListCredentialSubject struct {
+	Type          string              `json:"type"`
+	StatusPurpose StringOrStringArray `json:"statusPurpose"`
+	EncodedList   string              `json:"encodedList"`
+}
+
+// CredentialStatus is the status of a credential in Bitstring Status List or StatusList2021 credential
+type CredentialStatus struct {
+	StatusListCredential string `json:"statusListCredential" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/398cee0a-efac-4643-9f4c-74c48c72a14b"`
+	StatusListIndex      int    `json:"statusListIndex" example:"94567"`
+	StatusPurpose        string `json:"statusPurpose" example:"revocation"`
+	StatusSize           int    `json:"statusSize" example:"1"`
+	// Value of the status bits. For revocation and suspension 1 means the credential is revoked or suspended
+	Status int `json:"status" example:"0"`
+}
+
+// ParseStatusListCredential parses status list credential secured either as JSON or as JWT.
+// Proof of the credential is not verified.
+func ParseStatusListCredential(data []byte) (*StatusListCredential, error) {
+	// VC-JWT keeps the credential in the payload, under "vc" claim for VC Data Model 1.1
+	if parts := strings.Split(strings.TrimSpace(string(data)), "."); len(parts) == 3 {
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		var claims struct {
+			Vc json.RawMessage `json:"vc"`
+		}
+		if err := json.Unmarshal(payload, &claims); err != nil {
+			return nil, err
+		}
+		data = payload
+		if len(claims.Vc) > 0 {
+			data = claims.Vc
+		}
+	}
+
+	var credential StatusListCredential
+	if err := json.Unmarshal(data, &credential); err != nil {
+		return nil, err
+	}
+	if credential.CredentialSubject.EncodedList == "" {
+		return nil, errors.New("status list credential should have encodedList")
+	}
+	return &credential, nil
+}
+
+// GetStatusPurpose returns the purpose the status is checked for.
+// If the purpose is not requested, the credential should have only one.
+func (e StatusListCredential) GetStatusPurpose(requested string) (string, error) {
+	purposes := e.CredentialSubject.StatusPurpose
+	if requested == "" {
+		if len(purposes) != 1 {
+			return "", errors.New("statusPurpose should be specified for the status list with several purposes")
+		}
+		return purposes[0], nil
+	}
+
+	for _, purpose := range purposes {
+		if purpose == requested {
+			return purpose, nil
+		}
+	}
+	return "", fmt.Errorf("status list has no %s purpose", requested)
+}
+
+func (e StatusListCredential) DecodeBitstring() ([]byte, error) {
+	return utils.DecodeStatusList(e.CredentialSubject.EncodedList)
+}
+
+func (e *CredentialStatus) AddContext(newProtocol string) {}
+func (e *CredentialStatus) RemoveContext()                {}
+func (e *CredentialStatus) GetBytes() []byte              { return []byte{} }
@@ -21,6 +21,51 @@ type DereferencedResource struct {
 	Checksum          string     `json:"checksum" example:"a95380f460e63ad939541a57aecbfd795fcd37c6d78ee86c885340e33a91b559"`
 	PreviousVersionId *string    `json:"previousVersionId" example:"ad7a8442-3531-46eb-a024-53953ec6e4ff"`
 	NextVersionId     *string    `json:"nextVersionId" example:"d4829ac7-4566-478c-a408-b44767eddadc"`
+	// DID URLs of other versions of the resource
+	Links *ResourceVersionLinks `json:"links,omitempty"`
+}
+
+// DereferencedResourceFields are JSON names of DereferencedResource fields which can be selected by resourceFields query
+var DereferencedResourceFields = []string{
+	"resourceURI", "resourceCollectionId", "resourceId", "resourceName", "resourceType", "mediaType",
+	"resourceVersion", "created", "checksum", "previousVersionId", "nextVersionId", "links",
+}
+
+// ResourceProjection is the resource with only the fields selected by resourceFields query
+type ResourceProjection map[string]any
+
+// Project returns the selected fields of the resource. The fields are expected to be from DereferencedResourceFields.
+func (e DereferencedResource) Project(fields []string) ResourceProjection {
+	projection := ResourceProjection{}
+	for _, field := range fields {
+		switch field {
+		case "resourceURI":
+			projection[field] = e.ResourceURI
+		case "resourceCollectionId":
+			projection[field] = e.CollectionId
+		case "resourceId":
+			projection[field] = e.ResourceId
+		case "resourceName":
+			projection[field] = e.Name
+		case "resourceType":
+			projection[field] = e.ResourceType
+		case "mediaType":
+			projection[field] = e.MediaType
+		case "resourceVersion":
+			projection[field] = e.Version
+		case "created":
+			projection[field] = e.Created
+		case "checksum":
+			projection[field] = e.Checksum
+		case "previousVersionId":
+			projection[field] = e.PreviousVersionId
+		case "nextVersionId":
+			projection[field] = e.NextVersionId
+		case "links":
+			projection[field] = e.Links
+		}
+	}
+	return projection
 }
 
 func NewDereferencedResource(did string, resource *resourceTypes.Metadata) *DereferencedResource {
@@ -44,9 +89,41 @@ func NewDereferencedResource(did string, resource *resourceTypes.Metadata) *Dere
 		Checksum:          resource.Checksum,
 		PreviousVersionId: previousVersionId,
 		NextVersionId:     nextVersionId,
+		Links:             NewResourceVersionLinks(did, resource),
 	}
 }
 
+type ResourceVersionLinks struct {
+	Previous string `json:"previousVersion,omitempty" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/ad7a8442-3531-46eb-a024-53953ec6e4ff"`
+	Next     string `json:"nextVersion,omitempty" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/d4829ac7-4566-478c-a408-b44767eddadc"`
+	Latest   string `json:"latestVersion" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47?resourceName=Image+Resource&resourceType=Image&resourceVersion=latest"`
+	First    string `json:"firstVersion" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47?resourceName=Image+Resource&resourceType=Image&resourceVersion=first"`
+}
+
+// NewResourceVersionLinks builds links to the versions of the resource. Versions of the resource
+// have the same name and type, so the latest and the first ones are addressed by queries.
+func NewResourceVersionLinks(did string, resource *resourceTypes.Metadata) *ResourceVersionLinks {
+	versionQuery := func(version string) string {
+		return did + "?" + url.Values{
+			ResourceName:    {resource.Name},
+			ResourceType:    {resource.ResourceType},
+			ResourceVersion: {version},
+		}.Encode()
+	}
+
+	links := ResourceVersionLinks{
+		Latest: versionQuery(ResourceVersionLatest),
+		First:  versionQuery(ResourceVersionFirst),
+	}
+	if resource.PreviousVersionId != "" {
+		links.Previous = did + RESOURCE_PATH + resource.PreviousVersionId
+	}
+	if resource.NextVersionId != "" {
+		links.Next = did + RESOURCE_PATH + resource.NextVersionId
+	}
+	return &links
+}
+
 type DereferencedResourceListStruct struct {
 	Resources DereferencedResourceList `json:"linkedResourceMetadata,omitempty"`
 }
@@ -74,6 +151,15 @@ func (e *DereferencedResourceList) AddContext(newProtocol string) {}
 func (e *DereferencedResourceList) RemoveContext()                {}
 func (e *DereferencedResourceList) GetBytes() []byte              { return []byte{} }
 
+// Project returns the selected fields of every resource
+func (e DereferencedResourceList) Project(fields []string) []ResourceProjection {
+	projections := make([]ResourceProjection, len(e))
+	for i, r := range e {
+		projections[i] = r.Project(fields)
+	}
+	return projections
+}
+
 func (e DereferencedResourceList) GetByResourceId(resourceId string) DereferencedResourceList {
 	for _, r := range e {
 		if r.ResourceId == resourceId {
@@ -133,6 +219,67 @@ func (e DereferencedResourceList) FilterByChecksum(checksum string) Dereferenced
 	return filteredResources
 }
 
+func (e DereferencedResourceList) FilterByMediaType(mediaType string) DereferencedResourceList {
+	filteredResources := DereferencedResourceList{}
+	for _, r := range e {
+		if r.MediaType == mediaType {
+			filteredResources = append(filteredResources, r)
+		}
+	}
+	return filteredResources
+}
+
+// FilterByCreated keeps resources created strictly after and before the given times. Nil time doesn't limit the range
+func (e DereferencedResourceList) FilterByCreated(after *time.Time, before *time.Time) DereferencedResourceList {
+	filteredResources := DereferencedResourceList{}
+	for _, r := range e {
+		if r.Created == nil || (after != nil && !r.Created.After(*after)) || (before != nil && !r.Created.Before(*before)) {
+			continue
+		}
+		filteredResources = append(filteredResources, r)
+	}
+	return filteredResources
+}
+
+// FilterLatestVersions keeps only the newest resource of every name and type
+func (e DereferencedResourceList) FilterLatestVersions() DereferencedResourceList {
+	return e.filterVersions(func(r, found DereferencedResource) bool { return r.Created.After(*found.Created) })
+}
+
+// FilterFirstVersions keeps only the oldest resource of every name and type
+func (e DereferencedResourceList) FilterFirstVersions() DereferencedResourceList {
+	return e.filterVersions(func(r, found DereferencedResource) bool { return r.Created.Before(*found.Created) })
+}
+
+func (e DereferencedResourceList) filterVersions(replaces func(r, found DereferencedResource) bool) DereferencedResourceList {
+	versions := map[[2]string]int{}
+	filteredResources := DereferencedResourceList{}
+	for _, r := range e {
+		key := [2]string{r.Name, r.ResourceType}
+		i, ok := versions[key]
+		switch {
+		case !ok:
+			versions[key] = len(filteredResources)
+			filteredResources = append(filteredResources, r)
+		case replaces(r, filteredResources[i]):
+			filteredResources[i] = r
+		}
+	}
+	return filteredResources
+}
+
+// GetVersions returns all versions of the resource from the oldest to the newest
+func (e DereferencedResourceList) GetVersions(resourceId string) DereferencedResourceList {
+	resource := e.GetByResourceId(resourceId)
+	if len(resource) == 0 {
+		return resource
+	}
+
+	versions := e.FilterByResourceName(resource[0].Name).FilterByResourceType(resource[0].ResourceType)
+	sort.Stable(sort.Reverse(versions))
+	return versions
+}
+
 func (e DereferencedResourceList) FindBeforeTime(stime string) (string, error) {
 	search_time, err := utils.ParseFromStringTimeToGoTime(stime)
 	if err != nil {
@@ -152,6 +299,21 @@ func (e DereferencedResourceList) FindBeforeTime(stime string) (string, error) {
 	return "", nil
 }
 
+// FindNextVersionTime returns creation time of the resource which replaced the given one, or nil if it's the latest
+func (e DereferencedResourceList) FindNextVersionTime(resourceId string) *time.Time {
+	versions := e
+	sort.Sort(versions)
+	for i, v := range versions {
+		if v.ResourceId == resourceId {
+			if i == 0 {
+				return nil
+			}
+			return versions[i-1].Created
+		}
+	}
+	return nil
+}
+
 func (e DereferencedResourceList) FindAllBeforeTime(stime string) (DereferencedResourceList, error) {
 	l := DereferencedResourceList{}
 	search_time, err := utils.ParseFromStringTimeToGoTime(stime)
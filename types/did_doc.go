@@ -3,7 +3,9 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/url"
+	"sort"
 	"strings"
 
 	did "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
@@ -231,6 +233,41 @@ func NewAssertionMethod(protoAssertionMethod string) *AssertionMethod {
 	}
 }
 
+// HasVerificationRelationship checks whether the verification method is referenced
+// under the given verification relationship of the DID Document
+func (e DidDoc) HasVerificationRelationship(relationship VerificationRelationship, verificationMethodId string) bool {
+	var references []string
+	switch relationship {
+	case AuthenticationRelationship:
+		references = e.Authentication
+	case AssertionMethodRelationship:
+		for _, assertionMethod := range e.AssertionMethod {
+			if assertionMethod.Id != nil {
+				references = append(references, *assertionMethod.Id)
+			} else if assertionMethod.AssertionMethodJSON != nil {
+				references = append(references, assertionMethod.AssertionMethodJSON.Id)
+			}
+		}
+	case CapabilityInvocationRelationship:
+		references = e.CapabilityInvocation
+	case CapabilityDelegationRelationship:
+		references = e.CapabilityDelegation
+	case KeyAgreementRelationship:
+		references = e.KeyAgreement
+	}
+
+	for _, reference := range references {
+		// Relative references like "#key-1" point to the current DID Document
+		if strings.HasPrefix(reference, "#") {
+			reference = e.Id + reference
+		}
+		if reference == verificationMethodId {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *DidDoc) AddContext(newProtocol string) { e.Context = AddElemToSet(e.Context, newProtocol) }
 func (e *DidDoc) RemoveContext()                { e.Context = nil }
 func (e *DidDoc) GetBytes() []byte              { return []byte{} }
@@ -246,14 +283,57 @@ func (e *VerificationMethod) RemoveContext()   { e.Context = nil }
 func (e *VerificationMethod) GetBytes() []byte { return []byte{} }
 
 func (d DidDoc) GetServiceByName(serviceId string) (string, error) {
+	endpoints, err := d.GetServiceEndpoints(serviceId, "")
+	if err != nil || len(endpoints) == 0 {
+		return "", err
+	}
+	return endpoints[0], nil
+}
+
+// GetServices returns services matching the id fragment and the type, ordered by priority.
+// Empty serviceId or serviceType matches any service. Lower priority value goes first,
+// and services without priority, which is 0 on the ledger, go last.
+func (d DidDoc) GetServices(serviceId string, serviceType string) ([]Service, error) {
+	var result []Service
 	for _, s := range d.Service {
-		_url, err := url.Parse(s.Id)
-		if err != nil {
-			return "", err
+		if serviceId != "" {
+			_url, err := url.Parse(s.Id)
+			if err != nil {
+				return nil, err
+			}
+			if _url.Fragment != serviceId {
+				continue
+			}
 		}
-		if _url.Fragment == serviceId {
-			return s.ServiceEndpoint[0], nil
+		if serviceType != "" && s.Type != serviceType {
+			continue
+		}
+		result = append(result, s)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return servicePriorityRank(result[i].Priority) < servicePriorityRank(result[j].Priority)
+	})
+	return result, nil
+}
+
+func servicePriorityRank(priority uint32) uint64 {
+	if priority == 0 {
+		return math.MaxUint32 + 1
+	}
+	return uint64(priority)
+}
+
+// GetServiceEndpoints returns all endpoints of the matching service with the highest priority, in the listed order
+func (d DidDoc) GetServiceEndpoints(serviceId string, serviceType string) ([]string, error) {
+	services, err := d.GetServices(serviceId, serviceType)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range services {
+		if len(s.ServiceEndpoint) > 0 {
+			return s.ServiceEndpoint, nil
 		}
 	}
-	return "", nil
+	return nil, nil
 }
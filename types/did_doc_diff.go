@@ -0,0 +1,136 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+type DidDocDiff struct {
+	From    *ResolutionDidDocMetadata `json:"from"`
+	To      *ResolutionDidDocMetadata `json:"to"`
+	Patch   JsonPatch                 `json:"patch"`
+	Summary DidDocDiffSummary         `json:"summary"`
+}
+
+type DidDocDiffSummary struct {
+	KeysAdded          []string `json:"keysAdded" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47#key-2"`
+	KeysRemoved        []string `json:"keysRemoved" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47#key-1"`
+	KeysChanged        []string `json:"keysChanged"`
+	ServicesAdded      []string `json:"servicesAdded"`
+	ServicesRemoved    []string `json:"servicesRemoved"`
+	ServicesChanged    []string `json:"servicesChanged" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47#service-1"`
+	ControllersAdded   []string `json:"controllersAdded"`
+	ControllersRemoved []string `json:"controllersRemoved"`
+}
+
+func NewDidDocDiff(did string, from *didTypes.DidDocWithMetadata, to *didTypes.DidDocWithMetadata) (*DidDocDiff, error) {
+	fromDidDoc := NewDidDoc(from.DidDoc)
+	toDidDoc := NewDidDoc(to.DidDoc)
+
+	fromJson, err := toGenericJson(&fromDidDoc)
+	if err != nil {
+		return nil, err
+	}
+	toJson, err := toGenericJson(&toDidDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Linked resources are not a part of the DID document, so they are skipped here
+	return &DidDocDiff{
+		From:    NewResolutionDidDocMetadata(did, from.Metadata, nil),
+		To:      NewResolutionDidDocMetadata(did, to.Metadata, nil),
+		Patch:   NewJsonPatch(fromJson, toJson),
+		Summary: NewDidDocDiffSummary(fromDidDoc, toDidDoc),
+	}, nil
+}
+
+func NewDidDocDiffSummary(from DidDoc, to DidDoc) DidDocDiffSummary {
+	fromKeys := map[string]VerificationMethod{}
+	for _, vm := range from.VerificationMethod {
+		fromKeys[vm.Id] = vm
+	}
+	toKeys := map[string]VerificationMethod{}
+	for _, vm := range to.VerificationMethod {
+		toKeys[vm.Id] = vm
+	}
+
+	fromServices := map[string]Service{}
+	for _, s := range from.Service {
+		fromServices[s.Id] = s
+	}
+	toServices := map[string]Service{}
+	for _, s := range to.Service {
+		toServices[s.Id] = s
+	}
+
+	summary := DidDocDiffSummary{
+		KeysAdded:          []string{},
+		KeysRemoved:        []string{},
+		KeysChanged:        []string{},
+		ServicesAdded:      []string{},
+		ServicesRemoved:    []string{},
+		ServicesChanged:    []string{},
+		ControllersAdded:   []string{},
+		ControllersRemoved: []string{},
+	}
+
+	for _, vm := range to.VerificationMethod {
+		previous, ok := fromKeys[vm.Id]
+		if !ok {
+			summary.KeysAdded = append(summary.KeysAdded, vm.Id)
+		} else if !reflect.DeepEqual(previous, vm) {
+			summary.KeysChanged = append(summary.KeysChanged, vm.Id)
+		}
+	}
+	for _, vm := range from.VerificationMethod {
+		if _, ok := toKeys[vm.Id]; !ok {
+			summary.KeysRemoved = append(summary.KeysRemoved, vm.Id)
+		}
+	}
+
+	for _, s := range to.Service {
+		previous, ok := fromServices[s.Id]
+		if !ok {
+			summary.ServicesAdded = append(summary.ServicesAdded, s.Id)
+		} else if !reflect.DeepEqual(previous, s) {
+			summary.ServicesChanged = append(summary.ServicesChanged, s.Id)
+		}
+	}
+	for _, s := range from.Service {
+		if _, ok := toServices[s.Id]; !ok {
+			summary.ServicesRemoved = append(summary.ServicesRemoved, s.Id)
+		}
+	}
+
+	for _, controller := range to.Controller {
+		if !utils.Contains(from.Controller, controller) {
+			summary.ControllersAdded = append(summary.ControllersAdded, controller)
+		}
+	}
+	for _, controller := range from.Controller {
+		if !utils.Contains(to.Controller, controller) {
+			summary.ControllersRemoved = append(summary.ControllersRemoved, controller)
+		}
+	}
+
+	return summary
+}
+
+func toGenericJson(v interface{}) (interface{}, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	err = json.Unmarshal(bytes, &result)
+	return result, err
+}
+
+func (e *DidDocDiff) AddContext(newProtocol string) {}
+func (e *DidDocDiff) RemoveContext()                {}
+func (e *DidDocDiff) GetBytes() []byte              { return []byte{} }
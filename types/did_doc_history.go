@@ -0,0 +1,62 @@
+package types
+
+import (
+	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
+)
+
+// DidDocHistory holds versions metadata of a DID Document in chronological order.
+// DID Documents of the versions are resolved while the history is written to the client
+// in JSON Lines format, one DidDocHistoryEntry per line.
+type DidDocHistory struct {
+	Versions    DidDocMetadataList
+	contentType ContentType
+}
+
+type DidDocHistoryEntry struct {
+	Context  string                    `json:"@context,omitempty" example:"https://w3id.org/did-resolution/v1"`
+	Did      *DidDoc                   `json:"didDocument"`
+	Metadata *ResolutionDidDocMetadata `json:"didDocumentMetadata"`
+}
+
+// NewDidDocHistory builds the history from versions metadata sorted from the latest to the oldest one,
+// as returned by NewDereferencedDidVersionsList.
+func NewDidDocHistory(versions DidDocMetadataList, contentType ContentType) *DidDocHistory {
+	history := DidDocHistory{Versions: make(DidDocMetadataList, 0, len(versions)), contentType: contentType}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		metadata := versions[i]
+		metadata.Resources = versions.GetResourcesBeforeNextVersion(metadata.VersionId)
+		history.Versions = append(history.Versions, metadata)
+	}
+
+	return &history
+}
+
+// NewEntry returns the i-th version of the history with its DID Document
+func (h DidDocHistory) NewEntry(i int, didDoc *didTypes.DidDoc) DidDocHistoryEntry {
+	doc := NewDidDoc(didDoc)
+	metadata := h.Versions[i]
+
+	entry := DidDocHistoryEntry{Did: &doc, Metadata: &metadata}
+	if h.contentType == DIDJSONLD || h.contentType == JSONLD {
+		doc.AddContext(DIDSchemaJSONLD)
+		entry.Context = ResolutionSchemaJSONLD
+	} else {
+		doc.RemoveContext()
+	}
+	return entry
+}
+
+// Interface implementation
+
+func (h DidDocHistory) GetContentType() string {
+	return string(JSONL)
+}
+
+func (h DidDocHistory) GetBytes() []byte {
+	return []byte{}
+}
+
+func (h DidDocHistory) IsRedirect() bool {
+	return false
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"slices"
 	"sort"
 	"time"
 
@@ -68,6 +69,45 @@ func (dd DidDocMetadataList) GetResourcesBeforeNextVersion(versionId string) Der
 	return DereferencedResourceList{}
 }
 
+// GetVersionWindow returns the interval in which the version was the active one.
+// validUntil is nil for the latest version, validFrom and validUntil are both nil if there is no such version.
+func (dd DidDocMetadataList) GetVersionWindow(versionId string) (validFrom *time.Time, validUntil *time.Time) {
+	// Sort a copy, the order of the list belongs to the caller
+	versions := slices.Clone(dd)
+	sort.Sort(versions)
+	for i, version := range versions {
+		if version.VersionId == versionId {
+			validFrom = activeSince(version)
+			// Versions are sorted in reverse order, so the next version is the previous element
+			if i > 0 {
+				validUntil = activeSince(versions[i-1])
+			}
+			return validFrom, validUntil
+		}
+	}
+	return nil, nil
+}
+
+// IsDeactivatedInVersion returns true if the DID was already deactivated while the version was the active one.
+// cheqd-node deactivates a DID by adding a new version and then sets deactivated on all the previous versions
+// as well (MsgDeactivateDidDoc in x/did/keeper/msg_server_deactivate_did_doc.go), so the flag alone doesn't tell
+// when the DID was deactivated. The DID is deactivated only from the version without nextVersionId on.
+func (dd DidDocMetadataList) IsDeactivatedInVersion(versionId string) bool {
+	for _, version := range dd {
+		if version.VersionId == versionId {
+			return version.Deactivated && version.NextVersionId == ""
+		}
+	}
+	return false
+}
+
+func activeSince(version ResolutionDidDocMetadata) *time.Time {
+	if version.Updated != nil {
+		return version.Updated
+	}
+	return version.Created
+}
+
 func (dd DidDocMetadataList) Len() int {
 	return len(dd)
 }
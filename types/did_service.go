@@ -2,7 +2,8 @@ package types
 
 // Implements ResolutionResult interface
 type ServiceResult struct {
-	endpoint string
+	// All endpoints of the service, the request is redirected to the first one
+	endpoints []string
 }
 
 // Interface implementation
@@ -11,11 +12,14 @@ func (s ServiceResult) GetContentType() string {
 }
 
 func (s ServiceResult) GetBytes() []byte {
-	return []byte(s.endpoint)
+	return []byte(s.GetServiceEndpoint())
 }
 
 func (s ServiceResult) GetServiceEndpoint() string {
-	return s.endpoint
+	if len(s.endpoints) == 0 {
+		return ""
+	}
+	return s.endpoints[0]
 }
 
 func (r ServiceResult) IsRedirect() bool {
@@ -24,6 +28,18 @@ func (r ServiceResult) IsRedirect() bool {
 
 // end of Interface implementation
 
-func NewServiceResult(endpoint string) *ServiceResult {
-	return &ServiceResult{endpoint: endpoint}
+func (s ServiceResult) GetServiceEndpoints() []string {
+	return s.endpoints
+}
+
+// GetAlternateEndpoints returns the endpoints of the service other than the one the request is redirected to
+func (s ServiceResult) GetAlternateEndpoints() []string {
+	if len(s.endpoints) < 2 {
+		return nil
+	}
+	return s.endpoints[1:]
+}
+
+func NewServiceResult(endpoints ...string) *ServiceResult {
+	return &ServiceResult{endpoints: endpoints}
 }
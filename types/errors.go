@@ -77,6 +77,11 @@ func NewInternalError(did string, contentType ContentType, err error, isDerefere
 	return NewIdentityError(InternalErrorHttpCode, "internalError", isDereferencing, did, contentType, err)
 }
 
+// NewChecksumMismatchError is returned when resource data doesn't match the checksum stored on the ledger
+func NewChecksumMismatchError(did string, contentType ContentType, err error, isDereferencing bool) *IdentityError {
+	return NewIdentityError(InternalErrorHttpCode, "checksumMismatch", isDereferencing, did, contentType, err)
+}
+
 func NewMethodNotSupportedError(did string, contentType ContentType, err error, isDereferencing bool) *IdentityError {
 	return NewIdentityError(MethodNotSupportedHttpCode, "methodNotSupported", isDereferencing, did, contentType, err)
 }
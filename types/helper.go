@@ -1,12 +1,14 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
@@ -56,12 +58,15 @@ func ParseGRPCEndpoint(configEndpoint string) (*Endpoint, error) {
 
 // Config functions
 
+// ConfigFile is read on start, if it exists, and watched for changes. Environment variables take precedence over it.
+const ConfigFile = "config.env"
+
 func LoadConfig() (Config, error) {
-	if _, err := os.Stat("config.env"); err == nil {
-		viper.SetConfigFile("config.env")
+	if _, err := os.Stat(ConfigFile); err == nil {
+		viper.SetConfigFile(ConfigFile)
 		err := viper.ReadInConfig()
 		if err != nil {
-			return Config{}, fmt.Errorf("error reading config.env: %v", err)
+			return Config{}, fmt.Errorf("error reading %s: %v", ConfigFile, err)
 		}
 	}
 	viper.SetDefault("MAINNET_ENDPOINT", "")
@@ -71,6 +76,15 @@ func LoadConfig() (Config, error) {
 	viper.SetDefault("ENABLE_FALLBACK_ENDPOINTS", false)
 	viper.SetDefault("LOG_LEVEL", "")
 	viper.SetDefault("RESOLVER_LISTENER", "")
+	viper.SetDefault("DID_URL_PATH_SERVICE", "")
+	viper.SetDefault("RESOURCE_BY_NAME_REDIRECT", true)
+	viper.SetDefault("SNAPSHOT_PATH", "")
+	viper.SetDefault("CACHE_DIR", "")
+	viper.SetDefault("CACHE_MAX_SIZE_MB", 256)
+	viper.SetDefault("LEDGER_RECORD_PATH", "")
+	viper.SetDefault("LEDGER_REPLAY_PATH", "")
+	viper.SetDefault("ADMIN_LISTENER", "")
+	viper.SetDefault("ADMIN_TOKEN", "")
 	viper.AutomaticEnv()
 
 	rawConf := &RawConfig{}
@@ -80,11 +94,30 @@ func LoadConfig() (Config, error) {
 	}
 	conf, err := NewConfig(*rawConf)
 	if err != nil {
-		return Config{}, fmt.Errorf("invalid config parameter, %v", err)
+		return Config{}, fmt.Errorf("invalid config parameter, %w", err)
 	}
 	return conf, nil
 }
 
+// WatchConfig calls onChange with the config loaded after every change of config.env. Invalid configs are
+// logged and skipped, so the current one stays in use. Returns false if there's no config.env to watch.
+func WatchConfig(onChange func(Config)) bool {
+	if _, err := os.Stat(ConfigFile); err != nil {
+		return false
+	}
+	viper.OnConfigChange(func(event fsnotify.Event) {
+		log.Info().Msgf("Configuration file %s changed", event.Name)
+		config, err := LoadConfig()
+		if err != nil {
+			log.Error().Err(err).Msg("Changed configuration is rejected, keeping the current one")
+			return
+		}
+		onChange(config)
+	})
+	viper.WatchConfig()
+	return true
+}
+
 func MustLoadConfig() Config {
 	config, err := LoadConfig()
 	if err != nil {
@@ -95,106 +128,110 @@ func MustLoadConfig() Config {
 }
 
 func NewConfig(rawConfig RawConfig) (Config, error) {
-	// Parse primary endpoints
-	mainnetPrimary, err := ParseGRPCEndpoint(rawConfig.MainnetEndpoint)
-	if err != nil {
-		return Config{}, err
+	config := Config{
+		EnableFallbackEndpoints: rawConfig.EnableFallbackEndpoints,
+		ResolverListener:        rawConfig.ResolverListener,
+		LogLevel:                rawConfig.LogLevel,
+		DidUrlPathService:       rawConfig.DidUrlPathService,
+		ResourceByNameRedirect:  rawConfig.ResourceByNameRedirect,
+		SnapshotPath:            rawConfig.SnapshotPath,
+		CacheDir:                rawConfig.CacheDir,
+		CacheMaxSizeMB:          rawConfig.CacheMaxSizeMB,
+		LedgerRecordPath:        rawConfig.LedgerRecordPath,
+		LedgerReplayPath:        rawConfig.LedgerReplayPath,
+		AdminListener:           rawConfig.AdminListener,
+		AdminToken:              rawConfig.AdminToken,
 	}
-	testnetPrimary, err := ParseGRPCEndpoint(rawConfig.TestnetEndpoint)
-	if err != nil {
-		return Config{}, err
+
+	namespaceEndpoints := []struct {
+		namespace string
+		primary   string
+		fallback  string
+	}{
+		{"mainnet", rawConfig.MainnetEndpoint, rawConfig.MainnetEndpointFallback},
+		{"testnet", rawConfig.TestnetEndpoint, rawConfig.TestnetEndpointFallback},
 	}
 
-	// Create networks with primary endpoints
-	networks := []Network{
-		{
-			Namespace: "mainnet",
-			Endpoints: []Endpoint{
-				{
-					URL:     mainnetPrimary.URL,
-					UseTls:  mainnetPrimary.UseTls,
-					Timeout: mainnetPrimary.Timeout,
-					Role:    EndpointRolePrimary,
-				},
-			},
-			UseTls:  mainnetPrimary.UseTls,
-			Timeout: mainnetPrimary.Timeout,
-		},
-		{
-			Namespace: "testnet",
+	// All problems are collected, so they can be fixed at once
+	var errs []error
+	if _, err := zerolog.ParseLevel(rawConfig.LogLevel); err != nil {
+		errs = append(errs, fmt.Errorf("invalid LOG_LEVEL: %v", err))
+	}
+	if rawConfig.CacheDir != "" && rawConfig.CacheMaxSizeMB <= 0 {
+		errs = append(errs, fmt.Errorf("CACHE_MAX_SIZE_MB must be positive, got %d", rawConfig.CacheMaxSizeMB))
+	}
+	if rawConfig.LedgerRecordPath != "" && rawConfig.LedgerReplayPath != "" {
+		errs = append(errs, errors.New("LEDGER_RECORD_PATH and LEDGER_REPLAY_PATH can't be set at the same time"))
+	}
+	if rawConfig.AdminListener != "" && rawConfig.AdminToken == "" {
+		errs = append(errs, errors.New("ADMIN_TOKEN is required to enable ADMIN_LISTENER"))
+	}
+	if rawConfig.AdminListener != "" && rawConfig.LedgerReplayPath != "" {
+		errs = append(errs, errors.New("ADMIN_LISTENER can't be used with LEDGER_REPLAY_PATH, which has no endpoints to manage"))
+	}
+
+	for _, endpoints := range namespaceEndpoints {
+		// Namespaces without endpoint are served from the snapshot or the recording only
+		if endpoints.primary == "" && (rawConfig.SnapshotPath != "" || rawConfig.LedgerReplayPath != "") {
+			continue
+		}
+
+		// Parse primary endpoint
+		primary, err := ParseGRPCEndpoint(endpoints.primary)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s endpoint: %v", endpoints.namespace, err))
+			continue
+		}
+		network := Network{
+			Namespace: endpoints.namespace,
 			Endpoints: []Endpoint{
 				{
-					URL:     testnetPrimary.URL,
-					UseTls:  testnetPrimary.UseTls,
-					Timeout: testnetPrimary.Timeout,
+					URL:     primary.URL,
+					UseTls:  primary.UseTls,
+					Timeout: primary.Timeout,
 					Role:    EndpointRolePrimary,
 				},
 			},
-			UseTls:  testnetPrimary.UseTls,
-			Timeout: testnetPrimary.Timeout,
-		},
-	}
+			UseTls:  primary.UseTls,
+			Timeout: primary.Timeout,
+		}
 
-	// Handle fallback endpoints if enabled
-	if !rawConfig.EnableFallbackEndpoints {
-		return Config{
-			Networks:                networks,
-			EnableFallbackEndpoints: rawConfig.EnableFallbackEndpoints,
-			ResolverListener:        rawConfig.ResolverListener,
-			LogLevel:                rawConfig.LogLevel,
-		}, nil
-	}
+		// Handle fallback endpoint if enabled
+		if rawConfig.EnableFallbackEndpoints {
+			// When fallbacks are enabled, ALL namespaces must have fallback endpoints
+			if endpoints.fallback == "" {
+				errs = append(errs, fmt.Errorf("ENABLE_FALLBACK_ENDPOINTS=true but %s_ENDPOINT_FALLBACK is not configured", strings.ToUpper(endpoints.namespace)))
+				continue
+			}
+			fallback, err := ParseGRPCEndpoint(endpoints.fallback)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s fallback endpoint: %v", endpoints.namespace, err))
+				continue
+			}
+			network.Endpoints = append(network.Endpoints, Endpoint{
+				URL:     fallback.URL,
+				UseTls:  fallback.UseTls,
+				Timeout: fallback.Timeout,
+				Role:    EndpointRoleFallback,
+			})
+		}
 
-	// When fallbacks are enabled, ALL namespaces must have fallback endpoints
-	if rawConfig.MainnetEndpointFallback == "" {
-		return Config{}, fmt.Errorf("ENABLE_FALLBACK_ENDPOINTS=true but MAINNET_ENDPOINT_FALLBACK is not configured")
-	}
-	if rawConfig.TestnetEndpointFallback == "" {
-		return Config{}, fmt.Errorf("ENABLE_FALLBACK_ENDPOINTS=true but TESTNET_ENDPOINT_FALLBACK is not configured")
+		config.Networks = append(config.Networks, network)
 	}
 
-	// Parse fallback endpoints
-	mainnetFallback, err := ParseGRPCEndpoint(rawConfig.MainnetEndpointFallback)
-	if err != nil {
-		return Config{}, fmt.Errorf("invalid mainnet fallback endpoint: %v", err)
+	if len(errs) != 0 {
+		return Config{}, errors.Join(errs...)
 	}
-	testnetFallback, err := ParseGRPCEndpoint(rawConfig.TestnetEndpointFallback)
-	if err != nil {
-		return Config{}, fmt.Errorf("invalid testnet fallback endpoint: %v", err)
-	}
-
-	// Add fallback endpoints to existing networks by namespace using a map
-	namespaceFallbacks := map[string]Endpoint{
-		"mainnet": {
-			URL:     mainnetFallback.URL,
-			UseTls:  mainnetFallback.UseTls,
-			Timeout: mainnetFallback.Timeout,
-			Role:    EndpointRoleFallback,
-		},
-		"testnet": {
-			URL:     testnetFallback.URL,
-			UseTls:  testnetFallback.UseTls,
-			Timeout: testnetFallback.Timeout,
-			Role:    EndpointRoleFallback,
-		},
-	}
-	for i, network := range networks {
-		if fallback, ok := namespaceFallbacks[network.Namespace]; ok {
-			networks[i].Endpoints = append(networks[i].Endpoints, fallback)
-		}
+	if !rawConfig.EnableFallbackEndpoints || len(config.Networks) == 0 {
+		return config, nil
 	}
 
 	// Validate that each namespace has at least 2 endpoints (primary + fallback)
-	if err := validateFallbackEndpoints(networks); err != nil {
+	if err := validateFallbackEndpoints(config.Networks); err != nil {
 		return Config{}, err
 	}
 
-	return Config{
-		Networks:                networks,
-		EnableFallbackEndpoints: rawConfig.EnableFallbackEndpoints,
-		ResolverListener:        rawConfig.ResolverListener,
-		LogLevel:                rawConfig.LogLevel,
-	}, nil
+	return config, nil
 }
 
 // validateFallbackEndpoints ensures that when fallbacks are enabled, each namespace has at least 2 endpoints
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	JsonPatchAdd     = "add"
+	JsonPatchRemove  = "remove"
+	JsonPatchReplace = "replace"
+)
+
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// JsonPatchOperation is a single RFC 6902 operation
+type JsonPatchOperation struct {
+	Op    string      `json:"op" example:"replace"`
+	Path  string      `json:"path" example:"/verificationMethod/0/publicKeyMultibase"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON keeps the value for add and replace operations even if it is null, false or empty
+func (o JsonPatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == JsonPatchRemove {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+
+	return json.Marshal(struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}{o.Op, o.Path, o.Value})
+}
+
+type JsonPatch []JsonPatchOperation
+
+// NewJsonPatch builds the list of operations which transforms `from` into `to`.
+// Both values are expected to be generic JSON values, as produced by json.Unmarshal into interface{}.
+func NewJsonPatch(from interface{}, to interface{}) JsonPatch {
+	patch := JsonPatch{}
+	patch.diff("", from, to)
+	return patch
+}
+
+func (p *JsonPatch) diff(path string, from interface{}, to interface{}) {
+	switch fromValue := from.(type) {
+	case map[string]interface{}:
+		toValue, ok := to.(map[string]interface{})
+		if !ok {
+			p.add(JsonPatchReplace, path, to)
+			return
+		}
+		p.diffObjects(path, fromValue, toValue)
+	case []interface{}:
+		toValue, ok := to.([]interface{})
+		if !ok {
+			p.add(JsonPatchReplace, path, to)
+			return
+		}
+		p.diffArrays(path, fromValue, toValue)
+	default:
+		if !reflect.DeepEqual(from, to) {
+			p.add(JsonPatchReplace, path, to)
+		}
+	}
+}
+
+func (p *JsonPatch) diffObjects(path string, from map[string]interface{}, to map[string]interface{}) {
+	for _, key := range sortedKeys(from) {
+		if _, ok := to[key]; !ok {
+			p.add(JsonPatchRemove, path+"/"+jsonPointerEscaper.Replace(key), nil)
+		}
+	}
+
+	for _, key := range sortedKeys(to) {
+		keyPath := path + "/" + jsonPointerEscaper.Replace(key)
+		fromValue, ok := from[key]
+		if !ok {
+			p.add(JsonPatchAdd, keyPath, to[key])
+			continue
+		}
+		p.diff(keyPath, fromValue, to[key])
+	}
+}
+
+func (p *JsonPatch) diffArrays(path string, from []interface{}, to []interface{}) {
+	common := min(len(from), len(to))
+	for i := 0; i < common; i++ {
+		p.diff(path+"/"+strconv.Itoa(i), from[i], to[i])
+	}
+
+	// Remove from the tail, so the indexes of remaining elements stay the same
+	for i := len(from) - 1; i >= common; i-- {
+		p.add(JsonPatchRemove, path+"/"+strconv.Itoa(i), nil)
+	}
+
+	for i := common; i < len(to); i++ {
+		p.add(JsonPatchAdd, path+"/"+strconv.Itoa(i), to[i])
+	}
+}
+
+func (p *JsonPatch) add(op string, path string, value interface{}) {
+	*p = append(*p, JsonPatchOperation{Op: op, Path: path, Value: value})
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"time"
 
 	didTypes "github.com/cheqd/cheqd-node/api/v2/cheqd/did/v2"
@@ -9,13 +10,34 @@ import (
 )
 
 type ResolutionDidDocMetadata struct {
-	Created           *time.Time               `json:"created,omitempty" example:"2021-09-01T12:00:00Z"`
-	Updated           *time.Time               `json:"updated,omitempty" example:"2021-09-10T12:00:00Z"`
-	Deactivated       bool                     `json:"deactivated,omitempty" example:"false"`
-	VersionId         string                   `json:"versionId,omitempty" example:"284f297b-b6e3-4ffa-9172-bc3bb904e286"`
-	NextVersionId     string                   `json:"nextVersionId,omitempty" example:"3f3111af-dfe6-411f-adc9-02af59716ddb"`
-	PreviousVersionId string                   `json:"previousVersionId,omitempty" example:"139445af-4281-4453-b05a-ec9a8931c1f9"`
-	Resources         DereferencedResourceList `json:"linkedResourceMetadata,omitempty"`
+	Created            *time.Time               `json:"created,omitempty" example:"2021-09-01T12:00:00Z"`
+	Updated            *time.Time               `json:"updated,omitempty" example:"2021-09-10T12:00:00Z"`
+	Deactivated        bool                     `json:"deactivated,omitempty" example:"false"`
+	VersionId          string                   `json:"versionId,omitempty" example:"284f297b-b6e3-4ffa-9172-bc3bb904e286"`
+	NextVersionId      string                   `json:"nextVersionId,omitempty" example:"3f3111af-dfe6-411f-adc9-02af59716ddb"`
+	PreviousVersionId  string                   `json:"previousVersionId,omitempty" example:"139445af-4281-4453-b05a-ec9a8931c1f9"`
+	ValidFrom          *time.Time               `json:"validFrom,omitempty" example:"2021-09-10T12:00:00Z"`
+	ValidUntil         *time.Time               `json:"validUntil,omitempty" example:"2021-09-20T12:00:00Z"`
+	Resources          DereferencedResourceList `json:"linkedResourceMetadata,omitempty"`
+	ResourcePagination *ResourcePagination      `json:"linkedResourcePagination,omitempty"`
+	ResourceSummary    *ResourceSummary         `json:"linkedResourceSummary,omitempty"`
+	// Fields of linkedResourceMetadata selected by resourceFields query, all fields are returned if empty
+	ResourceFields []string `json:"-"`
+}
+
+// MarshalJSON returns only the selected fields of linkedResourceMetadata if the selection is set
+func (e ResolutionDidDocMetadata) MarshalJSON() ([]byte, error) {
+	type metadata ResolutionDidDocMetadata
+	if len(e.ResourceFields) == 0 || len(e.Resources) == 0 {
+		return json.Marshal(metadata(e))
+	}
+	return json.Marshal(struct {
+		metadata
+		Resources []ResourceProjection `json:"linkedResourceMetadata"`
+	}{
+		metadata:  metadata(e),
+		Resources: e.Resources.Project(e.ResourceFields),
+	})
 }
 
 func NewResolutionDidDocMetadata(did string, metadata *didTypes.Metadata, resources []*resourceTypes.Metadata) *ResolutionDidDocMetadata {
@@ -12,6 +12,10 @@ type ResolutionMetadata struct {
 	ResolutionError string        `json:"error,omitempty"`
 	Retrieved       string        `json:"retrieved,omitempty" example:"2021-09-01T12:00:00Z"`
 	DidProperties   DidProperties `json:"did,omitempty"`
+	// Set for dereferenced resource data if its checksum matches the one stored on the ledger
+	ChecksumVerified bool `json:"checksumVerified,omitempty"`
+	// Set for dereferenced resource data validated against JSON Schema
+	SchemaValidation *SchemaValidation `json:"schemaValidation,omitempty"`
 }
 
 type DidProperties struct {
@@ -48,6 +52,13 @@ func (r DidResolution) GetServiceByName(serviceName string) (string, error) {
 	return r.Did.GetServiceByName(serviceName)
 }
 
+func (r DidResolution) GetServiceEndpoints(serviceName string, serviceType string) ([]string, error) {
+	if r.Did == nil {
+		return nil, errors.New("did document is nil")
+	}
+	return r.Did.GetServiceEndpoints(serviceName, serviceType)
+}
+
 // end of Interface implementation
 
 func NewResolutionMetadata(didUrl string, contentType ContentType, resolutionError string) ResolutionMetadata {
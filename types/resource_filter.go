@@ -0,0 +1,80 @@
+package types
+
+import (
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/cheqd/did-resolver/utils"
+)
+
+// ResourceFilter selects resources of the collection by their metadata. Empty fields don't filter
+type ResourceFilter struct {
+	ResourceType string
+	ResourceName string
+	MediaType    string
+	// Either the value of resourceVersion, or latest or first version in the order of creation
+	Version       string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// NewResourceFilter reads the filter from the queries of resource collection listing
+func NewResourceFilter(queries url.Values) (*ResourceFilter, error) {
+	filter := ResourceFilter{
+		ResourceType: queries.Get(ResourceType),
+		ResourceName: queries.Get(ResourceName),
+		MediaType:    queries.Get(MediaTypeQ),
+		Version:      queries.Get(ResourceVersion),
+	}
+
+	if createdAfter := queries.Get(CreatedAfterQ); createdAfter != "" {
+		t, err := utils.ParseFromStringTimeToGoTime(createdAfter)
+		if err != nil {
+			return nil, err
+		}
+		filter.CreatedAfter = &t
+	}
+
+	if createdBefore := queries.Get(CreatedBeforeQ); createdBefore != "" {
+		t, err := utils.ParseFromStringTimeToGoTime(createdBefore)
+		if err != nil {
+			return nil, err
+		}
+		filter.CreatedBefore = &t
+	}
+	return &filter, nil
+}
+
+// Apply returns the resources matching the filter from the newest to the oldest.
+// Resources created at the same time keep their order in the collection.
+func (f ResourceFilter) Apply(resources DereferencedResourceList) DereferencedResourceList {
+	filtered := make(DereferencedResourceList, len(resources))
+	copy(filtered, resources)
+
+	if f.ResourceType != "" {
+		filtered = filtered.FilterByResourceType(f.ResourceType)
+	}
+	if f.ResourceName != "" {
+		filtered = filtered.FilterByResourceName(f.ResourceName)
+	}
+	if f.MediaType != "" {
+		filtered = filtered.FilterByMediaType(f.MediaType)
+	}
+	if f.CreatedAfter != nil || f.CreatedBefore != nil {
+		filtered = filtered.FilterByCreated(f.CreatedAfter, f.CreatedBefore)
+	}
+
+	switch f.Version {
+	case "":
+	case ResourceVersionLatest:
+		filtered = filtered.FilterLatestVersions()
+	case ResourceVersionFirst:
+		filtered = filtered.FilterFirstVersions()
+	default:
+		filtered = filtered.FilterByResourceVersion(f.Version)
+	}
+
+	sort.Stable(filtered)
+	return filtered
+}
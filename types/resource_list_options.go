@@ -0,0 +1,263 @@
+package types
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	query "cosmossdk.io/api/cosmos/base/query/v1beta1"
+	"github.com/cheqd/did-resolver/utils"
+)
+
+const (
+	ResourceSortCreated = "created"
+	ResourceSortName    = "name"
+	ResourceSortVersion = "version"
+	// Prefix of the sort order, e.g. -created sorts resources from the newest to the oldest
+	ResourceSortDescending = "-"
+
+	// Value of resourceMetadata query to return counts of resources instead of the full list
+	ResourceMetadataSummary = "summary"
+
+	MaxResourceLimit = 1000
+)
+
+// ResourceListOptions paginates, sorts and selects fields of linkedResourceMetadata.
+//
+// Lists of the whole collection are paginated by the ledger, and the cursor is the ledger key of the next page.
+// Sorted lists and lists of a part of the collection, e.g. resources of a DID Document version, are paginated
+// by the resolver, and the cursor is the id of the last resource of the previous page.
+type ResourceListOptions struct {
+	Limit int
+	// Decoded nextCursor of the previous page
+	Cursor  string
+	Sort    string
+	Fields  []string
+	Summary bool
+}
+
+type ResourcePagination struct {
+	// Total number of resources. The ledger counts them only for the first page.
+	Total      int    `json:"total,omitempty" example:"1200"`
+	Limit      int    `json:"limit,omitempty" example:"100"`
+	NextCursor string `json:"nextCursor,omitempty" example:"Mzk4Y2VlMGEtZWZhYy00NjQzLTlmNGMtNzRjNDhjNzJhMTRi"`
+}
+
+// ResourceSummary counts linked resources per resourceType and resourceName
+type ResourceSummary struct {
+	Total     int                    `json:"total" example:"1200"`
+	Resources []ResourceSummaryEntry `json:"resources"`
+}
+
+type ResourceSummaryEntry struct {
+	ResourceType  string     `json:"resourceType" example:"StatusList2021Revocation"`
+	Name          string     `json:"resourceName" example:"revocation-list"`
+	Count         int        `json:"count" example:"1199"`
+	LatestCreated *time.Time `json:"latestCreated,omitempty" example:"2021-09-01T12:00:00Z"`
+}
+
+// NewResourceListOptions reads resource list queries. Nil is returned if there are no such queries.
+func NewResourceListOptions(queries url.Values) (*ResourceListOptions, error) {
+	options := ResourceListOptions{
+		Sort:    queries.Get(ResourceSortQ),
+		Summary: queries.Get(ResourceMetadata) == ResourceMetadataSummary,
+	}
+
+	if limit := queries.Get(ResourceLimitQ); limit != "" {
+		var err error
+		if options.Limit, err = strconv.Atoi(limit); err != nil {
+			return nil, err
+		}
+		if options.Limit < 1 || options.Limit > MaxResourceLimit {
+			return nil, fmt.Errorf("%s should be from 1 to %d", ResourceLimitQ, MaxResourceLimit)
+		}
+	}
+
+	if cursor := queries.Get(ResourceCursorQ); cursor != "" {
+		key, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if len(key) == 0 {
+			return nil, fmt.Errorf("%s is not valid", ResourceCursorQ)
+		}
+		options.Cursor = string(key)
+	}
+
+	switch strings.TrimPrefix(options.Sort, ResourceSortDescending) {
+	case "", ResourceSortCreated, ResourceSortName, ResourceSortVersion:
+	default:
+		return nil, fmt.Errorf("resources can't be sorted by %s", options.Sort)
+	}
+
+	if fields := queries.Get(ResourceFieldsQ); fields != "" {
+		options.Fields = strings.Split(fields, ",")
+		for _, field := range options.Fields {
+			if !utils.Contains(DereferencedResourceFields, field) {
+				return nil, fmt.Errorf("resource has no %s field", field)
+			}
+		}
+	}
+
+	if options.Summary && (options.Limit != 0 || options.Cursor != "" || options.Sort != "" || len(options.Fields) != 0) {
+		return nil, errors.New("resource summary can't be paginated, sorted or filtered by fields")
+	}
+
+	if !options.Summary && options.Limit == 0 && options.Cursor == "" && options.Sort == "" && len(options.Fields) == 0 {
+		return nil, nil
+	}
+	return &options, nil
+}
+
+// PageRequest returns the page of the collection to be requested from the ledger, or nil if the list isn't paginated.
+// The ledger pages resources in the order of its keys, so sorted lists are fetched whole and paginated by the resolver.
+func (o ResourceListOptions) PageRequest() *query.PageRequest {
+	if o.Limit == 0 && o.Cursor == "" || o.Sort != "" {
+		return nil
+	}
+	// The ledger counts resources only if the page is requested without the key
+	return &query.PageRequest{Key: []byte(o.Cursor), Limit: uint64(o.Limit), CountTotal: o.Cursor == ""}
+}
+
+// NewResourcePagination describes the page of the collection returned by the ledger
+func NewResourcePagination(request *query.PageRequest, response *query.PageResponse) *ResourcePagination {
+	pagination := ResourcePagination{Limit: int(request.Limit)}
+	if response != nil {
+		pagination.Total = int(response.Total)
+		if len(response.NextKey) != 0 {
+			pagination.NextCursor = base64.RawURLEncoding.EncodeToString(response.NextKey)
+		}
+	}
+	return &pagination
+}
+
+// Apply returns either the requested page of resources or their summary.
+// Selection of fields is left to the response metadata, see ResolutionDidDocMetadata.ResourceFields.
+func (o ResourceListOptions) Apply(resources DereferencedResourceList) (DereferencedResourceList, *ResourcePagination, *ResourceSummary, error) {
+	if o.Summary {
+		return nil, nil, NewResourceSummary(resources), nil
+	}
+
+	page := make(DereferencedResourceList, len(resources))
+	copy(page, resources)
+	// Stable sort keeps the original order of resources with the same key
+	if o.Sort != "" {
+		field := strings.TrimPrefix(o.Sort, ResourceSortDescending)
+		descending := strings.HasPrefix(o.Sort, ResourceSortDescending)
+		sort.SliceStable(page, func(i, j int) bool {
+			a, b := page[i], page[j]
+			if descending {
+				a, b = b, a
+			}
+			switch field {
+			case ResourceSortName:
+				return a.Name < b.Name
+			case ResourceSortVersion:
+				return a.Version < b.Version
+			default:
+				return a.Created != nil && b.Created != nil && a.Created.Before(*b.Created)
+			}
+		})
+	}
+
+	var pagination *ResourcePagination
+	if o.Limit != 0 || o.Cursor != "" {
+		pagination = &ResourcePagination{Total: len(page), Limit: o.Limit}
+	}
+
+	if o.Cursor != "" {
+		position := -1
+		for i, r := range page {
+			if r.ResourceId == o.Cursor {
+				position = i
+				break
+			}
+		}
+		if position == -1 {
+			return nil, nil, nil, fmt.Errorf("resource %s from the cursor is not found", o.Cursor)
+		}
+		page = page[position+1:]
+	}
+
+	if o.Limit != 0 && len(page) > o.Limit {
+		page = page[:o.Limit]
+		pagination.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(page[len(page)-1].ResourceId))
+	}
+	return page, pagination, nil, nil
+}
+
+// ApplyToMetadata replaces linkedResourceMetadata with the requested page or summary.
+// If the ledger has already returned the page, which happens only for unsorted lists, it's kept as is.
+func (o ResourceListOptions) ApplyToMetadata(metadata *ResolutionDidDocMetadata) error {
+	ledgerPagination := metadata.ResourcePagination
+	if ledgerPagination != nil {
+		o.Limit, o.Cursor = 0, ""
+	}
+	resources, pagination, summary, err := o.Apply(metadata.Resources)
+	if err != nil {
+		return err
+	}
+	if ledgerPagination != nil {
+		pagination = ledgerPagination
+	}
+	metadata.Resources, metadata.ResourcePagination, metadata.ResourceSummary = resources, pagination, summary
+	metadata.ResourceFields = o.Fields
+	return nil
+}
+
+// ApplyToResourceMetadata does the same as ApplyToMetadata for metadata of resource collection dereferencing
+func (o ResourceListOptions) ApplyToResourceMetadata(metadata *ResolutionResourceMetadata) error {
+	if metadata.Resources == nil {
+		return nil
+	}
+	ledgerPagination := metadata.ResourcePagination
+	if ledgerPagination != nil {
+		o.Limit, o.Cursor = 0, ""
+	}
+	resources, pagination, summary, err := o.Apply(*metadata.Resources)
+	if err != nil {
+		return err
+	}
+	if ledgerPagination != nil {
+		pagination = ledgerPagination
+	}
+	metadata.Resources, metadata.ResourcePagination, metadata.ResourceSummary = &resources, pagination, summary
+	if summary != nil {
+		metadata.Resources = nil
+	}
+	metadata.ResourceFields = o.Fields
+	return nil
+}
+
+func NewResourceSummary(resources DereferencedResourceList) *ResourceSummary {
+	summary := ResourceSummary{Total: len(resources), Resources: []ResourceSummaryEntry{}}
+	entries := map[[2]string]*ResourceSummaryEntry{}
+	for _, r := range resources {
+		key := [2]string{r.ResourceType, r.Name}
+		entry, ok := entries[key]
+		if !ok {
+			entry = &ResourceSummaryEntry{ResourceType: r.ResourceType, Name: r.Name}
+			entries[key] = entry
+		}
+		entry.Count++
+		if r.Created != nil && (entry.LatestCreated == nil || r.Created.After(*entry.LatestCreated)) {
+			entry.LatestCreated = r.Created
+		}
+	}
+
+	for _, entry := range entries {
+		summary.Resources = append(summary.Resources, *entry)
+	}
+	sort.Slice(summary.Resources, func(i, j int) bool {
+		if summary.Resources[i].ResourceType != summary.Resources[j].ResourceType {
+			return summary.Resources[i].ResourceType < summary.Resources[j].ResourceType
+		}
+		return summary.Resources[i].Name < summary.Resources[j].Name
+	})
+	return &summary
+}
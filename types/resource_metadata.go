@@ -5,8 +5,12 @@ import (
 )
 
 type ResolutionResourceMetadata struct {
-	ContentMetadata *DereferencedResource     `json:"metadata,omitempty"`
-	Resources       *DereferencedResourceList `json:"linkedResourceMetadata,omitempty"`
+	ContentMetadata    *DereferencedResource     `json:"metadata,omitempty"`
+	Resources          *DereferencedResourceList `json:"linkedResourceMetadata,omitempty"`
+	ResourcePagination *ResourcePagination       `json:"linkedResourcePagination,omitempty"`
+	ResourceSummary    *ResourceSummary          `json:"linkedResourceSummary,omitempty"`
+	// Fields of linkedResourceMetadata selected by resourceFields query, all fields are returned if empty
+	ResourceFields []string `json:"-"`
 }
 
 func (e *ResolutionResourceMetadata) MarshalJSON() ([]byte, error) {
@@ -15,18 +19,31 @@ func (e *ResolutionResourceMetadata) MarshalJSON() ([]byte, error) {
 		return json.Marshal(e.ContentMetadata)
 	}
 
-	// Otherwise, marshal Resources normally
+	// Otherwise, marshal Resources normally, or only their selected fields
+	var resources any
+	if e.Resources != nil {
+		resources = e.Resources
+		if len(e.ResourceFields) != 0 {
+			resources = e.Resources.Project(e.ResourceFields)
+		}
+	}
 	return json.Marshal(struct {
-		Resources *DereferencedResourceList `json:"linkedResourceMetadata,omitempty"`
+		Resources          any                 `json:"linkedResourceMetadata,omitempty"`
+		ResourcePagination *ResourcePagination `json:"linkedResourcePagination,omitempty"`
+		ResourceSummary    *ResourceSummary    `json:"linkedResourceSummary,omitempty"`
 	}{
-		Resources: e.Resources,
+		Resources:          resources,
+		ResourcePagination: e.ResourcePagination,
+		ResourceSummary:    e.ResourceSummary,
 	})
 }
 
 func (e *ResolutionResourceMetadata) UnmarshalJSON(data []byte) error {
 	// Define a temporary structure to assist with unmarshalling
 	var aux struct {
-		Resources *DereferencedResourceList `json:"linkedResourceMetadata,omitempty"`
+		Resources          *DereferencedResourceList `json:"linkedResourceMetadata,omitempty"`
+		ResourcePagination *ResourcePagination       `json:"linkedResourcePagination,omitempty"`
+		ResourceSummary    *ResourceSummary          `json:"linkedResourceSummary,omitempty"`
 	}
 
 	// First, try to unmarshal into ContentMetadata
@@ -41,6 +58,8 @@ func (e *ResolutionResourceMetadata) UnmarshalJSON(data []byte) error {
 
 	// Assign the extracted Resources
 	e.Resources = aux.Resources
+	e.ResourcePagination = aux.ResourcePagination
+	e.ResourceSummary = aux.ResourceSummary
 	e.ContentMetadata = nil
 	return nil
 }
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cheqd/did-resolver/utils"
+	accept "github.com/timewasted/go-accept-headers"
+)
+
+// Media types of YAML, including the ones used before application/yaml was registered
+var yamlMediaTypes = []string{string(YAML), "application/x-yaml", "text/yaml"}
+
+// NegotiateResourceData returns the representation of resource data which satisfies the Accept header.
+// Data is returned as is if its media type or a DID resolution media type is accepted.
+// JSON resources are also served for application/json and application/ld+json,
+// and transcoded to CBOR and YAML, if the conversion is lossless.
+// Expanded and compacted forms of JSON-LD aren't supported.
+func NegotiateResourceData(acceptHeader string, mediaType ContentType, data []byte) (ContentType, []byte, error) {
+	acceptedTypes := accept.Parse(acceptHeader)
+	if len(acceptedTypes) == 0 {
+		return mediaType, data, nil
+	}
+
+	for _, at := range acceptedTypes {
+		if at.Q == 0 {
+			continue
+		}
+		requested := ContentType(at.Type + "/" + at.Subtype)
+		profile := strings.Trim(at.Extensions["profile"], "\"")
+		if contentType, representation, ok := representResourceData(requested, profile, mediaType, data); ok {
+			return contentType, representation, nil
+		}
+	}
+	return "", nil, fmt.Errorf("resource of %s media type can't be represented as %s", mediaType, acceptHeader)
+}
+
+func representResourceData(requested ContentType, profile string, mediaType ContentType, data []byte) (ContentType, []byte, bool) {
+	isJSON := utils.IsJSONMediaType(string(mediaType))
+	switch {
+	// Forms of JSON-LD, like expanded or compacted, aren't supported, as they need a JSON-LD processor
+	case requested == JSONLD && profile != "":
+		return "", nil, false
+	case requested == JSONLD && isJSON && utils.HasJSONLDContext(data):
+		return JSONLD, data, true
+	case matchesMediaRange(requested, mediaType):
+		return mediaType, data, true
+	// Resources of any media type have always been served for the DID resolution media types
+	case requested == DIDJSON || requested == DIDJSONLD:
+		return mediaType, data, true
+	case !isJSON:
+		return "", nil, false
+	case requested == JSON || requested == JSONLD:
+		return mediaType, data, true
+	case requested == CBOR:
+		representation, err := utils.JSONToCBOR(data)
+		return CBOR, representation, err == nil
+	case utils.Contains(yamlMediaTypes, string(requested)):
+		representation, err := utils.JSONToYAML(data)
+		return requested, representation, err == nil
+	}
+	return "", nil, false
+}
+
+func matchesMediaRange(mediaRange ContentType, mediaType ContentType) bool {
+	rangeType, rangeSubtype, _ := strings.Cut(string(mediaRange), "/")
+	base := utils.BaseMediaType(string(mediaType))
+	mediaTypeType, mediaTypeSubtype, _ := strings.Cut(base, "/")
+	return (rangeType == "*" || rangeType == mediaTypeType) && (rangeSubtype == "*" || rangeSubtype == mediaTypeSubtype)
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/cheqd/did-resolver/utils"
+)
+
+// SchemaValidation is the result of validation of resource data against JSON Schema stored as another resource
+type SchemaValidation struct {
+	Schema string   `json:"schema" example:"did:cheqd:testnet:55dbc8bf-fba3-4117-855c-1e0dc1d3bb47/resources/a7e7d4e6-5b3c-4f3e-9d2a-0e4f1c2b3a4d"`
+	Valid  bool     `json:"valid" example:"true"`
+	Errors []string `json:"errors,omitempty" example:"at '/name': got number, want string"`
+}
+
+// ResourceDidUrl identifies a resource either by its id or by the name and type of its latest version
+type ResourceDidUrl struct {
+	Did          string
+	ResourceId   string
+	ResourceName string
+	ResourceType string
+}
+
+// ParseResourceDidUrl parses DID URL of a resource, which may be also a URL of this resolver,
+// e.g. https://resolver.cheqd.net/1.0/identifiers/did:cheqd:mainnet:...
+func ParseResourceDidUrl(didUrl string) (ResourceDidUrl, error) {
+	if i := strings.Index(didUrl, RESOLVER_PATH); i != -1 {
+		unescaped, err := url.PathUnescape(didUrl[i+len(RESOLVER_PATH):])
+		if err != nil {
+			return ResourceDidUrl{}, err
+		}
+		didUrl = unescaped
+	}
+
+	did, path, query, _, err := utils.TrySplitDIDUrl(didUrl)
+	if err != nil {
+		return ResourceDidUrl{}, err
+	}
+
+	switch {
+	case strings.HasPrefix(path, RESOURCE_PATH):
+		return ResourceDidUrl{Did: did, ResourceId: strings.TrimPrefix(path, RESOURCE_PATH)}, nil
+	case path != "":
+		return ResourceDidUrl{}, errors.New("DID URL doesn't point to a resource")
+	}
+
+	queries, err := url.ParseQuery(query)
+	if err != nil {
+		return ResourceDidUrl{}, err
+	}
+	return ResourceDidUrl{
+		Did:          did,
+		ResourceId:   queries.Get(ResourceId),
+		ResourceName: queries.Get(ResourceName),
+		ResourceType: queries.Get(ResourceType),
+	}, nil
+}
+
+// GetSchemaDidUrl returns $schema of JSON resource data if it's a DID URL.
+// Other values, like the URL of JSON Schema metaschema, are ignored.
+func GetSchemaDidUrl(data []byte) string {
+	var document struct {
+		Schema string `json:"$schema"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return ""
+	}
+	if !strings.HasPrefix(document.Schema, "did:") {
+		return ""
+	}
+	return document.Schema
+}
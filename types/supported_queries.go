@@ -42,8 +42,13 @@ var DidSupportedQueries = SupportedQueriesT{
 	TransformKeys,
 	ResourceMetadata,
 	ServiceQ,
+	ServiceTypeQ,
 	RelativeRef,
 	Metadata,
+	ResourceLimitQ,
+	ResourceCursorQ,
+	ResourceSortQ,
+	ResourceFieldsQ,
 }
 
 var DidResolutionQueries = SupportedQueriesT{
@@ -51,6 +56,7 @@ var DidResolutionQueries = SupportedQueriesT{
 	VersionTime,
 	TransformKeys,
 	ServiceQ,
+	ServiceTypeQ,
 	RelativeRef,
 }
 
@@ -63,12 +69,43 @@ var ResourceSupportedQueries = SupportedQueriesT{
 	ResourceVersion,
 	ResourceVersionTime,
 	ResourceChecksum,
+	ValidateAgainstQ,
 }
 
 var ResourceAmbiguousQueries = SupportedQueriesT{
 	ResourceCollectionId,
 	ResourceVersion,
 	ResourceVersionTime,
+	ValidateAgainstQ,
+}
+
+var DidDocDiffQueries = SupportedQueriesT{
+	DiffFrom,
+	DiffTo,
+}
+
+// ResourceListQueries paginate, sort and select fields of linkedResourceMetadata
+var ResourceListQueries = SupportedQueriesT{
+	ResourceMetadata,
+	ResourceLimitQ,
+	ResourceCursorQ,
+	ResourceSortQ,
+	ResourceFieldsQ,
+}
+
+// ResourceCollectionQueries filter, paginate and sort the listing of resource collection
+var ResourceCollectionQueries = ResourceListQueries.Plus(SupportedQueriesT{
+	ResourceType,
+	ResourceName,
+	MediaTypeQ,
+	CreatedAfterQ,
+	CreatedBeforeQ,
+	ResourceVersion,
+})
+
+// ResourceDataWithMetadataQueries are allowed for resource data returned with dereferencing metadata
+var ResourceDataWithMetadataQueries = SupportedQueriesT{
+	ValidateAgainstQ,
 }
 
 var AllSupportedQueries = DidSupportedQueries.Plus(ResourceSupportedQueries)
@@ -77,6 +114,7 @@ var SupportedQueriesWithTransformKeys = []string{
 	VersionId,
 	VersionTime,
 	ServiceQ,
+	ServiceTypeQ,
 	RelativeRef,
 }
 
@@ -93,3 +131,21 @@ func IsSupportedWithCombinationTransformKeysQuery(values url.Values) bool {
 
 	return true
 }
+
+var ControllerAuthorizationQueries = SupportedQueriesT{
+	VerificationMethodQ,
+	RelationshipQ,
+}
+
+var AnonCredsStatusListQueries = SupportedQueriesT{
+	RevRegDefIdQ,
+	ResourceName,
+	TimestampQ,
+}
+
+var CredentialStatusQueries = SupportedQueriesT{
+	StatusListCredentialQ,
+	StatusListIndexQ,
+	StatusPurposeQ,
+	StatusSizeQ,
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Multibase prefix of base64url encoding used by Bitstring Status List
+const multibaseBase64UrlPrefix = "u"
+
+// MaxStatusListSize limits the decompressed bitstring, so a crafted status list can't exhaust the memory.
+// It's far above lists of any real size, e.g. 16 MiB hold 134 million single-bit entries.
+const MaxStatusListSize = 16 << 20
+
+// DecodeStatusList decodes GZIP compressed and base64 encoded bitstring of
+// Bitstring Status List or StatusList2021 credential
+func DecodeStatusList(encodedList string) ([]byte, error) {
+	// GZIP data always starts with "H4sI" in base64, so the prefix is not ambiguous
+	encodedList = strings.TrimPrefix(encodedList, multibaseBase64UrlPrefix)
+
+	var compressed []byte
+	var err error
+	for _, encoding := range []*base64.Encoding{base64.RawURLEncoding, base64.URLEncoding, base64.StdEncoding, base64.RawStdEncoding} {
+		compressed, err = encoding.DecodeString(encodedList)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encoded list is not base64 encoded: %v", err)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("encoded list is not GZIP compressed: %v", err)
+	}
+	defer reader.Close()
+
+	bitstring, err := io.ReadAll(io.LimitReader(reader, MaxStatusListSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("encoded list is not GZIP compressed: %v", err)
+	}
+	if len(bitstring) > MaxStatusListSize {
+		return nil, fmt.Errorf("decoded list is larger than %d bytes", MaxStatusListSize)
+	}
+	return bitstring, nil
+}
+
+// GetStatusFromBitstring returns the value of statusSize bits at the index.
+// Index 0 is the left-most bit of the first byte.
+func GetStatusFromBitstring(bitstring []byte, index int, statusSize int) (int, error) {
+	if index < 0 || statusSize < 1 {
+		return 0, errors.New("index should be non-negative and status size should be positive")
+	}
+	// Compared without multiplying the index, which comes from the credential and may overflow
+	entries := len(bitstring) * 8 / statusSize
+	if index >= entries {
+		return 0, fmt.Errorf("index %d is out of the status list of %d entries", index, entries)
+	}
+
+	status := 0
+	for position := index * statusSize; position < (index+1)*statusSize; position++ {
+		bit := (bitstring[position/8] >> (7 - position%8)) & 1
+		status = status<<1 | int(bit)
+	}
+	return status, nil
+}
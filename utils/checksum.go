@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// Sha256Checksum returns hex encoded SHA-256 checksum, the same way as it's stored on the ledger
+func Sha256Checksum(data []byte) string {
+	checksum := sha256.Sum256(data)
+	return fmt.Sprintf("%x", checksum)
+}
+
+// ContentDigest returns value of the Content-Digest and Repr-Digest headers as described in RFC 9530
+func ContentDigest(data []byte) string {
+	checksum := sha256.Sum256(data)
+	return fmt.Sprintf("sha-256=:%s:", base64.StdEncoding.EncodeToString(checksum[:]))
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -19,7 +20,7 @@ import (
 // 5 - #([^#]+[\$]?) - group for fragment, starts with #, includes #                              (#key1???)
 // 6 - [^#]+[\$]?    - fragment only															  (key1???)
 // Number of queries is not limited.
-var SplitDIDURLRegexp = regexp.MustCompile(`([^/?#]*)?([^?#]*)(\?([^#]*))?(#([^#]+$))?$`)
+var SplitDIDURLRegexp = regexp.MustCompile(`^([^/?#]*)?([^?#]*)(\?([^#]*))?(#([^#]+$))?$`)
 
 var (
 	DIDPathAbemptyRegexp = regexp.MustCompile(`^([/a-zA-Z0-9\-\.\_\~\!\$\&\'\(\)\*\+\,\;\=\:\@]*|(%[0-9A-Fa-f]{2})*)*$`)
@@ -185,3 +186,47 @@ func TrySplitDID(did string) (method string, namespace string, id string, err er
 	match := matches[0]
 	return match[1], match[3], match[4], nil
 }
+
+// ResolveReference resolves the reference against the base URL as described in RFC 3986, section 5.2
+// JoinURLPath appends the path to the path of the absolute base URL and adds the query to its query, if any.
+// Unlike reference resolution, the last segment of the base path is kept whether or not it ends with slash.
+func JoinURLPath(base string, path string, query string) (string, error) {
+	baseUrl, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	if !baseUrl.IsAbs() {
+		return "", fmt.Errorf("base URL %s is not absolute", base)
+	}
+
+	pathUrl, err := url.Parse("/" + strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return "", err
+	}
+	baseUrl.RawPath = strings.TrimSuffix(baseUrl.EscapedPath(), "/") + pathUrl.EscapedPath()
+	baseUrl.Path = strings.TrimSuffix(baseUrl.Path, "/") + pathUrl.Path
+	switch {
+	case baseUrl.RawQuery == "":
+		baseUrl.RawQuery = query
+	case query != "":
+		baseUrl.RawQuery += "&" + query
+	}
+	return baseUrl.String(), nil
+}
+
+func ResolveReference(base string, reference string) (string, error) {
+	baseUrl, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	if !baseUrl.IsAbs() {
+		return "", fmt.Errorf("base URL %s is not absolute", base)
+	}
+
+	referenceUrl, err := url.Parse(reference)
+	if err != nil {
+		return "", err
+	}
+
+	return baseUrl.ResolveReference(referenceUrl).String(), nil
+}
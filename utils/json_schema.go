@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+var schemaErrorPrinter = message.NewPrinter(language.English)
+
+// SchemaLoader returns JSON Schema referenced by $ref from another schema
+type SchemaLoader func(url string) ([]byte, error)
+
+func (l SchemaLoader) Load(url string) (any, error) {
+	schema, err := l(url)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+}
+
+// ValidateJSONSchema validates JSON data against JSON Schema. Draft 2020-12 is used if the schema doesn't declare
+// another one in $schema. Referenced schemas are read only by load, nothing is fetched from the network.
+// Violations of the schema are returned as messages, the error is returned if the schema can't be compiled.
+func ValidateJSONSchema(schemaUrl string, schema []byte, data []byte, load SchemaLoader) ([]string, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	compiler.UseLoader(load)
+
+	document, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, err
+	}
+	if err := compiler.AddResource(schemaUrl, document); err != nil {
+		return nil, err
+	}
+	compiled, err := compiler.Compile(schemaUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return []string{fmt.Sprintf("data is not valid JSON: %s", err)}, nil
+	}
+
+	err = compiled.Validate(instance)
+	var validationError *jsonschema.ValidationError
+	if errors.As(err, &validationError) {
+		return validationErrorMessages(validationError), nil
+	}
+	return nil, err
+}
+
+// validationErrorMessages returns messages of the violated keywords, i.e. the leaves of the error tree
+func validationErrorMessages(err *jsonschema.ValidationError) []string {
+	if len(err.Causes) == 0 {
+		location := "/" + strings.Join(err.InstanceLocation, "/")
+		return []string{fmt.Sprintf("at '%s': %s", location, err.ErrorKind.LocalizedString(schemaErrorPrinter))}
+	}
+
+	var messages []string
+	for _, cause := range err.Causes {
+		messages = append(messages, validationErrorMessages(cause)...)
+	}
+	return messages
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	jsonMediaType   = "application/json"
+	jsonLDMediaType = "application/ld+json"
+)
+
+// IsJSONMediaType checks if the media type is JSON or has +json structured syntax suffix, like application/ld+json
+func IsJSONMediaType(mediaType string) bool {
+	base := BaseMediaType(mediaType)
+	return base == jsonMediaType || strings.HasSuffix(base, "+json")
+}
+
+// BaseMediaType returns the media type without parameters in lower case
+func BaseMediaType(mediaType string) string {
+	base, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+	}
+	return base
+}
+
+// SniffMediaType detects the media type of the data. JSON and JSON-LD are detected by the syntax,
+// other formats by their signatures as described in https://mimesniff.spec.whatwg.org
+func SniffMediaType(data []byte) string {
+	if json.Valid(data) {
+		if HasJSONLDContext(data) {
+			return jsonLDMediaType
+		}
+		return jsonMediaType
+	}
+	return BaseMediaType(http.DetectContentType(data))
+}
+
+// ResolveMediaType returns the stored media type of the data unless it's missing or contradicts the data.
+// JSON media types are checked by the syntax. Others are replaced only by the formats with reliable signatures,
+// e.g. images, because plain text and generic containers like zip match too many media types.
+func ResolveMediaType(stored string, data []byte) string {
+	if strings.TrimSpace(stored) == "" {
+		return SniffMediaType(data)
+	}
+
+	if IsJSONMediaType(stored) {
+		if json.Valid(data) {
+			return stored
+		}
+		return SniffMediaType(data)
+	}
+
+	sniffed := SniffMediaType(data)
+	if isReliableSignature(sniffed) && sniffed != BaseMediaType(stored) {
+		return sniffed
+	}
+	return stored
+}
+
+func isReliableSignature(mediaType string) bool {
+	switch strings.Split(mediaType, "/")[0] {
+	case "image", "audio", "video", "font":
+		return true
+	}
+	return mediaType == "application/pdf" || mediaType == "application/wasm"
+}
+
+// HasJSONLDContext checks if the JSON document or every document of the JSON array has @context
+func HasJSONLDContext(data []byte) bool {
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return false
+	}
+
+	switch document := document.(type) {
+	case map[string]interface{}:
+		_, ok := document["@context"]
+		return ok
+	case []interface{}:
+		for _, d := range document {
+			node, ok := d.(map[string]interface{})
+			if !ok {
+				return false
+			}
+			if _, ok := node["@context"]; !ok {
+				return false
+			}
+		}
+		return len(document) != 0
+	}
+	return false
+}
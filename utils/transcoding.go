@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// JSONToCBOR converts JSON document to deterministically encoded CBOR.
+// Integers which don't fit 64 bits are encoded as bignums. Other numbers are converted
+// only if float64 keeps them exactly as written, otherwise the conversion is not lossless.
+func JSONToCBOR(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("data after the JSON document")
+	}
+
+	value, err := toCBORValue(document)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder, err := cbor.CoreDetEncOptions().EncMode()
+	if err != nil {
+		return nil, err
+	}
+	return encoder.Marshal(value)
+}
+
+func toCBORValue(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			converted, err := toCBORValue(v)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = converted
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for i, v := range value {
+			converted, err := toCBORValue(v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	case json.Number:
+		return toCBORNumber(value)
+	}
+	return value, nil
+}
+
+func toCBORNumber(number json.Number) (interface{}, error) {
+	if i, err := number.Int64(); err == nil {
+		return i, nil
+	}
+	if i, ok := new(big.Int).SetString(number.String(), 10); ok {
+		return i, nil
+	}
+
+	f, err := number.Float64()
+	if err != nil {
+		return nil, err
+	}
+	// The shortest representation of float64 should be the same number as written in JSON
+	expected, _ := new(big.Rat).SetString(number.String())
+	actual, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	if expected == nil || !ok || expected.Cmp(actual) != 0 {
+		return nil, fmt.Errorf("number %s can't be represented exactly", number)
+	}
+	return f, nil
+}
+
+// JSONToYAML converts JSON document to YAML keeping the order of keys and the notation of numbers
+func JSONToYAML(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	node, err := decodeYAMLNode(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("data after the JSON document")
+	}
+
+	return yaml.Marshal(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}})
+}
+
+func decodeYAMLNode(decoder *json.Decoder) (*yaml.Node, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if token == '{' {
+			node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+		for decoder.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			value, err := decodeYAMLNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, value)
+		}
+		// Closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: token}, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(token.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: token.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(token)}, nil
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+}
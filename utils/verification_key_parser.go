@@ -1,16 +1,22 @@
 package utils
 
 import (
+	"bytes"
+	"crypto/ed25519"
 	"encoding/base64"
+	"errors"
 	"fmt"
 
 	"github.com/mr-tron/base58"
 	"github.com/multiformats/go-multibase"
 )
 
+// Multicodec prefix of Ed25519 public keys in publicKeyMultibase
+var ed25519MulticodecPrefix = []byte{0xed, 0x01}
+
 func Ed25519VerificationKey2018ToEd25519VerificationKey2020(publicKeyBase58 string) (string, error) {
 	// get public key from Ed25519VerificationKey2018 key
-	pubKey, err := base58.Decode(publicKeyBase58)
+	pubKey, err := decodeEd25519VerificationKey2018(publicKeyBase58)
 	if err != nil {
 		return "", err
 	}
@@ -21,7 +27,7 @@ func Ed25519VerificationKey2018ToEd25519VerificationKey2020(publicKeyBase58 stri
 
 func Ed25519VerificationKey2018ToJSONWebKey2020(publicKeyBase58 string) (interface{}, error) {
 	// get public key from Ed25519VerificationKey2018 key
-	pubKey, err := base58.Decode(publicKeyBase58)
+	pubKey, err := decodeEd25519VerificationKey2018(publicKeyBase58)
 	if err != nil {
 		return "", err
 	}
@@ -32,10 +38,7 @@ func Ed25519VerificationKey2018ToJSONWebKey2020(publicKeyBase58 string) (interfa
 
 func Ed25519VerificationKey2020ToEd25519VerificationKey2018(publicKeyMultibase string) (string, error) {
 	// get public key from Ed25519VerificationKey2020 key
-	encoding, pubKey, err := multibase.Decode(publicKeyMultibase)
-	if encoding != multibase.Base58BTC {
-		return "", fmt.Errorf("Only Base58BTC encoding is supported")
-	}
+	pubKey, err := decodeEd25519VerificationKey2020(publicKeyMultibase)
 	if err != nil {
 		return "", err
 	}
@@ -46,10 +49,7 @@ func Ed25519VerificationKey2020ToEd25519VerificationKey2018(publicKeyMultibase s
 
 func Ed25519VerificationKey2020ToJSONWebKey2020(publicKeyMultibase string) (interface{}, error) {
 	// get public key from Ed25519VerificationKey2020 key
-	encoding, pubKey, err := multibase.Decode(publicKeyMultibase)
-	if encoding != multibase.Base58BTC {
-		return "", fmt.Errorf("Only Base58BTC encoding is supported")
-	}
+	pubKey, err := decodeEd25519VerificationKey2020(publicKeyMultibase)
 	if err != nil {
 		return "", err
 	}
@@ -60,8 +60,7 @@ func Ed25519VerificationKey2020ToJSONWebKey2020(publicKeyMultibase string) (inte
 
 func JSONWebKey2020ToEd25519VerificationKey2018(publicKeyJwk interface{}) (string, error) {
 	// get the public key from JSONWebKey2020
-	jwk := publicKeyJwk.(map[string]interface{})
-	pubKey, err := base64.RawURLEncoding.DecodeString(jwk["x"].(string))
+	pubKey, err := decodeJSONWebKey2020(publicKeyJwk)
 	if err != nil {
 		return "", err
 	}
@@ -72,8 +71,7 @@ func JSONWebKey2020ToEd25519VerificationKey2018(publicKeyJwk interface{}) (strin
 
 func JSONWebKey2020ToEd25519VerificationKey2020(publicKeyJwk interface{}) (string, error) {
 	// get the public key from JSONWebKey2020
-	jwk := publicKeyJwk.(map[string]interface{})
-	pubKey, err := base64.RawURLEncoding.DecodeString(jwk["x"].(string))
+	pubKey, err := decodeJSONWebKey2020(publicKeyJwk)
 	if err != nil {
 		return "", err
 	}
@@ -81,3 +79,50 @@ func JSONWebKey2020ToEd25519VerificationKey2020(publicKeyJwk interface{}) (strin
 	// generate Ed25519VerificationKey2020 key
 	return GenerateEd25519VerificationKey2020(pubKey)
 }
+
+func decodeEd25519VerificationKey2018(publicKeyBase58 string) (ed25519.PublicKey, error) {
+	pubKey, err := base58.Decode(publicKeyBase58)
+	if err != nil {
+		return nil, err
+	}
+	return validateEd25519PublicKey(pubKey)
+}
+
+// decodeEd25519VerificationKey2020 strips the multicodec prefix, which isn't a part of the key.
+// Before, the prefix was kept, so transformed publicKeyBase58 and JWK "x" values were 34 bytes long.
+func decodeEd25519VerificationKey2020(publicKeyMultibase string) (ed25519.PublicKey, error) {
+	encoding, pubKey, err := multibase.Decode(publicKeyMultibase)
+	if err != nil {
+		return nil, err
+	}
+	if encoding != multibase.Base58BTC {
+		return nil, fmt.Errorf("Only Base58BTC encoding is supported")
+	}
+	if !bytes.HasPrefix(pubKey, ed25519MulticodecPrefix) {
+		return nil, errors.New("publicKeyMultibase must have ed25519-pub multicodec prefix")
+	}
+	return validateEd25519PublicKey(pubKey[len(ed25519MulticodecPrefix):])
+}
+
+func decodeJSONWebKey2020(publicKeyJwk interface{}) (ed25519.PublicKey, error) {
+	jwk, ok := publicKeyJwk.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("publicKeyJwk must be a JSON object")
+	}
+	x, ok := jwk["x"].(string)
+	if !ok {
+		return nil, errors.New("publicKeyJwk must have x parameter")
+	}
+	pubKey, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil {
+		return nil, err
+	}
+	return validateEd25519PublicKey(pubKey)
+}
+
+func validateEd25519PublicKey(pubKey []byte) (ed25519.PublicKey, error) {
+	if len(pubKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("Ed25519 public key must be %d bytes, got %d", ed25519.PublicKeySize, len(pubKey))
+	}
+	return pubKey, nil
+}